@@ -0,0 +1,233 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Fftbench measures the speed of the fft package across transform sizes,
+// worker counts and algorithms, printing a table, CSV or JSON for tracking
+// performance regressions.
+//
+// Usage:
+//
+//	fftbench [flags]
+//
+// Sizes are the powers of two from -min to -max. The radix2 and real
+// algorithms transform those sizes; bluestein transforms the next larger size
+// with a prime factor above 7, which forces the Bluestein algorithm. The reported MFLOPS are
+// 5*n*log2(n)/time, the conventional estimate for a complex FFT.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/mjibson/go-dsp/fft"
+)
+
+var (
+	flagMin     = flag.Int("min", 64, "smallest transform size, rounded up to a power of two")
+	flagMax     = flag.Int("max", 1<<16, "largest transform size")
+	flagWorkers = flag.String("workers", "0", "comma-separated worker pool sizes; 0 uses GOMAXPROCS")
+	flagAlgo    = flag.String("algo", "radix2,bluestein,real", "comma-separated algorithms: radix2, bluestein, real")
+	flagTime    = flag.Duration("time", 200*time.Millisecond, "minimum measurement time per result")
+	flagFormat  = flag.String("format", "text", "output format: text, csv or json")
+)
+
+// result is the measurement of one configuration.
+type result struct {
+	Algo       string  `json:"algo"`
+	Size       int     `json:"size"`
+	Workers    int     `json:"workers"`
+	Iterations int     `json:"iterations"`
+	NsPerOp    float64 `json:"ns_per_op"`
+	MFLOPS     float64 `json:"mflops"`
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("fftbench: ")
+	flag.Parse()
+
+	workers, err := parseInts(*flagWorkers)
+	if err != nil {
+		log.Fatal(err)
+	}
+	algos := strings.Split(*flagAlgo, ",")
+	for _, a := range algos {
+		if _, ok := algorithms[a]; !ok {
+			log.Fatalf("unknown algorithm: %q", a)
+		}
+	}
+	if *flagMin < 1 || *flagMax < *flagMin {
+		log.Fatal("sizes must satisfy 1 <= min <= max")
+	}
+
+	var results []result
+	for _, a := range algos {
+		for _, w := range workers {
+			for n := nextPow2(*flagMin); n <= *flagMax; n *= 2 {
+				results = append(results, measure(a, n, w, *flagTime))
+			}
+		}
+	}
+	if err := write(os.Stdout, results, *flagFormat); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func parseInts(s string) ([]int, error) {
+	var r []int
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.Atoi(strings.TrimSpace(f))
+		if err != nil {
+			return nil, err
+		}
+		if v < 0 {
+			return nil, fmt.Errorf("negative worker count: %v", v)
+		}
+		r = append(r, v)
+	}
+	return r, nil
+}
+
+func nextPow2(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// algorithms returns a function transforming a random input for each
+// algorithm, given the nominal size.
+var algorithms = map[string]func(n int) func(){
+	"radix2": func(n int) func() {
+		x := randComplex(n)
+		return func() { fft.FFT(x) }
+	},
+	"bluestein": func(n int) func() {
+		x := randComplex(bluesteinSize(n))
+		return func() { fft.FFT(x) }
+	},
+	"real": func(n int) func() {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = rand.Float64()
+		}
+		return func() { fft.FFTReal(x) }
+	},
+}
+
+// bluesteinSize returns the smallest size above n with a prime factor larger
+// than 7, which the fft package transforms with the Bluestein algorithm.
+func bluesteinSize(n int) int {
+	for m := n + 1; ; m++ {
+		r := m
+		for _, p := range []int{2, 3, 5, 7} {
+			for r%p == 0 {
+				r /= p
+			}
+		}
+		if r > 1 {
+			return m
+		}
+	}
+}
+
+func randComplex(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(rand.Float64(), rand.Float64())
+	}
+	return x
+}
+
+// measure times the algorithm at size n with the worker pool size w,
+// doubling the iterations until they take at least d.
+func measure(algo string, n, w int, d time.Duration) result {
+	fft.SetWorkerPoolSize(w)
+	f := algorithms[algo](n)
+	f() // precompute factors
+
+	var elapsed time.Duration
+	iter := 1
+	for {
+		start := time.Now()
+		for i := 0; i < iter; i++ {
+			f()
+		}
+		elapsed = time.Since(start)
+		if elapsed >= d {
+			break
+		}
+		iter *= 2
+	}
+	ns := float64(elapsed.Nanoseconds()) / float64(iter)
+	return result{
+		Algo:       algo,
+		Size:       n,
+		Workers:    w,
+		Iterations: iter,
+		NsPerOp:    ns,
+		MFLOPS:     5 * float64(n) * math.Log2(float64(n)) / ns * 1e3,
+	}
+}
+
+// write writes results to w in the format.
+func write(w io.Writer, results []result, format string) error {
+	header := []string{"algo", "size", "workers", "iterations", "ns_per_op", "mflops"}
+	row := func(r result) []string {
+		return []string{
+			r.Algo,
+			strconv.Itoa(r.Size),
+			strconv.Itoa(r.Workers),
+			strconv.Itoa(r.Iterations),
+			strconv.FormatFloat(r.NsPerOp, 'f', 1, 64),
+			strconv.FormatFloat(r.MFLOPS, 'f', 1, 64),
+		}
+	}
+	switch format {
+	case "text":
+		t := tabwriter.NewWriter(w, 0, 8, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(t, strings.Join(header, "\t")+"\t")
+		for _, r := range results {
+			fmt.Fprintln(t, strings.Join(row(r), "\t")+"\t")
+		}
+		return t.Flush()
+	case "csv":
+		c := csv.NewWriter(w)
+		c.Write(header)
+		for _, r := range results {
+			c.Write(row(r))
+		}
+		c.Flush()
+		return c.Error()
+	case "json":
+		e := json.NewEncoder(w)
+		e.SetIndent("", "\t")
+		return e.Encode(results)
+	}
+	return fmt.Errorf("unknown format: %q", format)
+}
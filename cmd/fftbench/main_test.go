@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMeasure(t *testing.T) {
+	for algo := range algorithms {
+		r := measure(algo, 64, 1, time.Millisecond)
+		if r.Algo != algo || r.Size != 64 || r.Iterations < 1 || r.NsPerOp <= 0 || r.MFLOPS <= 0 {
+			t.Error("measure error:", r)
+		}
+	}
+}
+
+func TestWrite(t *testing.T) {
+	results := []result{
+		{"radix2", 64, 1, 1024, 512.25, 3.75},
+		{"real", 128, 0, 512, 1000, 4.5},
+	}
+
+	var b bytes.Buffer
+	if err := write(&b, results, "csv"); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := []string{"radix2", "64", "1", "1024", "512.2", "3.8"}; len(rows) != 3 || !reflect.DeepEqual(rows[1], e) {
+		t.Error("csv error\noutput:", rows, "\nexpected:", e)
+	}
+
+	b.Reset()
+	if err := write(&b, results, "json"); err != nil {
+		t.Fatal(err)
+	}
+	var o []result
+	if err := json.Unmarshal(b.Bytes(), &o); err != nil || !reflect.DeepEqual(o, results) {
+		t.Error("json error\noutput:", o, err)
+	}
+
+	b.Reset()
+	if err := write(&b, results, "text"); err != nil || b.Len() == 0 {
+		t.Error("text error:", err)
+	}
+	if err := write(&b, results, "xml"); err == nil {
+		t.Error("expected format error")
+	}
+}
+
+func TestParseInts(t *testing.T) {
+	if o, err := parseInts("0, 1,4"); err != nil || !reflect.DeepEqual(o, []int{0, 1, 4}) {
+		t.Error("parseInts error:", o, err)
+	}
+	if _, err := parseInts("1,-2"); err == nil {
+		t.Error("parseInts expected error")
+	}
+}
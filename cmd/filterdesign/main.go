@@ -0,0 +1,307 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Filterdesign designs digital filters and prints their coefficients as Go,
+// C or CSV source, optionally writing the frequency response as CSV.
+//
+// Usage:
+//
+//	filterdesign [flags]
+//
+// For example, a 6th order Chebyshev type I lowpass at 1 kHz for 48 kHz
+// audio, as second-order sections:
+//
+//	filterdesign -design cheby1 -n 6 -cutoff 1000 -fs 48000 -sos
+//
+// and a 101 tap equiripple FIR bandpass:
+//
+//	filterdesign -design remez -type bandpass -n 101 -cutoff 300,3400 -width 200 -fs 8000
+//
+// and a 63 tap Blackman windowed FIR highpass:
+//
+//	filterdesign -design firwin -type highpass -n 63 -cutoff 500 -window blackman -fs 8000
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/cmplx"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mjibson/go-dsp/filter"
+	"github.com/mjibson/go-dsp/window"
+)
+
+var (
+	flagDesign   = flag.String("design", "cheby1", "design method: butter, cheby1, cheby2, ellip (IIR), firwin or remez (FIR)")
+	flagType     = flag.String("type", "lowpass", "band type: lowpass, highpass, bandpass or bandstop")
+	flagN        = flag.Int("n", 4, "filter order (IIR) or number of taps (FIR)")
+	flagCutoff   = flag.String("cutoff", "", "comma-separated cutoff frequencies: one for lowpass and highpass, two for bandpass and bandstop")
+	flagFs       = flag.Float64("fs", 2, "sampling frequency, in the units of the cutoff")
+	flagRipple   = flag.Float64("ripple", 1, "cheby1 and ellip passband ripple in dB")
+	flagAtten    = flag.Float64("atten", 40, "cheby2 and ellip stopband attenuation in dB")
+	flagWidth    = flag.Float64("width", 0, "remez transition band width, in the units of the cutoff")
+	flagWindow   = flag.String("window", "hamming", "firwin window function name")
+	flagSos      = flag.Bool("sos", false, "output IIR filters as second-order sections")
+	flagFormat   = flag.String("format", "go", "output format: go, c or csv")
+	flagResponse = flag.String("response", "", "write the frequency response as CSV to this file")
+	flagPoints   = flag.Int("points", 512, "number of frequency response points")
+)
+
+// design is a designed filter: transfer function coefficients b and a, or
+// second-order sections.
+type design struct {
+	b, a []float64
+	sos  [][6]float64
+}
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("filterdesign: ")
+	flag.Parse()
+	if flag.NArg() != 0 || *flagCutoff == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+	cutoff, err := parseFloats(*flagCutoff)
+	if err != nil {
+		log.Fatal(err)
+	}
+	btype, err := parseBandType(*flagType)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	d, err := designFilter(*flagDesign, btype, *flagN, cutoff, *flagFs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := write(os.Stdout, d, *flagFormat); err != nil {
+		log.Fatal(err)
+	}
+
+	if *flagResponse != "" {
+		f, err := os.Create(*flagResponse)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := writeResponse(f, d, *flagPoints, *flagFs); err != nil {
+			log.Fatal(err)
+		}
+		if err := f.Close(); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+func parseFloats(s string) ([]float64, error) {
+	var r []float64
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil {
+			return nil, err
+		}
+		r = append(r, v)
+	}
+	return r, nil
+}
+
+var bandTypes = map[string]filter.BandType{
+	"lowpass":  filter.Lowpass,
+	"highpass": filter.Highpass,
+	"bandpass": filter.Bandpass,
+	"bandstop": filter.Bandstop,
+}
+
+func parseBandType(s string) (filter.BandType, error) {
+	t, ok := bandTypes[s]
+	if !ok {
+		return 0, fmt.Errorf("unknown band type: %q", s)
+	}
+	return t, nil
+}
+
+// designFilter designs the filter with the named method. Invalid arguments
+// are returned as errors rather than panics.
+func designFilter(method string, btype filter.BandType, n int, cutoff []float64, fs float64) (d design, err error) {
+	want := 1
+	if btype == filter.Bandpass || btype == filter.Bandstop {
+		want = 2
+	}
+	if len(cutoff) != want {
+		return d, fmt.Errorf("%v cutoff frequencies needed, got %v", want, len(cutoff))
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	switch method {
+	case "butter":
+		if *flagSos {
+			d.sos = filter.ButterSos(n, cutoff, btype, fs)
+		} else {
+			d.b, d.a = filter.Butter(n, cutoff, btype, fs)
+		}
+	case "cheby1":
+		if *flagSos {
+			d.sos = filter.Cheby1Sos(n, *flagRipple, cutoff, btype, fs)
+		} else {
+			d.b, d.a = filter.Cheby1(n, *flagRipple, cutoff, btype, fs)
+		}
+	case "cheby2":
+		if *flagSos {
+			d.sos = filter.Cheby2Sos(n, *flagAtten, cutoff, btype, fs)
+		} else {
+			d.b, d.a = filter.Cheby2(n, *flagAtten, cutoff, btype, fs)
+		}
+	case "ellip":
+		if *flagSos {
+			d.sos = filter.EllipSos(n, *flagRipple, *flagAtten, cutoff, btype, fs)
+		} else {
+			d.b, d.a = filter.Ellip(n, *flagRipple, *flagAtten, cutoff, btype, fs)
+		}
+	case "firwin":
+		win, err := window.ByName(*flagWindow)
+		if err != nil {
+			return d, err
+		}
+		d.b = filter.Firwin(n, cutoff, win.Symmetric, btype, fs)
+		d.a = []float64{1}
+	case "remez":
+		if *flagWidth <= 0 {
+			return d, fmt.Errorf("remez needs a positive -width")
+		}
+		bands, desired := remezBands(btype, cutoff, *flagWidth, fs)
+		d.b, err = filter.Remez(n, bands, desired, nil, fs, filter.RemezBandpass)
+		d.a = []float64{1}
+	default:
+		return d, fmt.Errorf("unknown design method: %q", method)
+	}
+	return d, err
+}
+
+// remezBands returns the Remez band edges and desired gains for a filter of
+// type btype with transition bands of width centered on cutoff.
+func remezBands(btype filter.BandType, cutoff []float64, width, fs float64) (bands, desired []float64) {
+	bands = []float64{0}
+	for _, c := range cutoff {
+		bands = append(bands, c-width/2, c+width/2)
+	}
+	bands = append(bands, fs/2)
+	switch btype {
+	case filter.Lowpass:
+		desired = []float64{1, 0}
+	case filter.Highpass:
+		desired = []float64{0, 1}
+	case filter.Bandpass:
+		desired = []float64{0, 1, 0}
+	case filter.Bandstop:
+		desired = []float64{1, 0, 1}
+	}
+	return bands, desired
+}
+
+// write writes d's coefficients to w in the format.
+func write(w io.Writer, d design, format string) error {
+	var vec func(name string, v []float64) string
+	var mat func(name string, m [][6]float64) string
+	switch format {
+	case "go":
+		vec = func(name string, v []float64) string {
+			return fmt.Sprintf("var %s = []float64{%s}\n", name, join(v, ", "))
+		}
+		mat = func(name string, m [][6]float64) string {
+			s := fmt.Sprintf("var %s = [][6]float64{\n", name)
+			for _, r := range m {
+				s += fmt.Sprintf("\t{%s},\n", join(r[:], ", "))
+			}
+			return s + "}\n"
+		}
+	case "c":
+		vec = func(name string, v []float64) string {
+			return fmt.Sprintf("static const double %s[%d] = {%s};\n", name, len(v), join(v, ", "))
+		}
+		mat = func(name string, m [][6]float64) string {
+			s := fmt.Sprintf("static const double %s[%d][6] = {\n", name, len(m))
+			for _, r := range m {
+				s += fmt.Sprintf("\t{%s},\n", join(r[:], ", "))
+			}
+			return s + "};\n"
+		}
+	case "csv":
+		vec = func(name string, v []float64) string {
+			return name + "," + join(v, ",") + "\n"
+		}
+		mat = func(name string, m [][6]float64) string {
+			var s string
+			for _, r := range m {
+				s += name + "," + join(r[:], ",") + "\n"
+			}
+			return s
+		}
+	default:
+		return fmt.Errorf("unknown format: %q", format)
+	}
+
+	var s string
+	if d.sos != nil {
+		s = mat("sos", d.sos)
+	} else {
+		s = vec("b", d.b) + vec("a", d.a)
+	}
+	_, err := io.WriteString(w, s)
+	return err
+}
+
+func join(v []float64, sep string) string {
+	s := make([]string, len(v))
+	for i, f := range v {
+		s[i] = strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	return strings.Join(s, sep)
+}
+
+// writeResponse writes the frequency response of d at n frequencies as CSV:
+// frequency, magnitude in dB, and phase in degrees.
+func writeResponse(w io.Writer, d design, n int, fs float64) error {
+	var h []complex128
+	var freqs []float64
+	if d.sos != nil {
+		h, freqs = filter.FreqzSos(d.sos, n, fs)
+	} else {
+		h, freqs = filter.Freqz(d.b, d.a, n, fs)
+	}
+	c := csv.NewWriter(w)
+	c.Write([]string{"freq", "mag_db", "phase_deg"})
+	for i, v := range h {
+		a := cmplx.Abs(v)
+		c.Write([]string{
+			strconv.FormatFloat(freqs[i], 'g', -1, 64),
+			strconv.FormatFloat(20*math.Log10(a+1e-300), 'f', 3, 64),
+			strconv.FormatFloat(cmplx.Phase(v)*180/math.Pi, 'f', 3, 64),
+		})
+	}
+	c.Flush()
+	return c.Error()
+}
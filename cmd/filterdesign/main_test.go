@@ -0,0 +1,120 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strconv"
+	"testing"
+
+	"github.com/mjibson/go-dsp/filter"
+)
+
+func TestWrite(t *testing.T) {
+	d := design{b: []float64{0.5, 0.25}, a: []float64{1, -0.5}}
+	tests := map[string]string{
+		"go":  "var b = []float64{0.5, 0.25}\nvar a = []float64{1, -0.5}\n",
+		"c":   "static const double b[2] = {0.5, 0.25};\nstatic const double a[2] = {1, -0.5};\n",
+		"csv": "b,0.5,0.25\na,1,-0.5\n",
+	}
+	for format, e := range tests {
+		var b bytes.Buffer
+		if err := write(&b, d, format); err != nil {
+			t.Fatal(err)
+		}
+		if o := b.String(); o != e {
+			t.Errorf("write error\ninput: %s\noutput: %q\nexpected: %q", format, o, e)
+		}
+	}
+
+	d = design{sos: [][6]float64{{1, 2, 1, 1, 0, 0.25}}}
+	var b bytes.Buffer
+	if err := write(&b, d, "go"); err != nil {
+		t.Fatal(err)
+	}
+	if o, e := b.String(), "var sos = [][6]float64{\n\t{1, 2, 1, 1, 0, 0.25},\n}\n"; o != e {
+		t.Errorf("write sos error\noutput: %q\nexpected: %q", o, e)
+	}
+	if err := write(&b, d, "xml"); err == nil {
+		t.Error("write expected format error")
+	}
+}
+
+func TestDesignFilter(t *testing.T) {
+	*flagWidth = 200
+	d, err := designFilter("remez", filter.Bandpass, 101, []float64{1000, 2000}, 8000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.b) != 101 {
+		t.Error("remez length error:", len(d.b))
+	}
+
+	// The response is near 0 dB in the passband and attenuated outside it.
+	var b bytes.Buffer
+	if err := writeResponse(&b, d, 400, 8000); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&b).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range rows[1:] {
+		f, _ := strconv.ParseFloat(r[0], 64)
+		m, _ := strconv.ParseFloat(r[1], 64)
+		if f > 1200 && f < 1800 && (m < -1 || m > 1) || (f < 800 || f > 2200) && m > -20 {
+			t.Error("response error at", f, ":", m)
+		}
+	}
+
+	for _, method := range []string{"butter", "ellip"} {
+		d, err = designFilter(method, filter.Bandstop, 3, []float64{1000, 2000}, 8000)
+		if err != nil || len(d.b) != 7 || len(d.a) != 7 {
+			t.Error(method, "error:", d, err)
+		}
+	}
+	*flagSos = true
+	d, err = designFilter("ellip", filter.Lowpass, 5, []float64{1000}, 8000)
+	if err != nil || len(d.sos) != 3 {
+		t.Error("ellip sos error:", d, err)
+	}
+	*flagSos = false
+
+	d, err = designFilter("firwin", filter.Highpass, 63, []float64{2000}, 8000)
+	if err != nil || len(d.b) != 63 || len(d.a) != 1 {
+		t.Error("firwin error:", d, err)
+	}
+	if _, err := designFilter("firwin", filter.Highpass, 64, []float64{2000}, 8000); err == nil {
+		t.Error("expected firwin even length error")
+	}
+	*flagWindow = "nonesuch"
+	if _, err := designFilter("firwin", filter.Lowpass, 63, []float64{2000}, 8000); err == nil {
+		t.Error("expected window error")
+	}
+	*flagWindow = "hamming"
+
+	if _, err := designFilter("cheby1", filter.Bandpass, 4, []float64{1000}, 8000); err == nil {
+		t.Error("expected cutoff count error")
+	}
+	if _, err := designFilter("cheby1", filter.Lowpass, 4, []float64{5000}, 8000); err == nil {
+		t.Error("expected cutoff range error")
+	}
+	if _, err := designFilter("bessel", filter.Lowpass, 4, []float64{1000}, 8000); err == nil {
+		t.Error("expected method error")
+	}
+}
@@ -0,0 +1,232 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Spectrogram reads a WAV file and writes its spectrogram as a PNG image
+// and/or CSV table.
+//
+// Usage:
+//
+//	spectrogram [flags] file.wav
+//
+// The image has time increasing to the right and frequency increasing
+// upward, with one pixel per frame and FFT bin. The CSV has one row per
+// frame: the frame's center time in seconds followed by the power spectral
+// density in dB of each bin, with a header row of bin frequencies.
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strconv"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/spectral"
+	"github.com/mjibson/go-dsp/wav"
+	"github.com/mjibson/go-dsp/window"
+)
+
+var (
+	flagWindow  = flag.String("window", "hann", "window function name")
+	flagNFFT    = flag.Int("nfft", 1024, "samples per frame")
+	flagOverlap = flag.Int("overlap", 768, "samples of overlap between frames")
+	flagRange   = flag.Float64("range", 90, "dynamic range in dB of the image, below the peak")
+	flagPNG     = flag.String("png", "", "PNG output file")
+	flagCSV     = flag.String("csv", "", "CSV output file")
+)
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("spectrogram: ")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: spectrogram [flags] file.wav")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 || (*flagPNG == "" && *flagCSV == "") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	if *flagNFFT < 2 || *flagOverlap < 0 || *flagOverlap >= *flagNFFT {
+		log.Fatal("nfft must be at least 2 and overlap between 0 and nfft-1")
+	}
+	win, err := window.ByName(*flagWindow)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	x, fs, err := readWav(flag.Arg(0))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(x) < *flagNFFT {
+		log.Fatal("file shorter than one frame")
+	}
+	s, times, freqs := spectrogram(x, fs, win.Periodic, *flagNFFT, *flagOverlap)
+
+	if *flagPNG != "" {
+		if err := create(*flagPNG, func(w io.Writer) error {
+			return png.Encode(w, render(s, *flagRange))
+		}); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if *flagCSV != "" {
+		if err := create(*flagCSV, func(w io.Writer) error {
+			return writeCSV(w, s, times, freqs)
+		}); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// readWav returns the samples of the named WAV file, with channels mixed to
+// mono and the mean removed, and its sample rate.
+func readWav(name string) ([]float64, float64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	w, err := wav.New(f)
+	if err != nil {
+		return nil, 0, err
+	}
+	all, err := dsputils.ReadAll(w)
+	if err != nil {
+		return nil, 0, err
+	}
+	ch := int(w.NumChannels)
+	x := make([]float64, len(all)/ch)
+	var mean float64
+	for i := range x {
+		for c := 0; c < ch; c++ {
+			x[i] += all[i*ch+c]
+		}
+		x[i] /= float64(ch)
+		mean += x[i]
+	}
+	mean /= float64(len(x))
+	for i := range x {
+		x[i] -= mean
+	}
+	return x, float64(w.SampleRate), nil
+}
+
+// spectrogram returns the power spectral density in dB of each frame of x,
+// sampled at fs and windowed by w, and the frame times and bin frequencies.
+func spectrogram(x []float64, fs float64, w func(int) []float64, nfft, overlap int) (s [][]float64, times, freqs []float64) {
+	s, times, freqs = spectral.Spectrogram(x, fs, &spectral.SpectrogramOptions{
+		PwelchOptions: spectral.PwelchOptions{
+			NFFT:     nfft,
+			Window:   w,
+			Noverlap: overlap,
+		},
+	})
+	for _, row := range s {
+		for j, v := range row {
+			row[j] = 10 * math.Log10(v+1e-30)
+		}
+	}
+	return s, times, freqs
+}
+
+// render returns an image of s, mapping the rng dB below its peak to a
+// color scale.
+func render(s [][]float64, rng float64) image.Image {
+	peak := math.Inf(-1)
+	for _, row := range s {
+		for _, v := range row {
+			peak = math.Max(peak, v)
+		}
+	}
+	h := len(s[0])
+	img := image.NewRGBA(image.Rect(0, 0, len(s), h))
+	for x, row := range s {
+		for y, v := range row {
+			img.Set(x, h-1-y, colorAt((v-peak+rng)/rng))
+		}
+	}
+	return img
+}
+
+// colorScale is a black, blue, red, yellow, white scale.
+var colorScale = []color.RGBA{
+	{0, 0, 0, 255},
+	{40, 0, 140, 255},
+	{200, 30, 80, 255},
+	{250, 180, 0, 255},
+	{255, 255, 255, 255},
+}
+
+// colorAt returns the color at t, from 0 to 1, on the scale.
+func colorAt(t float64) color.RGBA {
+	t = math.Max(0, math.Min(1, t)) * float64(len(colorScale)-1)
+	i := int(t)
+	if i == len(colorScale)-1 {
+		return colorScale[i]
+	}
+	f := t - float64(i)
+	a, b := colorScale[i], colorScale[i+1]
+	mix := func(a, b uint8) uint8 {
+		return uint8(math.Round(float64(a)*(1-f) + float64(b)*f))
+	}
+	return color.RGBA{mix(a.R, b.R), mix(a.G, b.G), mix(a.B, b.B), 255}
+}
+
+// writeCSV writes s as CSV with a header of bin frequencies and a first
+// column of frame center times.
+func writeCSV(w io.Writer, s [][]float64, times, freqs []float64) error {
+	c := csv.NewWriter(w)
+	row := []string{"time"}
+	for _, f := range freqs {
+		row = append(row, strconv.FormatFloat(f, 'g', -1, 64))
+	}
+	if err := c.Write(row); err != nil {
+		return err
+	}
+	for i, frame := range s {
+		row = append(row[:0], strconv.FormatFloat(times[i], 'g', -1, 64))
+		for _, v := range frame {
+			row = append(row, strconv.FormatFloat(v, 'f', 2, 64))
+		}
+		if err := c.Write(row); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// create writes the named file with write.
+func create(name string, write func(io.Writer) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+func TestSpectrogram(t *testing.T) {
+	// A tone at bin 16 of a 128 point FFT.
+	x := make([]float64, 1024)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 16 * float64(i) / 128)
+	}
+	s, times, freqs := spectrogram(x, 8000, window.HannPeriodic, 128, 64)
+	if len(s) != 15 || len(s[0]) != 65 {
+		t.Fatal("spectrogram size error:", len(s), len(s[0]))
+	}
+	for i, row := range s {
+		best := 0
+		for j, v := range row {
+			if v > row[best] {
+				best = j
+			}
+		}
+		if best != 16 {
+			t.Error("spectrogram peak error in frame", i, ":", best)
+		}
+	}
+
+	img := render(s, 60)
+	if b := img.Bounds(); b.Dx() != 15 || b.Dy() != 65 {
+		t.Error("render size error:", b)
+	}
+	// Bin 16 is 16 rows above the bottom, at the top of the color scale.
+	if c := img.At(0, 64-16); c != colorScale[len(colorScale)-1] {
+		t.Error("render peak color error:", c)
+	}
+
+	var buf bytes.Buffer
+	if err := writeCSV(&buf, s, times, freqs); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 16 || len(rows[0]) != 66 || rows[0][2] != "62.5" || rows[1][0] != "0.008" {
+		t.Error("writeCSV error:", rows[0][:3], rows[1][:1])
+	}
+}
@@ -113,3 +113,18 @@ func Segment(x []complex128, segs int, noverlap float64) [][]complex128 {
 
 	return r
 }
+
+// Unwrap returns a copy of the phase angles p, in radians, with jumps
+// greater than π corrected by adding multiples of 2π.
+func Unwrap(p []float64) []float64 {
+	r := make([]float64, len(p))
+	var offset float64
+	for i, v := range p {
+		if i > 0 {
+			d := v - p[i-1]
+			offset -= 2 * math.Pi * math.Floor((d+math.Pi)/(2*math.Pi))
+		}
+		r[i] = v + offset
+	}
+	return r
+}
@@ -17,6 +17,7 @@
 package dsputils
 
 import (
+	"math"
 	"testing"
 )
 
@@ -56,3 +57,11 @@ func TestSegment(t *testing.T) {
 		}
 	}
 }
+
+func TestUnwrap(t *testing.T) {
+	in := []float64{0, 3, -3, -0.5, 2.8, -2.9, 0}
+	out := []float64{0, 3, 2*math.Pi - 3, 2*math.Pi - 0.5, 2.8, 2*math.Pi - 2.9, 2 * math.Pi}
+	if o := Unwrap(in); !PrettyClose(o, out) {
+		t.Error("Unwrap error\ninput:", in, "\noutput:", o, "\nexpected:", out)
+	}
+}
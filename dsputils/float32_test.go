@@ -0,0 +1,35 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"testing"
+)
+
+func TestFloat32(t *testing.T) {
+	x := []float64{0, 1.5, -2.25}
+	f := ToFloat32(x)
+	if e := []float32{0, 1.5, -2.25}; len(f) != len(e) || f[1] != e[1] || f[2] != e[2] {
+		t.Error("ToFloat32 error\ninput:", x, "\noutput:", f, "\nexpected:", e)
+	}
+	if o := ToFloat64(f); !PrettyClose(o, x) {
+		t.Error("ToFloat64 error\ninput:", f, "\noutput:", o, "\nexpected:", x)
+	}
+	if o, e := ToComplex64(f), []complex64{0, 1.5, -2.25}; o[0] != e[0] || o[1] != e[1] || o[2] != e[2] {
+		t.Error("ToComplex64 error\ninput:", f, "\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -16,6 +16,19 @@
 
 package dsputils
 
+import (
+	"errors"
+	"strings"
+)
+
+// Errors returned by MakeMatrixErr and MakeMatrix2Err. MakeMatrix and
+// MakeMatrix2 panic with the same messages, without the package prefix.
+var (
+	ErrInvalidDimensions = errors.New("dsputils: invalid dimensions")
+	ErrSizeMismatch      = errors.New("dsputils: incorrect dimensions")
+	ErrRaggedMatrix      = errors.New("dsputils: ragged array")
+)
+
 // Matrix is a multidimensional matrix of arbitrary size and dimension.
 // It cannot be resized after creation. Arrays in any axis can be set or fetched.
 type Matrix struct {
@@ -35,12 +48,18 @@ type Matrix struct {
 //     4, 3, 2, 1},
 //   []int {2, 3, 4})
 func MakeMatrix(x []complex128, dims []int) *Matrix {
+	return mustMatrix(MakeMatrixErr(x, dims))
+}
+
+// MakeMatrixErr is like MakeMatrix, but returns ErrInvalidDimensions if a
+// dimension is less than 1, or ErrSizeMismatch if len(x) does not match dims.
+func MakeMatrixErr(x []complex128, dims []int) (*Matrix, error) {
 	length := 1
 	offsets := make([]int, len(dims))
 
 	for i := len(dims) - 1; i >= 0; i-- {
 		if dims[i] < 1 {
-			panic("invalid dimensions")
+			return nil, ErrInvalidDimensions
 		}
 
 		offsets[i] = length
@@ -48,27 +67,45 @@ func MakeMatrix(x []complex128, dims []int) *Matrix {
 	}
 
 	if len(x) != length {
-		panic("incorrect dimensions")
+		return nil, ErrSizeMismatch
 	}
 
 	dc := make([]int, len(dims))
 	copy(dc, dims)
-	return &Matrix{x, dc, offsets}
+	return &Matrix{x, dc, offsets}, nil
 }
 
 // MakeMatrix2 is a helper function to convert a 2-d array to a matrix.
 func MakeMatrix2(x [][]complex128) *Matrix {
+	return mustMatrix(MakeMatrix2Err(x))
+}
+
+// mustMatrix panics with the plain strings MakeMatrix and MakeMatrix2 have
+// always panicked with, so callers that recover and match on them still work.
+func mustMatrix(m *Matrix, err error) *Matrix {
+	if err != nil {
+		panic(strings.TrimPrefix(err.Error(), "dsputils: "))
+	}
+	return m
+}
+
+// MakeMatrix2Err is like MakeMatrix2, but returns ErrInvalidDimensions if x
+// is empty, or ErrRaggedMatrix if its rows differ in length.
+func MakeMatrix2Err(x [][]complex128) (*Matrix, error) {
+	if len(x) == 0 {
+		return nil, ErrInvalidDimensions
+	}
 	dims := []int{len(x), len(x[0])}
 	r := make([]complex128, dims[0]*dims[1])
 	for n, v := range x {
 		if len(v) != dims[1] {
-			panic("ragged array")
+			return nil, ErrRaggedMatrix
 		}
 
 		copy(r[n*dims[1]:(n+1)*dims[1]], v)
 	}
 
-	return MakeMatrix(r, dims)
+	return MakeMatrixErr(r, dims)
 }
 
 // Copy returns a new copy of m.
@@ -17,6 +17,7 @@
 package dsputils
 
 import (
+	"errors"
 	"testing"
 )
 
@@ -46,6 +47,47 @@ func TestMakeMatrix(t *testing.T) {
 	checkFloat(t, m.Value(i), v)
 }
 
+func TestMakeMatrixErr(t *testing.T) {
+	if _, err := MakeMatrixErr(make([]complex128, 6), []int{2, 0}); !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("MakeMatrixErr error:", err)
+	}
+	if _, err := MakeMatrixErr(make([]complex128, 5), []int{2, 3}); !errors.Is(err, ErrSizeMismatch) {
+		t.Error("MakeMatrixErr error:", err)
+	}
+	if _, err := MakeMatrix2Err(nil); !errors.Is(err, ErrInvalidDimensions) {
+		t.Error("MakeMatrix2Err error:", err)
+	}
+	if _, err := MakeMatrix2Err([][]complex128{{1, 2}, {3}}); !errors.Is(err, ErrRaggedMatrix) {
+		t.Error("MakeMatrix2Err error:", err)
+	}
+	m, err := MakeMatrix2Err([][]complex128{{1, 2}, {3, 4}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkFloat(t, m.Value([]int{1, 0}), 3)
+}
+
+func TestMakeMatrixPanic(t *testing.T) {
+	tests := []struct {
+		f   func()
+		msg string
+	}{
+		{func() { MakeMatrix(make([]complex128, 6), []int{2, 0}) }, "invalid dimensions"},
+		{func() { MakeMatrix(make([]complex128, 5), []int{2, 3}) }, "incorrect dimensions"},
+		{func() { MakeMatrix2([][]complex128{{1, 2}, {3}}) }, "ragged array"},
+	}
+	for _, v := range tests {
+		func() {
+			defer func() {
+				if r := recover(); r != v.msg {
+					t.Errorf("MakeMatrix panic: %v, expected %q", r, v.msg)
+				}
+			}()
+			v.f()
+		}()
+	}
+}
+
 func checkArr(t *testing.T, have, want []complex128) {
 	if !PrettyCloseC(have, want) {
 		t.Error("have:", have, "want:", want)
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Roots returns the roots of the polynomial with coefficients p, highest
+// power first: p[0]*x^n + p[1]*x^(n-1) + ... + p[n]. Leading zeros in p are
+// ignored. The roots are found with the Aberth-Ehrlich method; as with any
+// numerical method, repeated roots are found with reduced accuracy.
+func Roots(p []float64) []complex128 {
+	for len(p) > 0 && p[0] == 0 {
+		p = p[1:]
+	}
+	if len(p) < 2 {
+		return []complex128{}
+	}
+
+	// Trailing zeros are roots at the origin.
+	var zeros int
+	for len(p) > 1 && p[len(p)-1] == 0 {
+		p = p[:len(p)-1]
+		zeros++
+	}
+
+	n := len(p) - 1
+	r := make([]complex128, n, n+zeros)
+	c := make([]complex128, len(p))
+	for i, v := range p {
+		c[i] = complex(v/p[0], 0)
+	}
+
+	// Start on a circle with radius the geometric mean of the roots' moduli.
+	radius := math.Pow(math.Abs(real(c[n])), 1/float64(n))
+	if radius == 0 || math.IsInf(radius, 0) || math.IsNaN(radius) {
+		radius = 1
+	}
+	for k := range r {
+		r[k] = cmplx.Rect(radius, 2*math.Pi*float64(k)/float64(n)+0.4)
+	}
+
+	for iter := 0; iter < 500; iter++ {
+		done := true
+		for k, z := range r {
+			// Evaluate the polynomial and its derivative with Horner's method.
+			v, d := c[0], complex(0, 0)
+			for _, ci := range c[1:] {
+				d = d*z + v
+				v = v*z + ci
+			}
+			if v == 0 {
+				continue
+			}
+
+			ratio := v / d
+			var sum complex128
+			for j, zj := range r {
+				if j != k {
+					sum += 1 / (z - zj)
+				}
+			}
+			w := ratio / (1 - ratio*sum)
+			r[k] = z - w
+
+			if cmplx.Abs(w) > 1e-15*math.Max(1, cmplx.Abs(z)) {
+				done = false
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	// Clean up imaginary parts of real roots.
+	for k, z := range r {
+		if math.Abs(imag(z)) <= 1e-12*math.Max(1, cmplx.Abs(z)) {
+			r[k] = complex(real(z), 0)
+		}
+	}
+
+	for i := 0; i < zeros; i++ {
+		r = append(r, 0)
+	}
+
+	return r
+}
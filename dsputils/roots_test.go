@@ -0,0 +1,54 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"math/cmplx"
+	"sort"
+	"testing"
+)
+
+type rootsTest struct {
+	in  []float64
+	out []complex128
+}
+
+var rootsTests = []rootsTest{
+	{[]float64{}, []complex128{}},
+	{[]float64{5}, []complex128{}},
+	{[]float64{2, -4}, []complex128{2}},
+	{[]float64{0, 1, -3, 2}, []complex128{1, 2}},
+	{[]float64{1, 0, 1}, []complex128{-1i, 1i}},
+	{[]float64{1, -6, 11, -6}, []complex128{1, 2, 3}},
+	{[]float64{1, 1, 0, 0}, []complex128{-1, 0, 0}},
+	{[]float64{1, 0, 0, 0, -16}, []complex128{-2, -2i, 2i, 2}},
+}
+
+func TestRoots(t *testing.T) {
+	for _, v := range rootsTests {
+		o := Roots(v.in)
+		sort.Slice(o, func(i, j int) bool {
+			if real(o[i]) != real(o[j]) && cmplx.Abs(complex(real(o[i])-real(o[j]), 0)) > 1e-9 {
+				return real(o[i]) < real(o[j])
+			}
+			return imag(o[i]) < imag(o[j])
+		})
+		if !PrettyCloseC(o, v.out) {
+			t.Error("Roots error\ninput:", v.in, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"io"
+)
+
+// SampleReader is the interface for streams of real samples, such as WAV
+// files, generators and filters, read like an io.Reader.
+//
+// ReadFloat64s reads up to len(p) samples into p and returns the number
+// read and any error. At the end of the stream it returns 0, io.EOF.
+// Implementations may return fewer than len(p) samples without an error.
+type SampleReader interface {
+	ReadFloat64s(p []float64) (n int, err error)
+}
+
+// SampleWriter is the interface for sinks of real samples, written like an
+// io.Writer.
+//
+// WriteFloat64s writes the samples of p and returns the number written and
+// any error, which must be non-nil if n < len(p).
+type SampleWriter interface {
+	WriteFloat64s(p []float64) (n int, err error)
+}
+
+// ComplexReader is the interface for streams of complex samples, such as IQ
+// captures, with the semantics of SampleReader.
+type ComplexReader interface {
+	ReadComplex128s(p []complex128) (n int, err error)
+}
+
+// ComplexWriter is the interface for sinks of complex samples, with the
+// semantics of SampleWriter.
+type ComplexWriter interface {
+	WriteComplex128s(p []complex128) (n int, err error)
+}
+
+// SliceReader is a SampleReader reading from a slice.
+type SliceReader struct {
+	x []float64
+}
+
+// NewSliceReader returns a SliceReader reading the samples of x.
+func NewSliceReader(x []float64) *SliceReader {
+	return &SliceReader{x}
+}
+
+// ReadFloat64s implements SampleReader.
+func (r *SliceReader) ReadFloat64s(p []float64) (int, error) {
+	if len(r.x) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.x)
+	r.x = r.x[n:]
+	return n, nil
+}
+
+// SliceWriter is a SampleWriter appending to a slice.
+type SliceWriter struct {
+	Samples []float64
+}
+
+// WriteFloat64s implements SampleWriter.
+func (w *SliceWriter) WriteFloat64s(p []float64) (int, error) {
+	w.Samples = append(w.Samples, p...)
+	return len(p), nil
+}
+
+// ReadAll reads from r until io.EOF and returns the samples read.
+func ReadAll(r SampleReader) ([]float64, error) {
+	var x []float64
+	p := make([]float64, 4096)
+	for {
+		n, err := r.ReadFloat64s(p)
+		x = append(x, p[:n]...)
+		if err == io.EOF {
+			return x, nil
+		} else if err != nil {
+			return x, err
+		}
+	}
+}
+
+// Copy copies samples from src to dst until io.EOF or an error, and returns
+// the number of samples copied.
+func Copy(dst SampleWriter, src SampleReader) (int64, error) {
+	var written int64
+	p := make([]float64, 4096)
+	for {
+		n, err := src.ReadFloat64s(p)
+		if n > 0 {
+			m, werr := dst.WriteFloat64s(p[:n])
+			written += int64(m)
+			if werr != nil {
+				return written, werr
+			}
+			if m < n {
+				return written, io.ErrShortWrite
+			}
+		}
+		if err == io.EOF {
+			return written, nil
+		} else if err != nil {
+			return written, err
+		}
+	}
+}
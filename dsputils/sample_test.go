@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"errors"
+	"io"
+	"testing"
+)
+
+func TestSliceReader(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5}
+	r := NewSliceReader(x)
+	p := make([]float64, 2)
+	var o []float64
+	for {
+		n, err := r.ReadFloat64s(p)
+		o = append(o, p[:n]...)
+		if err == io.EOF {
+			break
+		}
+	}
+	if !PrettyClose(o, x) {
+		t.Error("SliceReader error\ninput:", x, "\noutput:", o)
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	o, err := ReadAll(NewSliceReader(x))
+	if err != nil || !PrettyClose(o, x) {
+		t.Error("ReadAll error:", err)
+	}
+}
+
+type limitWriter struct {
+	SliceWriter
+	n int
+}
+
+func (w *limitWriter) WriteFloat64s(p []float64) (int, error) {
+	if len(w.Samples)+len(p) > w.n {
+		p = p[:w.n-len(w.Samples)]
+		w.SliceWriter.WriteFloat64s(p)
+		return len(p), errors.New("full")
+	}
+	return w.SliceWriter.WriteFloat64s(p)
+}
+
+func TestCopy(t *testing.T) {
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	var w SliceWriter
+	n, err := Copy(&w, NewSliceReader(x))
+	if err != nil || n != 10000 || !PrettyClose(w.Samples, x) {
+		t.Error("Copy error:", n, err)
+	}
+
+	lw := &limitWriter{n: 5000}
+	n, err = Copy(lw, NewSliceReader(x))
+	if err == nil || n != 5000 || !PrettyClose(lw.Samples, x[:5000]) {
+		t.Error("Copy short write error:", n, err)
+	}
+}
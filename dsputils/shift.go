@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+// FFTShift returns x with the zero-frequency component moved to the center,
+// so that the output of an FFT is ordered from the most negative to the most
+// positive frequency. For odd lengths, it is undone by IFFTShift, not by
+// FFTShift.
+func FFTShift(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	h := len(x) / 2
+	copy(r, x[len(x)-h:])
+	copy(r[h:], x[:len(x)-h])
+	return r
+}
+
+// IFFTShift is the inverse of FFTShift.
+func IFFTShift(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	h := len(x) / 2
+	copy(r, x[h:])
+	copy(r[len(x)-h:], x[:h])
+	return r
+}
+
+// FFTShiftF is FFTShift for real-valued slices, such as magnitude or power
+// spectra.
+func FFTShiftF(x []float64) []float64 {
+	r := make([]float64, len(x))
+	h := len(x) / 2
+	copy(r, x[len(x)-h:])
+	copy(r[h:], x[:len(x)-h])
+	return r
+}
+
+// IFFTShiftF is the inverse of FFTShiftF.
+func IFFTShiftF(x []float64) []float64 {
+	r := make([]float64, len(x))
+	h := len(x) / 2
+	copy(r, x[h:])
+	copy(r[len(x)-h:], x[:h])
+	return r
+}
+
+// FFTShift2 returns the 2-D array x with the zero-frequency component moved
+// to the center, shifting both the rows and the columns.
+func FFTShift2(x [][]complex128) [][]complex128 {
+	r := make([][]complex128, len(x))
+	h := len(x) / 2
+	for i, v := range x {
+		r[(i+h)%len(x)] = FFTShift(v)
+	}
+	return r
+}
+
+// IFFTShift2 is the inverse of FFTShift2.
+func IFFTShift2(x [][]complex128) [][]complex128 {
+	r := make([][]complex128, len(x))
+	h := len(x) / 2
+	for i, v := range x {
+		r[(i+len(x)-h)%len(x)] = IFFTShift(v)
+	}
+	return r
+}
+
+// FFTShiftN returns the Matrix m with the zero-frequency component moved to
+// the center, shifting every dimension.
+func FFTShiftN(m *Matrix) *Matrix {
+	return shiftN(m, false)
+}
+
+// IFFTShiftN is the inverse of FFTShiftN.
+func IFFTShiftN(m *Matrix) *Matrix {
+	return shiftN(m, true)
+}
+
+// shiftN circularly shifts each dimension of m by half its length, rounded
+// down, forward or, if inverse, backward.
+func shiftN(m *Matrix, inverse bool) *Matrix {
+	r := MakeEmptyMatrix(m.dims)
+	for i, v := range m.list {
+		o := 0
+		for d, n := range m.dims {
+			k := i / m.offsets[d] % n
+			if inverse {
+				k += n - n/2
+			} else {
+				k += n / 2
+			}
+			o += k % n * m.offsets[d]
+		}
+		r.list[o] = v
+	}
+	return r
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package dsputils
+
+import (
+	"testing"
+)
+
+var shiftTests = []struct {
+	in, shift, ishift []float64
+}{
+	{[]float64{}, []float64{}, []float64{}},
+	{[]float64{0}, []float64{0}, []float64{0}},
+	{[]float64{0, 1, 2, 3}, []float64{2, 3, 0, 1}, []float64{2, 3, 0, 1}},
+	{[]float64{0, 1, 2, -2, -1}, []float64{-2, -1, 0, 1, 2}, []float64{2, -2, -1, 0, 1}},
+}
+
+func TestFFTShift(t *testing.T) {
+	for _, st := range shiftTests {
+		if o := FFTShiftF(st.in); !PrettyClose(o, st.shift) {
+			t.Error("FFTShiftF error\ninput:", st.in, "\noutput:", o, "\nexpected:", st.shift)
+		}
+		if o := IFFTShiftF(st.in); !PrettyClose(o, st.ishift) {
+			t.Error("IFFTShiftF error\ninput:", st.in, "\noutput:", o, "\nexpected:", st.ishift)
+		}
+		if o := IFFTShiftF(FFTShiftF(st.in)); !PrettyClose(o, st.in) {
+			t.Error("IFFTShiftF inverse error\ninput:", st.in, "\noutput:", o)
+		}
+
+		in, e := ToComplex(st.in), ToComplex(st.shift)
+		if o := FFTShift(in); !PrettyCloseC(o, e) {
+			t.Error("FFTShift error\ninput:", in, "\noutput:", o, "\nexpected:", e)
+		}
+		if e := ToComplex(st.ishift); !PrettyCloseC(IFFTShift(in), e) {
+			t.Error("IFFTShift error\ninput:", in, "\noutput:", IFFTShift(in), "\nexpected:", e)
+		}
+	}
+}
+
+func TestFFTShift2(t *testing.T) {
+	x := [][]complex128{
+		{0, 1, 2},
+		{3, 4, 5},
+	}
+	e := [][]complex128{
+		{5, 3, 4},
+		{2, 0, 1},
+	}
+	if o := FFTShift2(x); !PrettyClose2(o, e) {
+		t.Error("FFTShift2 error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+	if o := IFFTShift2(e); !PrettyClose2(o, x) {
+		t.Error("IFFTShift2 error\ninput:", e, "\noutput:", o, "\nexpected:", x)
+	}
+
+	m := MakeMatrix2(x)
+	if o, e := FFTShiftN(m), MakeMatrix2(e); !o.PrettyClose(e) {
+		t.Error("FFTShiftN error\ninput:", x, "\noutput:", o.To2D(), "\nexpected:", e.To2D())
+	}
+	if o := IFFTShiftN(FFTShiftN(m)); !o.PrettyClose(m) {
+		t.Error("IFFTShiftN error\ninput:", x, "\noutput:", o.To2D())
+	}
+}
+
+func TestFFTShiftN(t *testing.T) {
+	// Each dimension of a 2x3x5 matrix is shifted by 1, 1 and 2.
+	x := make([]complex128, 30)
+	for i := range x {
+		x[i] = complex(float64(i), 0)
+	}
+	m := MakeMatrix(x, []int{2, 3, 5})
+	o := FFTShiftN(m)
+	for i := 0; i < 2; i++ {
+		for j := 0; j < 3; j++ {
+			for k := 0; k < 5; k++ {
+				e := m.Value([]int{(i + 1) % 2, (j + 2) % 3, (k + 3) % 5})
+				if v := o.Value([]int{i, j, k}); v != e {
+					t.Error("FFTShiftN error\nindex:", i, j, k, "\noutput:", v, "\nexpected:", e)
+				}
+			}
+		}
+	}
+	if o := IFFTShiftN(o); !o.PrettyClose(m) {
+		t.Error("IFFTShiftN error\noutput:", o, "\nexpected:", m)
+	}
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package emd provides empirical mode decomposition and Hilbert spectral
+// analysis of nonstationary signals.
+package emd
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/mjibson/go-dsp/interp"
+)
+
+// Options controls the sifting process of EMD and EEMD.
+type Options struct {
+	// MaxIMFs is the maximum number of intrinsic mode functions to extract.
+	//
+	// The default value is 0, which extracts IMFs until the residue has too
+	// few extrema. EEMD uses floor(log2(len(x))) instead.
+	MaxIMFs int
+
+	// MaxSifts is the maximum number of sifting iterations per IMF.
+	//
+	// The default value is 0, which uses 50.
+	MaxSifts int
+
+	// SD is the sifting stop threshold: sifting ends when the energy of the
+	// change between iterations relative to the energy of the candidate IMF
+	// falls below SD.
+	//
+	// The default value is 0, which uses 0.2.
+	SD float64
+}
+
+// EMD returns the empirical mode decomposition of x: the intrinsic mode
+// functions from highest to lowest frequency, followed by the residue. The
+// rows sum to x. A nil o uses the default options.
+// Reference: http://dx.doi.org/10.1098/rspa.1998.0193
+func EMD(x []float64, o *Options) [][]float64 {
+	if o == nil {
+		o = &Options{}
+	}
+	maxSifts := o.MaxSifts
+	if maxSifts == 0 {
+		maxSifts = 50
+	}
+	sd := o.SD
+	if sd == 0 {
+		sd = 0.2
+	}
+
+	var r [][]float64
+	res := append([]float64(nil), x...)
+	for o.MaxIMFs == 0 || len(r) < o.MaxIMFs {
+		h, ok := sift(res, maxSifts, sd)
+		if !ok {
+			break
+		}
+		for i := range res {
+			res[i] -= h[i]
+		}
+		r = append(r, h)
+	}
+	return append(r, res)
+}
+
+// sift returns the next IMF of x, or false if x has too few extrema to
+// contain one.
+func sift(x []float64, maxSifts int, sd float64) ([]float64, bool) {
+	h := append([]float64(nil), x...)
+	for s := 0; s < maxSifts; s++ {
+		upper, lower, ok := envelopes(h)
+		if !ok {
+			if s == 0 {
+				return nil, false
+			}
+			break
+		}
+		var num, den float64
+		for i := range h {
+			m := (upper[i] + lower[i]) / 2
+			num += m * m
+			den += h[i] * h[i]
+			h[i] -= m
+		}
+		if den == 0 || num/den < sd {
+			break
+		}
+	}
+	return h, true
+}
+
+// envelopes returns the upper and lower envelopes of x, cubic splines
+// through its maxima and minima, or false if x has fewer than two of either.
+func envelopes(x []float64) (upper, lower []float64, ok bool) {
+	var maxima, minima []int
+	for i := 1; i < len(x)-1; i++ {
+		switch {
+		case x[i] > x[i-1] && x[i] >= x[i+1]:
+			maxima = append(maxima, i)
+		case x[i] < x[i-1] && x[i] <= x[i+1]:
+			minima = append(minima, i)
+		}
+	}
+	if len(maxima) < 2 || len(minima) < 2 {
+		return nil, nil, false
+	}
+	return envelope(x, maxima), envelope(x, minima), true
+}
+
+// envelope returns the cubic spline through x at the extrema idx, extended
+// past the ends of x by mirroring the two outermost extrema.
+func envelope(x []float64, idx []int) []float64 {
+	last := float64(len(x) - 1)
+	var kx, ky []float64
+	for i := 1; i >= 0; i-- {
+		kx = append(kx, -float64(idx[i]))
+		ky = append(ky, x[idx[i]])
+	}
+	for _, i := range idx {
+		kx = append(kx, float64(i))
+		ky = append(ky, x[i])
+	}
+	for i := len(idx) - 1; i >= len(idx)-2; i-- {
+		kx = append(kx, 2*last-float64(idx[i]))
+		ky = append(ky, x[idx[i]])
+	}
+	return interp.NewSpline(kx, ky, interp.Natural).Sample(0, 1, len(x))
+}
+
+// EEMD returns the ensemble empirical mode decomposition of x: the average
+// of the IMFs of trials copies of x with added white Gaussian noise, whose
+// standard deviation is noise times that of x. The noise is seeded with
+// seed. The result has a fixed number of IMFs, followed by the residue; the
+// rows sum to x. A nil o uses the default options.
+// Reference: http://dx.doi.org/10.1142/S1793536909000047
+func EEMD(x []float64, trials int, noise float64, seed int64, o *Options) [][]float64 {
+	if trials < 1 {
+		panic("trials must be positive")
+	}
+	var opts Options
+	if o != nil {
+		opts = *o
+	}
+	if opts.MaxIMFs == 0 {
+		opts.MaxIMFs = int(math.Log2(float64(len(x))))
+		if opts.MaxIMFs < 1 {
+			opts.MaxIMFs = 1
+		}
+	}
+
+	var mean, sd float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	for _, v := range x {
+		sd += (v - mean) * (v - mean)
+	}
+	sd = math.Sqrt(sd/float64(len(x))) * noise
+
+	r := make([][]float64, opts.MaxIMFs+1)
+	for i := range r {
+		r[i] = make([]float64, len(x))
+	}
+	rnd := rand.New(rand.NewSource(seed))
+	y := make([]float64, len(x))
+	for t := 0; t < trials; t++ {
+		for i, v := range x {
+			y[i] = v + sd*rnd.NormFloat64()
+		}
+		imfs := EMD(y, &opts)
+		for k, imf := range imfs[:len(imfs)-1] {
+			for i, v := range imf {
+				r[k][i] += v / float64(trials)
+			}
+		}
+	}
+
+	res := r[len(r)-1]
+	copy(res, x)
+	for _, imf := range r[:len(r)-1] {
+		for i, v := range imf {
+			res[i] -= v
+		}
+	}
+	return r
+}
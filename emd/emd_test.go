@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package emd
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func twoTones(n int) (x, hi, lo []float64) {
+	x = make([]float64, n)
+	hi = make([]float64, n)
+	lo = make([]float64, n)
+	for i := range x {
+		hi[i] = math.Sin(2 * math.Pi * 0.05 * float64(i))
+		lo[i] = 2 * math.Sin(2*math.Pi*0.005*float64(i))
+		x[i] = hi[i] + lo[i]
+	}
+	return
+}
+
+func checkSum(t *testing.T, name string, x []float64, r [][]float64) {
+	sum := make([]float64, len(x))
+	for _, row := range r {
+		for i, v := range row {
+			sum[i] += v
+		}
+	}
+	if !dsputils.PrettyClose(sum, x) {
+		t.Error(name, "sum error")
+	}
+}
+
+// rms returns the RMS difference of a and b over the middle half.
+func rms(a, b []float64) float64 {
+	var s float64
+	n := len(a)
+	for i := n / 4; i < 3*n/4; i++ {
+		s += (a[i] - b[i]) * (a[i] - b[i])
+	}
+	return math.Sqrt(s / float64(n/2))
+}
+
+func TestEMD(t *testing.T) {
+	x, hi, lo := twoTones(2000)
+	r := EMD(x, nil)
+	if len(r) < 3 {
+		t.Fatal("EMD error: too few rows:", len(r))
+	}
+	checkSum(t, "EMD", x, r)
+	if e := rms(r[0], hi); e > 0.05 {
+		t.Error("EMD first IMF error:", e)
+	}
+	if e := rms(r[1], lo); e > 0.1 {
+		t.Error("EMD second IMF error:", e)
+	}
+
+	r = EMD(x, &Options{MaxIMFs: 1})
+	if len(r) != 2 {
+		t.Error("EMD MaxIMFs error:", len(r))
+	}
+	checkSum(t, "EMD MaxIMFs", x, r)
+
+	// A monotonic signal is all residue.
+	r = EMD([]float64{1, 2, 3, 4, 5}, nil)
+	if len(r) != 1 {
+		t.Error("EMD monotonic error:", len(r))
+	}
+}
+
+func TestEEMD(t *testing.T) {
+	x, hi, _ := twoTones(1024)
+	r := EEMD(x, 20, 0.1, 1, nil)
+	if len(r) != 11 {
+		t.Fatal("EEMD length error:", len(r))
+	}
+	checkSum(t, "EEMD", x, r)
+
+	// The noise spreads over the first IMFs; the tone is in one of them.
+	best := math.Inf(1)
+	for _, imf := range r[:4] {
+		if e := rms(imf, hi); e < best {
+			best = e
+		}
+	}
+	if best > 0.3 {
+		t.Error("EEMD IMF error:", best)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package emd
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/filter"
+)
+
+// Instantaneous returns the instantaneous amplitude and frequency of the
+// intrinsic mode function imf sampled at fs, from its analytic signal.
+func Instantaneous(imf []float64, fs float64) (amp, freq []float64) {
+	a := filter.Hilbert(imf)
+	amp = make([]float64, len(a))
+	phase := make([]float64, len(a))
+	for i, v := range a {
+		amp[i] = math.Hypot(real(v), imag(v))
+		phase[i] = math.Atan2(imag(v), real(v))
+	}
+	freq = filter.Gradient(dsputils.Unwrap(phase))
+	for i := range freq {
+		freq[i] *= fs / (2 * math.Pi)
+	}
+	return amp, freq
+}
+
+// HilbertSpectrum returns the Hilbert spectrum of the intrinsic mode
+// functions imfs sampled at fs: the instantaneous amplitude of each IMF at
+// each time, accumulated into nbins frequency bins evenly spanning 0 to
+// fs/2. The result is indexed by bin and then time, and freqs holds the bin
+// centers. Instantaneous frequencies outside the range are ignored. The
+// residue from EMD should not be included in imfs.
+// Reference: http://dx.doi.org/10.1098/rspa.1998.0193
+func HilbertSpectrum(imfs [][]float64, fs float64, nbins int) (h [][]float64, freqs []float64) {
+	if nbins < 1 {
+		panic("nbins must be positive")
+	}
+	var n int
+	if len(imfs) > 0 {
+		n = len(imfs[0])
+	}
+	h = make([][]float64, nbins)
+	for i := range h {
+		h[i] = make([]float64, n)
+	}
+	width := fs / 2 / float64(nbins)
+	freqs = make([]float64, nbins)
+	for i := range freqs {
+		freqs[i] = (float64(i) + 0.5) * width
+	}
+
+	for _, imf := range imfs {
+		amp, freq := Instantaneous(imf, fs)
+		for t, f := range freq {
+			b := int(math.Floor(f / width))
+			if b < 0 || b >= nbins {
+				continue
+			}
+			h[b][t] += amp[t]
+		}
+	}
+	return h, freqs
+}
+
+// MarginalSpectrum returns the marginal Hilbert spectrum of imfs: the
+// Hilbert spectrum summed over time, giving the total amplitude in each
+// frequency bin.
+func MarginalSpectrum(imfs [][]float64, fs float64, nbins int) (m, freqs []float64) {
+	h, freqs := HilbertSpectrum(imfs, fs, nbins)
+	m = make([]float64, nbins)
+	for i, row := range h {
+		for _, v := range row {
+			m[i] += v
+		}
+	}
+	return m, freqs
+}
@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package emd
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInstantaneous(t *testing.T) {
+	const fs, f = 1000.0, 50.0
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = 3 * math.Cos(2*math.Pi*f*float64(i)/fs)
+	}
+	amp, freq := Instantaneous(x, fs)
+	for i := range x {
+		if math.Abs(amp[i]-3) > 1e-9 || math.Abs(freq[i]-f) > 1e-9 {
+			t.Fatal("Instantaneous error at", i, ":", amp[i], freq[i])
+		}
+	}
+}
+
+func TestMarginalSpectrum(t *testing.T) {
+	const fs = 1000.0
+	x := make([]float64, 1000)
+	y := make([]float64, 1000)
+	for i := range x {
+		x[i] = math.Cos(2 * math.Pi * 105 * float64(i) / fs)
+		y[i] = 0.5 * math.Cos(2*math.Pi*315*float64(i)/fs)
+	}
+	m, freqs := MarginalSpectrum([][]float64{x, y}, fs, 50)
+	if len(m) != 50 || freqs[0] != 5 {
+		t.Fatal("MarginalSpectrum bins error:", len(m), freqs[0])
+	}
+	for i, v := range m {
+		var e float64
+		switch i {
+		case 10:
+			e = 1000
+		case 31:
+			e = 500
+		}
+		if math.Abs(v-e) > 1e-6 {
+			t.Error("MarginalSpectrum error at", freqs[i], "\noutput:", v, "\nexpected:", e)
+		}
+	}
+}
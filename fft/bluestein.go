@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"context"
 	"math"
 	"sync"
 
@@ -64,8 +65,9 @@ func hasBluesteinFactors(idx int) bool {
 	return bluesteinFactors[idx] != nil
 }
 
-// bluesteinFFT returns the FFT calculated using the Bluestein algorithm.
-func bluesteinFFT(x []complex128) []complex128 {
+// bluesteinFFT returns the FFT calculated using the Bluestein algorithm. If
+// ctx is cancelled, it returns an incomplete result.
+func bluesteinFFT(ctx context.Context, x []complex128) []complex128 {
 	lx := len(x)
 	a := dsputils.ZeroPad(x, dsputils.NextPowerOf2(lx*2-1))
 	la := len(a)
@@ -84,7 +86,23 @@ func bluesteinFFT(x []complex128) []complex128 {
 		}
 	}
 
-	r := Convolve(a, b)
+	// The circular convolution of a and b, as Convolve, checking ctx
+	// between the transforms.
+	A, err := fftCtx(ctx, a)
+	if err != nil {
+		return nil
+	}
+	B, err := fftCtx(ctx, b)
+	if err != nil {
+		return nil
+	}
+	for i := range A {
+		A[i] *= B[i]
+	}
+	r, err := ifftCtx(ctx, A)
+	if err != nil {
+		return nil
+	}
 
 	for i := 0; i < lx; i++ {
 		r[i] *= invFactors[i]
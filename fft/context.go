@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"context"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// The functions below are like their counterparts without the Ctx suffix,
+// but return ctx.Err() if ctx is cancelled. Multidimensional transforms
+// check ctx before each one-dimensional transform, so they stop early.
+// One-dimensional transforms check it between the stages of the radix-2 and
+// mixed-radix algorithms, and between the transforms of the Bluestein
+// algorithm.
+
+// FFTCtx is like FFT, but returns ctx.Err() if ctx is cancelled.
+func FFTCtx(ctx context.Context, x []complex128) ([]complex128, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return fftCtx(ctx, x)
+}
+
+// IFFTCtx is like IFFT, but returns ctx.Err() if ctx is cancelled.
+func IFFTCtx(ctx context.Context, x []complex128) ([]complex128, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return ifftCtx(ctx, x)
+}
+
+// FFT2Ctx is like FFT2Err, but returns ctx.Err() if ctx is cancelled.
+func FFT2Ctx(ctx context.Context, x [][]complex128) ([][]complex128, error) {
+	return computeFFT2(ctx, x, FFT)
+}
+
+// IFFT2Ctx is like IFFT2Err, but returns ctx.Err() if ctx is cancelled.
+func IFFT2Ctx(ctx context.Context, x [][]complex128) ([][]complex128, error) {
+	return computeFFT2(ctx, x, IFFT)
+}
+
+// FFTNCtx is like FFTN, but returns ctx.Err() if ctx is cancelled.
+func FFTNCtx(ctx context.Context, m *dsputils.Matrix) (*dsputils.Matrix, error) {
+	return computeFFTN(ctx, m, FFT)
+}
+
+// IFFTNCtx is like IFFTN, but returns ctx.Err() if ctx is cancelled.
+func IFFTNCtx(ctx context.Context, m *dsputils.Matrix) (*dsputils.Matrix, error) {
+	return computeFFTN(ctx, m, IFFT)
+}
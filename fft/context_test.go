@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestCtx(t *testing.T) {
+	ctx := context.Background()
+	for _, ft := range fftTests {
+		if len(ft.in) == 0 {
+			continue
+		}
+		x := dsputils.ToComplex(ft.in)
+		if o, err := FFTCtx(ctx, x); err != nil || !dsputils.PrettyCloseC(o, ft.out) {
+			t.Error("FFTCtx error\ninput:", x, "\noutput:", o, err, "\nexpected:", ft.out)
+		}
+		if o, err := IFFTCtx(ctx, ft.out); err != nil || !dsputils.PrettyCloseC(o, x) {
+			t.Error("IFFTCtx error\ninput:", ft.out, "\noutput:", o, err, "\nexpected:", x)
+		}
+	}
+	for _, ft := range fft2Tests {
+		x := dsputils.ToComplex2(ft.in)
+		if o, err := FFT2Ctx(ctx, x); err != nil || !dsputils.PrettyClose2(o, ft.out) {
+			t.Error("FFT2Ctx error\ninput:", x, "\noutput:", o, err, "\nexpected:", ft.out)
+		}
+		if o, err := IFFT2Ctx(ctx, ft.out); err != nil || !dsputils.PrettyClose2(o, x) {
+			t.Error("IFFT2Ctx error\ninput:", ft.out, "\noutput:", o, err, "\nexpected:", x)
+		}
+	}
+	for _, ft := range fftnTests {
+		m := dsputils.MakeMatrix(dsputils.ToComplex(ft.in), ft.dim)
+		e := dsputils.MakeMatrix(ft.out, ft.dim)
+		if o, err := FFTNCtx(ctx, m); err != nil || !o.PrettyClose(e) {
+			t.Error("FFTNCtx error\ninput:", m, "\noutput:", o, err, "\nexpected:", e)
+		}
+		if o, err := IFFTNCtx(ctx, e); err != nil || !o.PrettyClose(m) {
+			t.Error("IFFTNCtx error\ninput:", e, "\noutput:", o, err, "\nexpected:", m)
+		}
+	}
+}
+
+func TestCtxCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := FFTCtx(ctx, make([]complex128, 8)); err != context.Canceled {
+		t.Error("FFTCtx error:", err)
+	}
+	if _, err := IFFT2Ctx(ctx, [][]complex128{{1, 2}, {3, 4}}); err != context.Canceled {
+		t.Error("IFFT2Ctx error:", err)
+	}
+	m := dsputils.MakeEmptyMatrix([]int{2, 2, 2})
+	if _, err := FFTNCtx(ctx, m); err != context.Canceled {
+		t.Error("FFTNCtx error:", err)
+	}
+}
+
+// cancelAfter is a context that is cancelled after its Err method has been
+// called n times.
+type cancelAfter struct {
+	context.Context
+	n int32
+}
+
+func (c *cancelAfter) Err() error {
+	if atomic.AddInt32(&c.n, -1) < 0 {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestCtxCancelDuring(t *testing.T) {
+	// Each algorithm notices a cancellation during the transform.
+	for _, n := range []int{1 << 12, 3 << 12, 4099} {
+		x := make([]complex128, n)
+		if _, err := FFTCtx(&cancelAfter{context.Background(), 3}, x); err != context.Canceled {
+			t.Error("FFTCtx error:", n, err)
+		}
+		if _, err := IFFTCtx(&cancelAfter{context.Background(), 3}, x); err != context.Canceled {
+			t.Error("IFFTCtx error:", n, err)
+		}
+	}
+}
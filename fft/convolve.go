@@ -0,0 +1,141 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// ConvolveFull returns the full linear convolution of x and y, which has
+// length len(x) + len(y) - 1. Unlike Convolve, x and y may differ in length,
+// and both are zero-padded so the result is not circular. If either is
+// empty, the result is empty.
+func ConvolveFull(x, y []complex128) []complex128 {
+	if len(x) == 0 || len(y) == 0 {
+		return []complex128{}
+	}
+
+	l := len(x) + len(y) - 1
+	n := dsputils.NextPowerOf2(l)
+	X := FFT(dsputils.ZeroPad(x, n))
+	Y := FFT(dsputils.ZeroPad(y, n))
+	for i := range X {
+		X[i] *= Y[i]
+	}
+
+	return IFFT(X)[:l]
+}
+
+// OverlapAdd convolves a long or streaming signal with a short kernel using
+// the overlap-add method. The input is split into blocks, each block is
+// convolved with the kernel using FFTs of a fixed size, and the overlapping
+// tails of successive blocks are summed, so the cost per sample depends on
+// the kernel length rather than the signal length.
+//
+// An OverlapAdd must not be used by multiple goroutines at once.
+// Reference: http://en.wikipedia.org/wiki/Overlap%E2%80%93add_method
+type OverlapAdd struct {
+	m    int // kernel length
+	step int // input samples per block
+
+	h        []complex128 // transformed kernel
+	fwd, inv *Plan
+	buf      []complex128
+	tail     []complex128 // contributions to the next m-1 outputs
+}
+
+// NewOverlapAdd returns an OverlapAdd for the kernel h. The FFT size is the
+// smallest power of 2 that holds a block of block input samples convolved
+// with h. Larger blocks cost less per sample; a block of a few times len(h)
+// is a good choice.
+func NewOverlapAdd(h []complex128, block int) *OverlapAdd {
+	if len(h) == 0 {
+		panic("h must be non-empty")
+	}
+	if block < 1 {
+		panic("block must be positive")
+	}
+
+	m := len(h)
+	n := dsputils.NextPowerOf2(block + m - 1)
+	o := &OverlapAdd{
+		m:    m,
+		step: n - m + 1,
+		h:    dsputils.ZeroPad(h, n),
+		fwd:  NewPlan(n, Forward),
+		inv:  NewPlan(n, Inverse),
+		buf:  make([]complex128, n),
+		tail: make([]complex128, m-1),
+	}
+	o.fwd.ExecuteInPlace(o.h)
+	return o
+}
+
+// Process convolves the next samples of the signal with the kernel and
+// returns len(x) output samples, continuing from previous calls. The output
+// is not delayed: Process called on successive chunks of a signal returns
+// the first len(x) samples of ConvolveFull of the whole signal, and Flush
+// returns the rest.
+func (o *OverlapAdd) Process(x []complex128) []complex128 {
+	y := make([]complex128, len(x))
+	for start := 0; start < len(x); start += o.step {
+		end := start + o.step
+		if end > len(x) {
+			end = len(x)
+		}
+		o.block(y[start:end], x[start:end])
+	}
+	return y
+}
+
+// block convolves x, of at most o.step samples, with the kernel, stores the
+// finished outputs in y and keeps the remainder in o.tail.
+func (o *OverlapAdd) block(y, x []complex128) {
+	l := len(x)
+	copy(o.buf, x)
+	for i := l; i < len(o.buf); i++ {
+		o.buf[i] = 0
+	}
+
+	o.fwd.ExecuteInPlace(o.buf)
+	for i := range o.buf {
+		o.buf[i] *= o.h[i]
+	}
+	o.inv.ExecuteInPlace(o.buf)
+
+	for i, v := range o.tail {
+		o.buf[i] += v
+	}
+	copy(y, o.buf[:l])
+	copy(o.tail, o.buf[l:l+o.m-1])
+}
+
+// Flush returns the last len(h)-1 samples of the convolution, which depend
+// only on input already passed to Process, and resets o.
+func (o *OverlapAdd) Flush() []complex128 {
+	y := make([]complex128, len(o.tail))
+	copy(y, o.tail)
+	o.Reset()
+	return y
+}
+
+// Reset clears the state of o, as if no input had been processed.
+func (o *OverlapAdd) Reset() {
+	for i := range o.tail {
+		o.tail[i] = 0
+	}
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// directConvolve returns the full linear convolution of x and y computed
+// from the definition.
+func directConvolve(x, y []complex128) []complex128 {
+	if len(x) == 0 || len(y) == 0 {
+		return []complex128{}
+	}
+	r := make([]complex128, len(x)+len(y)-1)
+	for i, a := range x {
+		for j, b := range y {
+			r[i+j] += a * b
+		}
+	}
+	return r
+}
+
+func testSignal(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(math.Sin(float64(i*i)), math.Cos(float64(3*i)))
+	}
+	return x
+}
+
+var convolveFullTests = []struct {
+	x, y, out []complex128
+}{
+	{
+		[]complex128{1, 2, 3},
+		[]complex128{1i, -1, 0.5, 2},
+		[]complex128{1i, -1 + 2i, -1.5 + 3i, 0, 5.5, 6},
+	},
+	{
+		[]complex128{2},
+		[]complex128{1, 2, 3},
+		[]complex128{2, 4, 6},
+	},
+	{
+		[]complex128{},
+		[]complex128{1, 2, 3},
+		[]complex128{},
+	},
+}
+
+func TestConvolveFull(t *testing.T) {
+	for _, ct := range convolveFullTests {
+		o := ConvolveFull(ct.x, ct.y)
+		if !dsputils.PrettyCloseC(o, ct.out) {
+			t.Error("ConvolveFull error\ninput:", ct.x, ct.y, "\noutput:", o, "\nexpected:", ct.out)
+		}
+	}
+
+	for _, n := range [][2]int{{1, 1}, {5, 17}, {64, 7}, {100, 100}} {
+		x, y := testSignal(n[0]), testSignal(n[1])
+		if o, e := ConvolveFull(x, y), directConvolve(x, y); !dsputils.PrettyCloseC(o, e) {
+			t.Error("ConvolveFull error\ninput lengths:", n, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
+
+func TestOverlapAdd(t *testing.T) {
+	h := testSignal(13)
+	x := testSignal(500)
+	e := directConvolve(x, h)
+
+	for _, block := range []int{1, 13, 50, 256} {
+		o := NewOverlapAdd(h, block)
+
+		// Process in uneven chunks to exercise the carried state.
+		var y []complex128
+		for start, chunk := 0, 1; start < len(x); start, chunk = start+chunk, chunk*2+1 {
+			end := start + chunk
+			if end > len(x) {
+				end = len(x)
+			}
+			y = append(y, o.Process(x[start:end])...)
+		}
+		y = append(y, o.Flush()...)
+		if !dsputils.PrettyCloseC(y, e) {
+			t.Error("OverlapAdd error\nblock:", block, "\noutput:", y, "\nexpected:", e)
+		}
+
+		// Flush resets, so the filter can be reused.
+		if y := append(o.Process(x), o.Flush()...); !dsputils.PrettyCloseC(y, e) {
+			t.Error("OverlapAdd reuse error\nblock:", block, "\noutput:", y, "\nexpected:", e)
+		}
+	}
+}
@@ -18,10 +18,23 @@
 package fft
 
 import (
+	"context"
+	"errors"
+
 	"github.com/mjibson/go-dsp/dsputils"
 )
 
-// FFTReal returns the forward FFT of the real-valued slice.
+// Errors returned by the error-returning variants of the transforms. The
+// panicking variants panic with the same values. ErrSizeMismatch and
+// ErrRaggedMatrix are the dsputils errors, so errors.Is matches either name.
+var (
+	ErrSizeMismatch = dsputils.ErrSizeMismatch
+	ErrEmptyInput   = errors.New("fft: empty input array")
+	ErrRaggedMatrix = dsputils.ErrRaggedMatrix
+)
+
+// FFTReal returns the forward FFT of the real-valued slice. RFFT returns only
+// the non-redundant half of the spectrum, in about half the time.
 func FFTReal(x []float64) []complex128 {
 	return FFT(dsputils.ToComplex(x))
 }
@@ -33,6 +46,13 @@ func IFFTReal(x []float64) []complex128 {
 
 // IFFT returns the inverse FFT of the complex-valued slice.
 func IFFT(x []complex128) []complex128 {
+	r, _ := ifftCtx(context.Background(), x)
+	return r
+}
+
+// ifftCtx is like IFFT, but stops early if ctx is cancelled, returning
+// ctx.Err().
+func ifftCtx(ctx context.Context, x []complex128) ([]complex128, error) {
 	lx := len(x)
 	r := make([]complex128, lx)
 
@@ -42,19 +62,34 @@ func IFFT(x []complex128) []complex128 {
 		r[i] = x[lx-i]
 	}
 
-	r = FFT(r)
+	r, err := fftCtx(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 
 	N := complex(float64(lx), 0)
 	for n := range r {
 		r[n] /= N
 	}
-	return r
+	return r, nil
 }
 
-// Convolve returns the convolution of x ∗ y.
+// Convolve returns the circular convolution of x ∗ y. It panics if x and y
+// are not of equal size; ConvolveErr returns an error instead. ConvolveFull
+// returns the linear convolution of inputs of any size.
 func Convolve(x, y []complex128) []complex128 {
+	r, err := ConvolveErr(x, y)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// ConvolveErr is like Convolve, but returns ErrSizeMismatch if x and y are not
+// of equal size.
+func ConvolveErr(x, y []complex128) ([]complex128, error) {
 	if len(x) != len(y) {
-		panic("arrays not of equal size")
+		return nil, ErrSizeMismatch
 	}
 
 	fft_x := FFT(x)
@@ -65,25 +100,38 @@ func Convolve(x, y []complex128) []complex128 {
 		r[i] = fft_x[i] * fft_y[i]
 	}
 
-	return IFFT(r)
+	return IFFT(r), nil
 }
 
-// FFT returns the forward FFT of the complex-valued slice.
+// FFT returns the forward FFT of the complex-valued slice. Power of 2 lengths
+// use the radix-2 algorithm, lengths whose prime factors are at most 7 the
+// mixed-radix algorithm, and other lengths the Bluestein algorithm.
 func FFT(x []complex128) []complex128 {
+	r, _ := fftCtx(context.Background(), x)
+	return r
+}
+
+// fftCtx is like FFT, but stops early if ctx is cancelled, returning ctx.Err().
+// The algorithms check ctx between their stages.
+func fftCtx(ctx context.Context, x []complex128) ([]complex128, error) {
 	lx := len(x)
 
-	// todo: non-hack handling length <= 1 cases
+	var r []complex128
 	if lx <= 1 {
-		r := make([]complex128, lx)
+		// todo: non-hack handling length <= 1 cases
+		r = make([]complex128, lx)
 		copy(r, x)
-		return r
+	} else if dsputils.IsPowerOf2(lx) {
+		r = radix2FFT(ctx, x)
+	} else if f := factorize(lx); f != nil {
+		r = mixedRadixFFT(ctx, x, f)
+	} else {
+		r = bluesteinFFT(ctx, x)
 	}
-
-	if dsputils.IsPowerOf2(lx) {
-		return radix2FFT(x)
+	if err := ctx.Err(); err != nil {
+		return nil, err
 	}
-
-	return bluesteinFFT(x)
+	return r, nil
 }
 
 var (
@@ -105,9 +153,21 @@ func FFT2Real(x [][]float64) [][]complex128 {
 	return FFT2(dsputils.ToComplex2(x))
 }
 
+// FFT2RealErr is like FFT2Real, but returns an error instead of panicking.
+func FFT2RealErr(x [][]float64) ([][]complex128, error) {
+	return FFT2Err(dsputils.ToComplex2(x))
+}
+
 // FFT2 returns the 2-dimensional, forward FFT of the complex-valued matrix.
+// It panics if x is empty or ragged; FFT2Err returns an error instead.
 func FFT2(x [][]complex128) [][]complex128 {
-	return computeFFT2(x, FFT)
+	return must2(computeFFT2(context.Background(), x, FFT))
+}
+
+// FFT2Err is like FFT2, but returns ErrEmptyInput or ErrRaggedMatrix instead
+// of panicking.
+func FFT2Err(x [][]complex128) ([][]complex128, error) {
+	return computeFFT2(context.Background(), x, FFT)
 }
 
 // IFFT2Real returns the 2-dimensional, inverse FFT of the real-valued matrix.
@@ -115,27 +175,49 @@ func IFFT2Real(x [][]float64) [][]complex128 {
 	return IFFT2(dsputils.ToComplex2(x))
 }
 
+// IFFT2RealErr is like IFFT2Real, but returns an error instead of panicking.
+func IFFT2RealErr(x [][]float64) ([][]complex128, error) {
+	return IFFT2Err(dsputils.ToComplex2(x))
+}
+
 // IFFT2 returns the 2-dimensional, inverse FFT of the complex-valued matrix.
+// It panics if x is empty or ragged; IFFT2Err returns an error instead.
 func IFFT2(x [][]complex128) [][]complex128 {
-	return computeFFT2(x, IFFT)
+	return must2(computeFFT2(context.Background(), x, IFFT))
+}
+
+// IFFT2Err is like IFFT2, but returns ErrEmptyInput or ErrRaggedMatrix
+// instead of panicking.
+func IFFT2Err(x [][]complex128) ([][]complex128, error) {
+	return computeFFT2(context.Background(), x, IFFT)
+}
+
+func must2(r [][]complex128, err error) [][]complex128 {
+	if err != nil {
+		panic(err)
+	}
+	return r
 }
 
-func computeFFT2(x [][]complex128, fftFunc func([]complex128) []complex128) [][]complex128 {
+func computeFFT2(ctx context.Context, x [][]complex128, fftFunc func([]complex128) []complex128) ([][]complex128, error) {
 	rows := len(x)
 	if rows == 0 {
-		panic("empty input array")
+		return nil, ErrEmptyInput
 	}
 
 	cols := len(x[0])
 	r := make([][]complex128, rows)
 	for i := 0; i < rows; i++ {
 		if len(x[i]) != cols {
-			panic("ragged input array")
+			return nil, ErrRaggedMatrix
 		}
 		r[i] = make([]complex128, cols)
 	}
 
 	for i := 0; i < cols; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		t := make([]complex128, rows)
 		for j := 0; j < rows; j++ {
 			t[j] = x[j][i]
@@ -147,23 +229,28 @@ func computeFFT2(x [][]complex128, fftFunc func([]complex128) []complex128) [][]
 	}
 
 	for n, v := range r {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
 		r[n] = fftFunc(v)
 	}
 
-	return r
+	return r, nil
 }
 
 // FFTN returns the forward FFT of the matrix m, computed in all N dimensions.
 func FFTN(m *dsputils.Matrix) *dsputils.Matrix {
-	return computeFFTN(m, FFT)
+	r, _ := computeFFTN(context.Background(), m, FFT)
+	return r
 }
 
 // IFFTN returns the forward FFT of the matrix m, computed in all N dimensions.
 func IFFTN(m *dsputils.Matrix) *dsputils.Matrix {
-	return computeFFTN(m, IFFT)
+	r, _ := computeFFTN(context.Background(), m, IFFT)
+	return r
 }
 
-func computeFFTN(m *dsputils.Matrix, fftFunc func([]complex128) []complex128) *dsputils.Matrix {
+func computeFFTN(ctx context.Context, m *dsputils.Matrix, fftFunc func([]complex128) []complex128) (*dsputils.Matrix, error) {
 	dims := m.Dimensions()
 	t := m.Copy()
 	r := dsputils.MakeEmptyMatrix(dims)
@@ -178,6 +265,9 @@ func computeFFTN(m *dsputils.Matrix, fftFunc func([]complex128) []complex128) *d
 		d[n] = -1
 
 		for {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
 			r.SetDim(fftFunc(t.Dim(d)), d)
 
 			if !decrDim(d, dims) {
@@ -188,7 +278,7 @@ func computeFFTN(m *dsputils.Matrix, fftFunc func([]complex128) []complex128) *d
 		r, t = t, r
 	}
 
-	return t
+	return t, nil
 }
 
 // decrDim decrements an element of x by 1, skipping all -1s, and wrapping up to d.
@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"math/cmplx"
@@ -222,6 +223,34 @@ func TestFFT2(t *testing.T) {
 	}
 }
 
+func TestErrors(t *testing.T) {
+	if _, err := ConvolveErr(make([]complex128, 2), make([]complex128, 3)); !errors.Is(err, dsputils.ErrSizeMismatch) {
+		t.Error("ConvolveErr error:", err)
+	}
+	if r, err := ConvolveErr([]complex128{1, 0}, []complex128{2, 3}); err != nil || !dsputils.PrettyCloseC(r, []complex128{2, 3}) {
+		t.Error("ConvolveErr error\noutput:", r, err)
+	}
+	if _, err := FFT2Err(nil); !errors.Is(err, ErrEmptyInput) {
+		t.Error("FFT2Err error:", err)
+	}
+	if _, err := IFFT2Err([][]complex128{{1, 2}, {3}}); !errors.Is(err, ErrRaggedMatrix) {
+		t.Error("IFFT2Err error:", err)
+	}
+	if _, err := FFT2RealErr([][]float64{{1}, {2, 3}}); !errors.Is(err, dsputils.ErrRaggedMatrix) {
+		t.Error("FFT2RealErr error:", err)
+	}
+	if r, err := IFFT2RealErr([][]float64{{4, 0}, {0, 0}}); err != nil || !dsputils.PrettyClose2(r, [][]complex128{{1, 1}, {1, 1}}) {
+		t.Error("IFFT2RealErr error\noutput:", r, err)
+	}
+
+	defer func() {
+		if r := recover(); r != ErrSizeMismatch {
+			t.Error("Convolve panic:", r)
+		}
+	}()
+	Convolve(make([]complex128, 2), make([]complex128, 3))
+}
+
 func TestFFTN(t *testing.T) {
 	for _, ft := range fftnTests {
 		m := dsputils.MakeMatrix(dsputils.ToComplex(ft.in), ft.dim)
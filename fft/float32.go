@@ -0,0 +1,45 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+// The float32 functions below let single-precision pipelines stay in
+// float32 and complex64 across the API. The transforms are computed in
+// complex64 by a Plan32, which is cached and reused across calls.
+
+// FFT32 returns the forward FFT of the complex64-valued slice.
+func FFT32(x []complex64) []complex64 {
+	r := make([]complex64, len(x))
+	execute32(r, x, Forward)
+	return r
+}
+
+// IFFT32 returns the inverse FFT of the complex64-valued slice.
+func IFFT32(x []complex64) []complex64 {
+	r := make([]complex64, len(x))
+	execute32(r, x, Inverse)
+	return r
+}
+
+// FFTReal32 returns the forward FFT of the float32-valued slice.
+func FFTReal32(x []float32) []complex64 {
+	r := make([]complex64, len(x))
+	for i, v := range x {
+		r[i] = complex(v, 0)
+	}
+	execute32(r, r, Forward)
+	return r
+}
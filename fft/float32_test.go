@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math/cmplx"
+	"testing"
+)
+
+func TestFFT32(t *testing.T) {
+	for _, ft := range fftTests {
+		if len(ft.in) == 0 {
+			continue
+		}
+		x := make([]float32, len(ft.in))
+		for i, v := range ft.in {
+			x[i] = float32(v)
+		}
+		e := FFTReal(ft.in)
+
+		o := FFTReal32(x)
+		if !close32(o, e) {
+			t.Error("FFTReal32 error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+		}
+
+		if c := FFT32(IFFT32(o)); !close32(c, e) {
+			t.Error("FFT32 error\ninput:", o, "\noutput:", c, "\nexpected:", e)
+		}
+		if !close32(IFFT32(o), IFFT(e)) {
+			t.Error("IFFT32 error\ninput:", o, "\nexpected:", IFFT(e))
+		}
+	}
+}
+
+// close32 reports whether a matches b to single precision.
+func close32(a []complex64, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if cmplx.Abs(complex128(a[i])-b[i]) > 1e-5*(1+cmplx.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
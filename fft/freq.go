@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+// FFTFreq returns the frequencies of the bins of an FFT of length n, for
+// samples spaced d apart (the reciprocal of the sample rate). The
+// non-negative frequencies come first, followed by the negative ones, as
+// in the output of FFT:
+//
+//	[0, 1, ..., (n-1)/2, -(n/2), ..., -1] / (d*n)
+//
+// FFTShift in the dsputils package orders them from negative to positive.
+func FFTFreq(n int, d float64) []float64 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+
+	r := make([]float64, n)
+	v := d * float64(n)
+	for i := range r {
+		k := i
+		if i > (n-1)/2 {
+			k -= n
+		}
+		r[i] = float64(k) / v
+	}
+	return r
+}
+
+// RFFTFreq returns the frequencies of the n/2+1 bins of RFFT for a signal of
+// length n, for samples spaced d apart:
+//
+//	[0, 1, ..., n/2] / (d*n)
+func RFFTFreq(n int, d float64) []float64 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+
+	r := make([]float64, n/2+1)
+	v := d * float64(n)
+	for i := range r {
+		r[i] = float64(i) / v
+	}
+	return r
+}
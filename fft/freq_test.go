@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+var fftFreqTests = []struct {
+	n         int
+	d         float64
+	out, rout []float64
+}{
+	{1, 1, []float64{0}, []float64{0}},
+	{4, 1, []float64{0, 0.25, -0.5, -0.25}, []float64{0, 0.25, 0.5}},
+	{5, 0.1, []float64{0, 2, 4, -4, -2}, []float64{0, 2, 4}},
+	{8, 1.0 / 8000, []float64{0, 1000, 2000, 3000, -4000, -3000, -2000, -1000}, []float64{0, 1000, 2000, 3000, 4000}},
+}
+
+func TestFFTFreq(t *testing.T) {
+	for _, ft := range fftFreqTests {
+		if o := FFTFreq(ft.n, ft.d); !dsputils.PrettyClose(o, ft.out) {
+			t.Error("FFTFreq error\ninput:", ft.n, ft.d, "\noutput:", o, "\nexpected:", ft.out)
+		}
+		if o := RFFTFreq(ft.n, ft.d); !dsputils.PrettyClose(o, ft.rout) {
+			t.Error("RFFTFreq error\ninput:", ft.n, ft.d, "\noutput:", o, "\nexpected:", ft.rout)
+		}
+		if n := len(RFFT(make([]float64, ft.n))); n != len(ft.rout) {
+			t.Error("RFFTFreq length error\ninput:", ft.n, "\noutput:", len(ft.rout), "\nexpected:", n)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"sync"
+)
+
+// planCaches keeps plans for the most recently used sizes, so the in-place
+// functions reuse plans, and their scratch space, across calls and
+// goroutines. Callers that transform many different lengths should hold
+// their own *Plan for each instead.
+var planCaches planCache
+
+type planKey struct {
+	n   int
+	dir Direction
+}
+
+// FFTInPlace replaces x with its forward FFT. Plans are cached for the 16
+// most recently used lengths; after the first call for one of those it does
+// not allocate.
+func FFTInPlace(x []complex128) {
+	inPlace(x, Forward)
+}
+
+// IFFTInPlace replaces x with its inverse FFT. It caches plans as
+// FFTInPlace does.
+func IFFTInPlace(x []complex128) {
+	inPlace(x, Inverse)
+}
+
+// ExecuteInPlace replaces x with its transform. It is equivalent to
+// p.Execute(x, x).
+func (p *Plan) ExecuteInPlace(x []complex128) {
+	p.Execute(x, x)
+}
+
+func inPlace(x []complex128, dir Direction) {
+	key := planKey{len(x), dir}
+	p, _ := planCaches.get(key).(*Plan)
+	if p == nil {
+		p = NewPlan(len(x), dir)
+	}
+	p.Execute(x, x)
+	planCaches.put(key, p)
+}
+
+const (
+	// maxCachedSizes is the number of sizes a planCache keeps plans for.
+	maxCachedSizes = 16
+	// maxCachedPlans is the number of idle plans a planCache keeps for each
+	// size.
+	maxCachedPlans = 4
+)
+
+// planCache holds idle plans for up to maxCachedSizes sizes, evicting the
+// least recently used size when full. Unlike a sync.Pool, it does not drop
+// plans during garbage collection, so a plan in steady use is built once.
+type planCache struct {
+	mu    sync.Mutex
+	lists []*planList // most recently used first
+}
+
+// planList is the free list of plans for a single size and direction.
+type planList struct {
+	key  planKey
+	free []interface{}
+}
+
+// list returns the free list for key, moved to the front of c.lists, or nil
+// if there is none. c.mu must be held.
+func (c *planCache) list(key planKey) *planList {
+	for i, l := range c.lists {
+		if l.key == key {
+			copy(c.lists[1:i+1], c.lists[:i])
+			c.lists[0] = l
+			return l
+		}
+	}
+	return nil
+}
+
+// get removes and returns a cached plan for key, or nil if there is none.
+func (c *planCache) get(key planKey) interface{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l := c.list(key)
+	if l == nil || len(l.free) == 0 {
+		return nil
+	}
+	n := len(l.free)
+	p := l.free[n-1]
+	l.free[n-1] = nil
+	l.free = l.free[:n-1]
+	return p
+}
+
+// put returns p, a plan for key, to the cache.
+func (c *planCache) put(key planKey, p interface{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	l := c.list(key)
+	if l == nil {
+		l = &planList{key: key}
+		if len(c.lists) < maxCachedSizes {
+			c.lists = append(c.lists, nil)
+		}
+		copy(c.lists[1:], c.lists)
+		c.lists[0] = l
+	}
+	if len(l.free) < maxCachedPlans {
+		l.free = append(l.free, p)
+	}
+}
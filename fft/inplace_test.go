@@ -0,0 +1,87 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"sync"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestFFTInPlace(t *testing.T) {
+	for _, ft := range fftTests {
+		x := dsputils.ToComplex(ft.in)
+		FFTInPlace(x)
+		if !dsputils.PrettyCloseC(x, ft.out) {
+			t.Error("FFTInPlace error\ninput:", ft.in, "\noutput:", x, "\nexpected:", ft.out)
+		}
+
+		IFFTInPlace(x)
+		if e := dsputils.ToComplex(ft.in); !dsputils.PrettyCloseC(x, e) {
+			t.Error("IFFTInPlace error\ninput:", ft.out, "\noutput:", x, "\nexpected:", e)
+		}
+	}
+}
+
+func TestFFTInPlaceConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			n := 48 + g%2*16
+			x := make([]complex128, n)
+			for i := range x {
+				x[i] = complex(math.Sin(float64(i*g)), 0)
+			}
+			e := FFT(x)
+			for i := 0; i < 20; i++ {
+				y := append([]complex128(nil), x...)
+				FFTInPlace(y)
+				if !dsputils.PrettyCloseC(y, e) {
+					t.Error("FFTInPlace concurrent error\nsize:", n, "\noutput:", y, "\nexpected:", e)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+func TestFFTInPlaceAllocs(t *testing.T) {
+	x := make([]complex128, 4800)
+	FFTInPlace(x)
+	if a := testing.AllocsPerRun(10, func() { FFTInPlace(x) }); a != 0 {
+		t.Error("FFTInPlace allocations\noutput:", a, "\nexpected:", 0)
+	}
+}
+
+func TestFFTInPlaceCacheBound(t *testing.T) {
+	for n := 1; n <= 3*maxCachedSizes; n++ {
+		FFTInPlace(make([]complex128, n))
+	}
+	planCaches.mu.Lock()
+	defer planCaches.mu.Unlock()
+	if len(planCaches.lists) != maxCachedSizes {
+		t.Error("plan cache sizes\noutput:", len(planCaches.lists), "\nexpected:", maxCachedSizes)
+	}
+	if k := planCaches.lists[0].key; k != (planKey{3 * maxCachedSizes, Forward}) {
+		t.Error("plan cache order\noutput:", k)
+	}
+}
@@ -0,0 +1,190 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"context"
+	"math"
+)
+
+// maxRadix is the largest prime factor handled by the mixed-radix algorithm.
+// Sizes with larger prime factors use the Bluestein algorithm.
+const maxRadix = 7
+
+// factorize returns the radices used by the mixed-radix algorithm for length
+// n: all factors of 4, then 2, 3, 5 and 7. It returns nil if n has a prime
+// factor larger than maxRadix.
+func factorize(n int) []int {
+	var f []int
+	for n%4 == 0 {
+		f = append(f, 4)
+		n /= 4
+	}
+	for p := 2; p <= maxRadix; p++ {
+		for n%p == 0 {
+			f = append(f, p)
+			n /= p
+		}
+	}
+	if n != 1 {
+		return nil
+	}
+	return f
+}
+
+// makeTwiddles returns exp(-2πik/n) for k < n.
+func makeTwiddles(n int) []complex128 {
+	tw := make([]complex128, n)
+	for k := range tw {
+		sin, cos := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+		tw[k] = complex(cos, sin)
+	}
+	return tw
+}
+
+// mixedRadixFFT returns the FFT calculated using the mixed-radix
+// Cooley-Tukey algorithm with the radices f, as returned by factorize. The
+// twiddle factors are computed on each call; a Plan keeps them. If ctx is
+// cancelled, it returns an incomplete result.
+func mixedRadixFFT(ctx context.Context, x []complex128, f []int) []complex128 {
+	r := make([]complex128, len(x))
+	mixedRadix(ctx, r, x, makeTwiddles(len(x)), 1, f)
+	return r
+}
+
+// mixedRadixCheck is the smallest sub-transform size before which
+// mixedRadix checks its context, so the check costs little.
+const mixedRadixCheck = 256
+
+// mixedRadix stores the forward FFT of in, read with stride fstride, in out,
+// which must not overlap in. tw holds the twiddle factors of the full
+// transform, and f the remaining radices. If ctx is cancelled, it stops
+// before the next sub-transform of at least mixedRadixCheck samples.
+// Reference: https://github.com/mborgerding/kissfft
+func mixedRadix[T complexType](ctx context.Context, out, in, tw []T, fstride int, f []int) {
+	p := f[0]
+	m := len(out) / p
+	if m >= mixedRadixCheck && ctx.Err() != nil {
+		return
+	}
+	if m == 1 {
+		for q := range out {
+			out[q] = in[q*fstride]
+		}
+	} else {
+		for q := 0; q < p; q++ {
+			mixedRadix(ctx, out[q*m:(q+1)*m], in[q*fstride:], tw, fstride*p, f[1:])
+		}
+	}
+
+	switch p {
+	case 2:
+		butterfly2(out, tw, fstride, m)
+	case 3:
+		butterfly3(out, tw, fstride, m)
+	case 4:
+		butterfly4(out, tw, fstride, m)
+	case 5:
+		butterfly5(out, tw, fstride, m)
+	default:
+		butterfly(out, tw, fstride, p, m)
+	}
+}
+
+// mulNegI returns -i·v.
+func mulNegI[T complexType](v T) T {
+	c := complex128(v)
+	return T(complex(imag(c), -real(c)))
+}
+
+func butterfly2[T complexType](x, tw []T, fstride, m int) {
+	for k := 0; k < m; k++ {
+		t := x[k+m] * tw[k*fstride]
+		x[k+m] = x[k] - t
+		x[k] += t
+	}
+}
+
+func butterfly3[T complexType](x, tw []T, fstride, m int) {
+	s := T(complex(math.Sin(2*math.Pi/3), 0))
+	for k := 0; k < m; k++ {
+		y1 := x[k+m] * tw[k*fstride]
+		y2 := x[k+2*m] * tw[2*k*fstride]
+		sum, d := y1+y2, mulNegI(y1-y2)*s
+		t := x[k] - sum/2
+		x[k] += sum
+		x[k+m] = t + d
+		x[k+2*m] = t - d
+	}
+}
+
+func butterfly4[T complexType](x, tw []T, fstride, m int) {
+	for k := 0; k < m; k++ {
+		x0 := x[k]
+		y1 := x[k+m] * tw[k*fstride]
+		y2 := x[k+2*m] * tw[2*k*fstride]
+		y3 := x[k+3*m] * tw[3*k*fstride]
+		a, b := x0+y2, x0-y2
+		c, d := y1+y3, mulNegI(y1-y3)
+		x[k] = a + c
+		x[k+m] = b + d
+		x[k+2*m] = a - c
+		x[k+3*m] = b - d
+	}
+}
+
+func butterfly5[T complexType](x, tw []T, fstride, m int) {
+	c1 := T(complex(math.Cos(2*math.Pi/5), 0))
+	c2 := T(complex(math.Cos(4*math.Pi/5), 0))
+	s1 := T(complex(math.Sin(2*math.Pi/5), 0))
+	s2 := T(complex(math.Sin(4*math.Pi/5), 0))
+	for k := 0; k < m; k++ {
+		x0 := x[k]
+		y1 := x[k+m] * tw[k*fstride]
+		y2 := x[k+2*m] * tw[2*k*fstride]
+		y3 := x[k+3*m] * tw[3*k*fstride]
+		y4 := x[k+4*m] * tw[4*k*fstride]
+		a1, b1 := y1+y4, mulNegI(y1-y4)
+		a2, b2 := y2+y3, mulNegI(y2-y3)
+		t1 := x0 + a1*c1 + a2*c2
+		t2 := x0 + a1*c2 + a2*c1
+		u1 := b1*s1 + b2*s2
+		u2 := b1*s2 - b2*s1
+		x[k] = x0 + a1 + a2
+		x[k+m] = t1 + u1
+		x[k+2*m] = t2 + u2
+		x[k+3*m] = t2 - u2
+		x[k+4*m] = t1 - u1
+	}
+}
+
+// butterfly computes radix-p butterflies directly, for p <= maxRadix.
+func butterfly[T complexType](x, tw []T, fstride, p, m int) {
+	var y [maxRadix]T
+	for k := 0; k < m; k++ {
+		for q := 0; q < p; q++ {
+			y[q] = x[k+q*m] * tw[q*k*fstride]
+		}
+		for u := 0; u < p; u++ {
+			sum := y[0]
+			for q := 1; q < p; q++ {
+				sum += y[q] * tw[u*q%p*m*fstride]
+			}
+			x[k+u*m] = sum
+		}
+	}
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// dft returns the DFT of x computed directly from the definition.
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	r := make([]complex128, n)
+	for k := range r {
+		for j, v := range x {
+			r[k] += v * cmplx.Rect(1, -2*math.Pi*float64(j*k%n)/float64(n))
+		}
+	}
+	return r
+}
+
+func TestFactorize(t *testing.T) {
+	tests := []struct {
+		n   int
+		out []int
+	}{
+		{480, []int{4, 4, 2, 3, 5}},
+		{1536, []int{4, 4, 4, 4, 2, 3}},
+		{441, []int{3, 3, 7, 7}},
+		{6, []int{2, 3}},
+		{11, nil},
+		{22, nil},
+	}
+	for _, v := range tests {
+		o := factorize(v.n)
+		if len(o) != len(v.out) {
+			t.Error("factorize error\ninput:", v.n, "\noutput:", o, "\nexpected:", v.out)
+			continue
+		}
+		for i := range o {
+			if o[i] != v.out[i] {
+				t.Error("factorize error\ninput:", v.n, "\noutput:", o, "\nexpected:", v.out)
+				break
+			}
+		}
+	}
+}
+
+func TestMixedRadix(t *testing.T) {
+	for _, n := range []int{3, 5, 6, 7, 9, 10, 12, 14, 15, 20, 21, 25, 27, 35, 48, 49, 60, 96, 105, 480} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i)), math.Cos(float64(i*i)))
+		}
+		e := dft(x)
+		if o := FFT(x); !dsputils.PrettyCloseC(o, e) {
+			t.Error("mixed-radix FFT error\nsize:", n, "\noutput:", o, "\nexpected:", e)
+		}
+		if o := bluesteinFFT(context.Background(), x); !dsputils.PrettyCloseC(o, e) {
+			t.Error("Bluestein FFT error\nsize:", n, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
+
+func BenchmarkMixedRadix(b *testing.B) {
+	a := make([]complex128, 960)
+	for i := range a {
+		a[i] = complex(float64(i), 0)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		FFT(a)
+	}
+}
+
+func BenchmarkBluestein(b *testing.B) {
+	a := make([]complex128, 960)
+	for i := range a {
+		a[i] = complex(float64(i), 0)
+	}
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		bluesteinFFT(context.Background(), a)
+	}
+}
@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// complexType is the element type of a plan.
+type complexType interface {
+	complex64 | complex128
+}
+
+// Direction selects the transform computed by a Plan.
+type Direction int
+
+const (
+	// Forward computes the forward transform, as FFT.
+	Forward Direction = iota
+	// Inverse computes the inverse transform, as IFFT, including the 1/n
+	// scaling.
+	Inverse
+)
+
+// A Plan computes transforms of a single size and direction. The twiddle
+// factors, bit-reversal table and scratch space are computed once by NewPlan
+// and owned by the plan, so plans share no state with each other or with the
+// package-level functions, and Execute does not allocate. Plans run on the
+// calling goroutine and ignore SetWorkerPoolSize. Sizes use the same
+// algorithms as FFT.
+//
+// A Plan must not be used by multiple goroutines at once; create one plan per
+// goroutine instead.
+type Plan struct {
+	plan[complex128]
+}
+
+// plan is the implementation of Plan and Plan32, for elements of type T.
+type plan[T complexType] struct {
+	n   int
+	dir Direction
+
+	// Radix-2 transforms of size m, where m is n if n is a power of 2 and
+	// otherwise the Bluestein convolution size.
+	m   int
+	tw  []T // exp(-2πik/m), k < m/2
+	rev []int
+
+	// Mixed-radix radices and twiddle factors, exp(-2πik/n) for k < n.
+	radices []int
+	mtw     []T
+
+	// Bluestein chirp and its transformed convolution kernel.
+	chirp  []T
+	kernel []T
+
+	// Scratch of length m for Bluestein and n for mixed-radix transforms.
+	scratch []T
+}
+
+// NewPlan returns a plan for transforms of length n in direction dir.
+func NewPlan(n int, dir Direction) *Plan {
+	return &Plan{*newPlan[complex128](n, dir)}
+}
+
+// newPlan returns a plan for transforms of length n in direction dir. Its
+// tables are computed in double precision and converted to T.
+func newPlan[T complexType](n int, dir Direction) *plan[T] {
+	if n < 0 {
+		panic("n must not be negative")
+	}
+	if dir != Forward && dir != Inverse {
+		panic("invalid direction")
+	}
+
+	p := &plan[T]{n: n, dir: dir, m: n}
+	if n <= 1 {
+		return p
+	}
+	if !dsputils.IsPowerOf2(n) {
+		if p.radices = factorize(n); p.radices != nil {
+			p.mtw = convert[T](makeTwiddles(n))
+			p.scratch = make([]T, n)
+			return p
+		}
+		p.m = dsputils.NextPowerOf2(2*n - 1)
+	}
+
+	p.tw = make([]T, p.m/2)
+	for k := range p.tw {
+		sin, cos := math.Sincos(-2 * math.Pi * float64(k) / float64(p.m))
+		p.tw[k] = T(complex(cos, sin))
+	}
+	p.rev = make([]int, p.m)
+	s := log2(uint(p.m))
+	for i := range p.rev {
+		p.rev[i] = int(reverseBits(uint(i), s))
+	}
+
+	if p.m != n {
+		// The chirp is exp(∓iπk²/n). k² is reduced modulo 2n first so the
+		// argument stays accurate for large k.
+		sign := -1.0
+		if dir == Inverse {
+			sign = 1
+		}
+		chirp := make([]complex128, n)
+		for k := range chirp {
+			kk := int64(k) * int64(k) % int64(2*n)
+			sin, cos := math.Sincos(sign * math.Pi * float64(kk) / float64(n))
+			chirp[k] = complex(cos, sin)
+		}
+		kernel := make([]complex128, p.m)
+		for k, w := range chirp {
+			kernel[k] = cmplx.Conj(w)
+			if k != 0 {
+				kernel[p.m-k] = cmplx.Conj(w)
+			}
+		}
+		newPlan[complex128](p.m, Forward).radix2(kernel, false)
+		p.chirp = convert[T](chirp)
+		p.kernel = convert[T](kernel)
+		p.scratch = make([]T, p.m)
+	}
+	return p
+}
+
+// Len returns the transform length of p.
+func (p *Plan) Len() int {
+	return p.n
+}
+
+// Direction returns the transform direction of p.
+func (p *Plan) Direction() Direction {
+	return p.dir
+}
+
+// Execute stores the transform of src in dst. dst and src may be the same
+// slice. It panics with ErrSizeMismatch if either is not of length p.Len().
+func (p *Plan) Execute(dst, src []complex128) {
+	p.execute(dst, src)
+}
+
+func (p *plan[T]) execute(dst, src []T) {
+	if len(dst) != p.n || len(src) != p.n {
+		panic(ErrSizeMismatch)
+	}
+	if p.n <= 1 {
+		copy(dst, src)
+		return
+	}
+
+	if p.radices != nil {
+		// The inverse transform is the conjugate of the forward transform of
+		// the conjugate.
+		copy(p.scratch, src)
+		if p.dir == Inverse {
+			conj(p.scratch)
+		}
+		mixedRadix(context.Background(), dst, p.scratch, p.mtw, 1, p.radices)
+		if p.dir == Inverse {
+			conj(dst)
+			scale(dst, p.n)
+		}
+		return
+	}
+
+	if p.m == p.n {
+		copy(dst, src)
+		p.radix2(dst, p.dir == Inverse)
+		if p.dir == Inverse {
+			scale(dst, p.n)
+		}
+		return
+	}
+
+	// Bluestein: convolve the chirp-modulated input with the conjugate chirp,
+	// then demodulate.
+	a := p.scratch
+	for k, w := range p.chirp {
+		a[k] = src[k] * w
+	}
+	for k := p.n; k < p.m; k++ {
+		a[k] = 0
+	}
+	p.radix2(a, false)
+	for k, v := range p.kernel {
+		a[k] *= v
+	}
+	p.radix2(a, true)
+	s := 1 / float64(p.m)
+	if p.dir == Inverse {
+		s /= float64(p.n)
+	}
+	c := T(complex(s, 0))
+	for k, w := range p.chirp {
+		dst[k] = a[k] * w * c
+	}
+}
+
+// radix2 computes the unscaled transform of x, of length p.m, in place with
+// the iterative radix-2 DIT Cooley-Tukey algorithm.
+func (p *plan[T]) radix2(x []T, inverse bool) {
+	for i, j := range p.rev {
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+
+	for size := 2; size <= p.m; size <<= 1 {
+		half := size / 2
+		step := p.m / size
+		for start := 0; start < p.m; start += size {
+			for j := 0; j < half; j++ {
+				w := p.tw[j*step]
+				if inverse {
+					w = conjugate(w)
+				}
+				a := x[start+j]
+				b := x[start+j+half] * w
+				x[start+j] = a + b
+				x[start+j+half] = a - b
+			}
+		}
+	}
+}
+
+// convert returns x converted to []T.
+func convert[T complexType](x []complex128) []T {
+	r := make([]T, len(x))
+	for i, v := range x {
+		r[i] = T(v)
+	}
+	return r
+}
+
+// conjugate returns the complex conjugate of v. The real and imag builtins do
+// not accept type parameters, so v is converted to complex128, which for
+// complex128 is free.
+func conjugate[T complexType](v T) T {
+	c := complex128(v)
+	return T(complex(real(c), -imag(c)))
+}
+
+func scale[T complexType](x []T, n int) {
+	s := T(complex(1/float64(n), 0))
+	for i := range x {
+		x[i] *= s
+	}
+}
+
+func conj[T complexType](x []T) {
+	for i, v := range x {
+		x[i] = conjugate(v)
+	}
+}
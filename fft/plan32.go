@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+// A Plan32 is like Plan, but transforms complex64 values, for applications
+// where single precision suffices and memory bandwidth is the bottleneck.
+// It uses the same algorithms as Plan, with tables computed in double
+// precision and rounded.
+type Plan32 struct {
+	plan[complex64]
+}
+
+// NewPlan32 returns a plan for complex64 transforms of length n in direction
+// dir.
+func NewPlan32(n int, dir Direction) *Plan32 {
+	return &Plan32{*newPlan[complex64](n, dir)}
+}
+
+// Len returns the transform length of p.
+func (p *Plan32) Len() int {
+	return p.n
+}
+
+// Direction returns the transform direction of p.
+func (p *Plan32) Direction() Direction {
+	return p.dir
+}
+
+// ExecuteInPlace replaces x with its transform. It is equivalent to
+// p.Execute(x, x).
+func (p *Plan32) ExecuteInPlace(x []complex64) {
+	p.Execute(x, x)
+}
+
+// Execute stores the transform of src in dst. dst and src may be the same
+// slice. It panics with ErrSizeMismatch if either is not of length p.Len().
+func (p *Plan32) Execute(dst, src []complex64) {
+	p.execute(dst, src)
+}
+
+// planCaches32 is like planCaches, for Plan32.
+var planCaches32 planCache
+
+func execute32(dst, src []complex64, dir Direction) {
+	key := planKey{len(src), dir}
+	p, _ := planCaches32.get(key).(*Plan32)
+	if p == nil {
+		p = NewPlan32(len(src), dir)
+	}
+	p.Execute(dst, src)
+	planCaches32.put(key, p)
+}
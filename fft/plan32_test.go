@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestPlan32(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 5, 8, 12, 17, 77, 100, 480, 960, 1024, 1000} {
+		x := make([]complex128, n)
+		x32 := make([]complex64, n)
+		for i := range x {
+			x32[i] = complex64(complex(math.Sin(float64(i)), math.Cos(float64(i*i))))
+			x[i] = complex128(x32[i])
+		}
+
+		for _, dir := range []Direction{Forward, Inverse} {
+			e := make([]complex128, n)
+			NewPlan(n, dir).Execute(e, x)
+
+			p := NewPlan32(n, dir)
+			o := make([]complex64, n)
+			p.Execute(o, x32)
+			if !closeNorm32(o, e) {
+				t.Error("Plan32 error\nsize:", n, "\ndirection:", dir, "\noutput:", o, "\nexpected:", e)
+			}
+
+			o = append([]complex64(nil), x32...)
+			p.ExecuteInPlace(o)
+			if !closeNorm32(o, e) {
+				t.Error("Plan32 in-place error\nsize:", n, "\ndirection:", dir, "\noutput:", o, "\nexpected:", e)
+			}
+		}
+	}
+}
+
+func TestPlan32Allocs(t *testing.T) {
+	for _, n := range []int{64, 100, 101} {
+		p := NewPlan32(n, Inverse)
+		x := make([]complex64, n)
+		if a := testing.AllocsPerRun(10, func() { p.ExecuteInPlace(x) }); a != 0 {
+			t.Error("Plan32 allocations\nsize:", n, "\noutput:", a, "\nexpected:", 0)
+		}
+	}
+}
+
+func TestPlan32MixedRadix(t *testing.T) {
+	// Sizes whose prime factors are at most 7 avoid Bluestein, as in Plan.
+	for _, n := range []int{12, 480, 960, 1000} {
+		if p := NewPlan32(n, Forward); p.radices == nil || p.chirp != nil {
+			t.Error("Plan32 mixed-radix error\nsize:", n, "\nradices:", p.radices)
+		}
+	}
+}
+
+// closeNorm32 reports whether the error of a relative to the largest
+// magnitude in b is within single precision rounding of a long transform.
+func closeNorm32(a []complex64, b []complex128) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	var m float64
+	for _, v := range b {
+		m = math.Max(m, cmplx.Abs(v))
+	}
+	for i := range a {
+		if cmplx.Abs(complex128(a[i])-b[i]) > 1e-5*m {
+			return false
+		}
+	}
+	return true
+}
+
+func BenchmarkPlan32(b *testing.B) {
+	N := 1 << 20
+	a := make([]complex64, N)
+	for i := 0; i < N; i++ {
+		a[i] = complex(float32(i)/float32(N), 0)
+	}
+	p := NewPlan32(N, Forward)
+	r := make([]complex64, N)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.Execute(r, a)
+	}
+}
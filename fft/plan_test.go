@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestPlan(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 12, 16, 17, 100, 128, 1000} {
+		x := make([]complex128, n)
+		for i := range x {
+			x[i] = complex(math.Sin(float64(i)), math.Cos(float64(i*i)))
+		}
+
+		for _, dir := range []Direction{Forward, Inverse} {
+			p := NewPlan(n, dir)
+			e := []complex128{}
+			if n > 0 && dir == Forward {
+				e = FFT(x)
+			} else if n > 0 {
+				e = IFFT(x)
+			}
+
+			o := make([]complex128, n)
+			p.Execute(o, x)
+			if !dsputils.PrettyCloseC(o, e) {
+				t.Error("Plan error\nsize:", n, "\ndirection:", dir, "\noutput:", o, "\nexpected:", e)
+			}
+
+			// Executing again, and in place, must give the same result.
+			o = append([]complex128(nil), x...)
+			p.Execute(o, o)
+			if !dsputils.PrettyCloseC(o, e) {
+				t.Error("Plan in-place error\nsize:", n, "\ndirection:", dir, "\noutput:", o, "\nexpected:", e)
+			}
+		}
+	}
+}
+
+func TestPlanSize(t *testing.T) {
+	defer func() {
+		if r := recover(); r != ErrSizeMismatch {
+			t.Error("Execute panic:", r)
+		}
+	}()
+	NewPlan(4, Forward).Execute(make([]complex128, 4), make([]complex128, 3))
+}
+
+func TestPlanAllocs(t *testing.T) {
+	for _, n := range []int{64, 100} {
+		p := NewPlan(n, Forward)
+		x := make([]complex128, n)
+		if a := testing.AllocsPerRun(10, func() { p.Execute(x, x) }); a != 0 {
+			t.Error("Plan allocations\nsize:", n, "\noutput:", a, "\nexpected:", 0)
+		}
+	}
+}
+
+func BenchmarkPlan(b *testing.B) {
+	N := 1 << 20
+	a := make([]complex128, N)
+	for i := 0; i < N; i++ {
+		a[i] = complex(float64(i)/float64(N), 0)
+	}
+	p := NewPlan(N, Forward)
+	r := make([]complex128, N)
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		p.Execute(r, a)
+	}
+}
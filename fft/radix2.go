@@ -17,6 +17,7 @@
 package fft
 
 import (
+	"context"
 	"math"
 	"runtime"
 	"sync"
@@ -77,7 +78,9 @@ type fft_work struct {
 }
 
 // radix2FFT returns the FFT calculated using the radix-2 DIT Cooley-Tukey algorithm.
-func radix2FFT(x []complex128) []complex128 {
+// If ctx is cancelled, it stops after the current stage and returns an
+// incomplete result.
+func radix2FFT(ctx context.Context, x []complex128) []complex128 {
 	lx := len(x)
 	factors := getRadix2Factors(lx)
 
@@ -129,6 +132,9 @@ func radix2FFT(x []complex128) []complex128 {
 	defer close(jobs)
 
 	for stage = 2; stage <= lx; stage <<= 1 {
+		if ctx.Err() != nil {
+			break
+		}
 		blocks = lx / stage
 		s_2 = stage / 2
 
@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// RFFT returns the first len(x)/2+1 bins of the forward FFT of the
+// real-valued slice; the rest are the complex conjugates of these, in reverse
+// order. For even lengths, x is transformed as a complex sequence of half the
+// length, which is about twice as fast as FFTReal.
+func RFFT(x []float64) []complex128 {
+	n := len(x)
+	if n == 0 {
+		return []complex128{}
+	}
+	if n%2 != 0 {
+		return FFTReal(x)[:n/2+1]
+	}
+
+	// Pack the even samples into the real parts and the odd samples into the
+	// imaginary parts, and separate their transforms E and O afterwards.
+	h := n / 2
+	z := make([]complex128, h)
+	for k := range z {
+		z[k] = complex(x[2*k], x[2*k+1])
+	}
+	FFTInPlace(z)
+
+	r := make([]complex128, h+1)
+	r[0] = complex(real(z[0])+imag(z[0]), 0)
+	r[h] = complex(real(z[0])-imag(z[0]), 0)
+	for k := 1; k < h; k++ {
+		a, b := z[k], cmplx.Conj(z[h-k])
+		e := (a + b) / 2
+		o := (a - b) / 2i
+		sin, cos := math.Sincos(-2 * math.Pi * float64(k) / float64(n))
+		r[k] = e + complex(cos, sin)*o
+	}
+	return r
+}
+
+// IRFFT returns the length n inverse FFT of the half spectrum X, as returned
+// by RFFT(x) with len(x) == n. Only the first n/2+1 bins of X are used, with
+// missing bins taken as zero, and the imaginary parts of the zero and, for
+// even n, Nyquist frequency bins are ignored.
+func IRFFT(X []complex128, n int) []float64 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	h := n / 2
+	b := make([]complex128, h+1)
+	copy(b, X)
+	b[0] = complex(real(b[0]), 0)
+
+	r := make([]float64, n)
+	if n%2 != 0 {
+		full := make([]complex128, n)
+		copy(full, b)
+		for k := 1; k <= h; k++ {
+			full[n-k] = cmplx.Conj(b[k])
+		}
+		IFFTInPlace(full)
+		for i, v := range full {
+			r[i] = real(v)
+		}
+		return r
+	}
+
+	// Undo the separation done by RFFT and unpack.
+	b[h] = complex(real(b[h]), 0)
+	z := make([]complex128, h)
+	for k := range z {
+		a, c := b[k], cmplx.Conj(b[h-k])
+		e := (a + c) / 2
+		sin, cos := math.Sincos(2 * math.Pi * float64(k) / float64(n))
+		o := (a - c) / 2 * complex(cos, sin)
+		z[k] = e + 1i*o
+	}
+	IFFTInPlace(z)
+	for k, v := range z {
+		r[2*k] = real(v)
+		r[2*k+1] = imag(v)
+	}
+	return r
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package fft
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestRFFT(t *testing.T) {
+	for _, ft := range fftTests {
+		o := RFFT(ft.in)
+		if e := ft.out[:len(ft.in)/2+1]; !dsputils.PrettyCloseC(o, e) {
+			t.Error("RFFT error\ninput:", ft.in, "\noutput:", o, "\nexpected:", e)
+		}
+		if i := IRFFT(o, len(ft.in)); !dsputils.PrettyClose(i, ft.in) {
+			t.Error("IRFFT error\ninput:", o, "\noutput:", i, "\nexpected:", ft.in)
+		}
+	}
+
+	for n := 1; n <= 33; n++ {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = math.Sin(float64(i*i)) + 0.5
+		}
+		o := RFFT(x)
+		if e := FFTReal(x)[:n/2+1]; !dsputils.PrettyCloseC(o, e) {
+			t.Error("RFFT error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+		}
+		if i := IRFFT(o, n); !dsputils.PrettyClose(i, x) {
+			t.Error("IRFFT error\ninput:", o, "\noutput:", i, "\nexpected:", x)
+		}
+	}
+}
+
+func TestIRFFT(t *testing.T) {
+	tests := []struct {
+		in  []complex128
+		n   int
+		out []float64
+	}{
+		{[]complex128{10, -2 + 2i, -2}, 4, []float64{1, 2, 3, 4}},
+		// The imaginary parts of the DC and Nyquist bins are ignored.
+		{[]complex128{10 + 5i, -2 + 2i, -2 - 7i}, 4, []float64{1, 2, 3, 4}},
+		// Missing bins are zero, and extra bins unused.
+		{[]complex128{4}, 4, []float64{1, 1, 1, 1}},
+		{[]complex128{3, 0, 9}, 3, []float64{1, 1, 1}},
+		{[]complex128{6, -1.5 + 0.8660254037844386i}, 3, []float64{1, 2, 3}},
+	}
+	for _, v := range tests {
+		if o := IRFFT(v.in, v.n); !dsputils.PrettyClose(o, v.out) {
+			t.Error("IRFFT error\ninput:", v.in, v.n, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// The functions in this file operate on filters in zero-pole-gain form: the
+// zeros z, poles p, and gain k of the transfer function
+//
+//	H(s) = k (s - z[0]) (s - z[1]) ... / (s - p[0]) (s - p[1]) ...
+//
+// Analog lowpass prototypes can be transformed to other band types and
+// frequencies with Lp2lp, Lp2hp, Lp2bp, and Lp2bs, then to digital filters
+// with Bilinear.
+
+// Buttap returns the zeros, poles, and gain of an order n analog Butterworth
+// lowpass prototype with a cutoff of 1 rad/s.
+func Buttap(n int) ([]complex128, []complex128, float64) {
+	if n < 1 {
+		panic("filter order must be positive")
+	}
+
+	p := make([]complex128, n)
+	for i := range p {
+		p[i] = -cmplx.Exp(complex(0, math.Pi*float64(2*i-n+1)/float64(2*n)))
+	}
+
+	return []complex128{}, p, 1
+}
+
+// Cheb1ap returns the zeros, poles, and gain of an order n analog Chebyshev
+// type I lowpass prototype with rp dB of passband ripple and a cutoff of 1
+// rad/s.
+func Cheb1ap(n int, rp float64) ([]complex128, []complex128, float64) {
+	if n < 1 {
+		panic("filter order must be positive")
+	}
+
+	eps := math.Sqrt(math.Pow(10, 0.1*rp) - 1)
+	mu := math.Asinh(1/eps) / float64(n)
+
+	p := make([]complex128, n)
+	for i := range p {
+		theta := math.Pi * float64(2*i-n+1) / float64(2*n)
+		p[i] = -cmplx.Sinh(complex(mu, theta))
+	}
+
+	k := real(prodNeg(p))
+	if n%2 == 0 {
+		k /= math.Sqrt(1 + eps*eps)
+	}
+
+	return []complex128{}, p, k
+}
+
+// Cheb2ap returns the zeros, poles, and gain of an order n analog Chebyshev
+// type II lowpass prototype with rs dB of stopband attenuation beginning at 1
+// rad/s.
+func Cheb2ap(n int, rs float64) ([]complex128, []complex128, float64) {
+	if n < 1 {
+		panic("filter order must be positive")
+	}
+
+	de := 1 / math.Sqrt(math.Pow(10, 0.1*rs)-1)
+	mu := math.Asinh(1/de) / float64(n)
+
+	// Zeros are on the imaginary axis; for odd orders the one at infinity is
+	// dropped.
+	var z []complex128
+	for m := -n + 1; m < n; m += 2 {
+		if m == 0 {
+			continue
+		}
+		s := math.Sin(float64(m) * math.Pi / float64(2*n))
+		z = append(z, -cmplx.Conj(complex(0, 1/s)))
+	}
+
+	p := make([]complex128, n)
+	for i := range p {
+		v := -cmplx.Exp(complex(0, math.Pi*float64(2*i-n+1)/float64(2*n)))
+		v = complex(math.Sinh(mu)*real(v), math.Cosh(mu)*imag(v))
+		p[i] = 1 / v
+	}
+
+	k := real(prodNeg(p) / prodNeg(z))
+	return z, p, k
+}
+
+// Ellipap returns the zeros, poles, and gain of an order n analog elliptic
+// (Cauer) lowpass prototype with rp dB of passband ripple and at least rs dB
+// of stopband attenuation. The passband edge, where the gain first drops
+// below -rp dB, is at 1 rad/s. Elliptic filters have the steepest rolloff of
+// any filter of a given order, with ripple in both bands.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.ellipap.html
+func Ellipap(n int, rp, rs float64) ([]complex128, []complex128, float64) {
+	if n < 1 {
+		panic("filter order must be positive")
+	}
+	if rp <= 0 || rs <= rp {
+		panic("ripple must be positive and less than the attenuation")
+	}
+
+	epsSq := math.Pow(10, 0.1*rp) - 1
+	if n == 1 {
+		p := -math.Sqrt(1 / epsSq)
+		return []complex128{}, []complex128{complex(p, 0)}, -p
+	}
+
+	// m1 is the modulus of the degree equation relating the selectivity of
+	// the filter to its discrimination, and m the selectivity modulus.
+	m1 := epsSq / (math.Pow(10, 0.1*rs) - 1)
+	m := ellipdeg(n, m1)
+	K := ellipk(m)
+
+	// Zeros are on the imaginary axis in conjugate pairs, and poles are in
+	// conjugate pairs plus, for odd orders, one on the real axis (j = 0).
+	r := arcJacSC1(1/math.Sqrt(epsSq), m1)
+	v0 := K * r / (float64(n) * ellipk(m1))
+	sv, cv, dv := ellipj(v0, 1-m)
+	var z, p []complex128
+	for j := 1 - n%2; j < n; j += 2 {
+		s, c, d := ellipj(float64(j)*K/float64(n), m)
+		if math.Abs(s) > 1e-14 {
+			w := 1 / (math.Sqrt(m) * s)
+			z = append(z, complex(0, w), complex(0, -w))
+		}
+
+		den := 1 - (d*sv)*(d*sv)
+		v := complex(-c*d*sv*cv/den, -s*dv/den)
+		p = append(p, v)
+		if math.Abs(imag(v)) > 1e-14*cmplx.Abs(v) {
+			p = append(p, cmplx.Conj(v))
+		}
+	}
+
+	k := real(prodNeg(p) / prodNeg(z))
+	if n%2 == 0 {
+		k /= math.Sqrt(1 + epsSq)
+	}
+
+	return z, p, k
+}
+
+// prodNeg returns the product of the negated values of x.
+func prodNeg(x []complex128) complex128 {
+	r := complex(1, 0)
+	for _, v := range x {
+		r *= -v
+	}
+	return r
+}
+
+// relativeDegree returns the number of poles in excess of zeros. It panics
+// for an improper filter, with more zeros than poles, which the frequency
+// transformations and the bilinear transform do not support.
+func relativeDegree(z, p []complex128) int {
+	degree := len(p) - len(z)
+	if degree < 0 {
+		panic("improper filter: more zeros than poles")
+	}
+	return degree
+}
+
+// Lp2lp transforms an analog lowpass filter with a cutoff of 1 rad/s to
+// a lowpass filter with a cutoff of wo rad/s.
+func Lp2lp(z, p []complex128, k, wo float64) ([]complex128, []complex128, float64) {
+	degree := relativeDegree(z, p)
+	w := complex(wo, 0)
+
+	zl := make([]complex128, len(z))
+	for i, v := range z {
+		zl[i] = v * w
+	}
+	pl := make([]complex128, len(p))
+	for i, v := range p {
+		pl[i] = v * w
+	}
+
+	return zl, pl, k * math.Pow(wo, float64(degree))
+}
+
+// Lp2hp transforms an analog lowpass filter with a cutoff of 1 rad/s to
+// a highpass filter with a cutoff of wo rad/s.
+func Lp2hp(z, p []complex128, k, wo float64) ([]complex128, []complex128, float64) {
+	degree := relativeDegree(z, p)
+	w := complex(wo, 0)
+
+	zh := make([]complex128, len(z), len(p))
+	for i, v := range z {
+		zh[i] = w / v
+	}
+	ph := make([]complex128, len(p))
+	for i, v := range p {
+		ph[i] = w / v
+	}
+
+	// Zeros at infinity move to the origin.
+	for i := 0; i < degree; i++ {
+		zh = append(zh, 0)
+	}
+
+	return zh, ph, k * real(prodNeg(z)/prodNeg(p))
+}
+
+// Lp2bp transforms an analog lowpass filter with a cutoff of 1 rad/s to
+// a bandpass filter with center frequency wo and bandwidth bw, in rad/s.
+func Lp2bp(z, p []complex128, k, wo, bw float64) ([]complex128, []complex128, float64) {
+	degree := relativeDegree(z, p)
+
+	split := func(x []complex128) []complex128 {
+		r := make([]complex128, 2*len(x))
+		for i, v := range x {
+			v *= complex(bw/2, 0)
+			d := cmplx.Sqrt(v*v - complex(wo*wo, 0))
+			r[i] = v + d
+			r[i+len(x)] = v - d
+		}
+		return r
+	}
+
+	zb := split(z)
+	pb := split(p)
+
+	// Zeros at infinity move to the origin and infinity.
+	for i := 0; i < degree; i++ {
+		zb = append(zb, 0)
+	}
+
+	return zb, pb, k * math.Pow(bw, float64(degree))
+}
+
+// Lp2bs transforms an analog lowpass filter with a cutoff of 1 rad/s to
+// a bandstop filter with center frequency wo and bandwidth bw, in rad/s.
+func Lp2bs(z, p []complex128, k, wo, bw float64) ([]complex128, []complex128, float64) {
+	degree := relativeDegree(z, p)
+
+	split := func(x []complex128) []complex128 {
+		r := make([]complex128, 2*len(x))
+		for i, v := range x {
+			v = complex(bw/2, 0) / v
+			d := cmplx.Sqrt(v*v - complex(wo*wo, 0))
+			r[i] = v + d
+			r[i+len(x)] = v - d
+		}
+		return r
+	}
+
+	zb := split(z)
+	pb := split(p)
+
+	// Zeros at infinity move to the center of the stopband.
+	for i := 0; i < degree; i++ {
+		zb = append(zb, complex(0, wo))
+	}
+	for i := 0; i < degree; i++ {
+		zb = append(zb, complex(0, -wo))
+	}
+
+	return zb, pb, k * real(prodNeg(z)/prodNeg(p))
+}
+
+// Bilinear returns the digital filter corresponding to the analog filter
+// z, p, k using the bilinear transform with sampling frequency fs.
+func Bilinear(z, p []complex128, k, fs float64) ([]complex128, []complex128, float64) {
+	degree := relativeDegree(z, p)
+	fs2 := complex(2*fs, 0)
+
+	zz := make([]complex128, len(z), len(p))
+	num := complex(1, 0)
+	for i, v := range z {
+		zz[i] = (fs2 + v) / (fs2 - v)
+		num *= fs2 - v
+	}
+	pz := make([]complex128, len(p))
+	den := complex(1, 0)
+	for i, v := range p {
+		pz[i] = (fs2 + v) / (fs2 - v)
+		den *= fs2 - v
+	}
+
+	// Zeros at infinity move to Nyquist.
+	for i := 0; i < degree; i++ {
+		zz = append(zz, -1)
+	}
+
+	return zz, pz, k * real(num/den)
+}
@@ -0,0 +1,148 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type zpkTest struct {
+	name string
+	z, p []complex128
+	k    float64
+	ez   []complex128
+	ep   []complex128
+	ek   float64
+}
+
+// sortRoots sorts x by real then imaginary part, so roots can be compared.
+func sortRoots(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	copy(r, x)
+	sort.Slice(r, func(i, j int) bool {
+		if real(r[i]) != real(r[j]) {
+			return real(r[i]) < real(r[j])
+		}
+		return imag(r[i]) < imag(r[j])
+	})
+	return r
+}
+
+func checkZpk(t *testing.T, v zpkTest) {
+	if !dsputils.PrettyCloseC(sortRoots(v.z), sortRoots(v.ez)) {
+		t.Error(v.name, "zeros error\noutput:", v.z, "\nexpected:", v.ez)
+	}
+	if !dsputils.PrettyCloseC(sortRoots(v.p), sortRoots(v.ep)) {
+		t.Error(v.name, "poles error\noutput:", v.p, "\nexpected:", v.ep)
+	}
+	if !dsputils.Float64Equal(v.k, v.ek) {
+		t.Error(v.name, "gain error\noutput:", v.k, "\nexpected:", v.ek)
+	}
+}
+
+func TestAnalog(t *testing.T) {
+	s3 := math.Sqrt(3) / 2
+	p := []complex128{-1}
+	z := []complex128{}
+
+	v := zpkTest{name: "lp2lp", ez: []complex128{}, ep: []complex128{-2}, ek: 2}
+	v.z, v.p, v.k = Lp2lp(z, p, 1, 2)
+	checkZpk(t, v)
+
+	v = zpkTest{name: "lp2hp", ez: []complex128{0}, ep: []complex128{-2}, ek: 1}
+	v.z, v.p, v.k = Lp2hp(z, p, 1, 2)
+	checkZpk(t, v)
+
+	v = zpkTest{name: "lp2bp", ez: []complex128{0}, ep: []complex128{complex(-0.5, s3), complex(-0.5, -s3)}, ek: 1}
+	v.z, v.p, v.k = Lp2bp(z, p, 1, 1, 1)
+	checkZpk(t, v)
+
+	v = zpkTest{name: "lp2bs", ez: []complex128{1i, -1i}, ep: []complex128{complex(-0.5, s3), complex(-0.5, -s3)}, ek: 1}
+	v.z, v.p, v.k = Lp2bs(z, p, 1, 1, 1)
+	checkZpk(t, v)
+
+	v = zpkTest{name: "bilinear", ez: []complex128{-1}, ep: []complex128{0}, ek: 0.5}
+	v.z, v.p, v.k = Bilinear(z, p, 1, 0.5)
+	checkZpk(t, v)
+}
+
+func TestAnalogImproper(t *testing.T) {
+	z := []complex128{-1, -2}
+	p := []complex128{-1}
+	tests := []func(){
+		func() { Lp2lp(z, p, 1, 1) },
+		func() { Lp2hp(z, p, 1, 1) },
+		func() { Lp2bp(z, p, 1, 1, 1) },
+		func() { Lp2bs(z, p, 1, 1, 1) },
+		func() { Bilinear(z, p, 1, 1) },
+	}
+	for i, f := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("improper filter did not panic:", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestPrototypes(t *testing.T) {
+	for n := 1; n < 8; n++ {
+		// Butterworth poles are on the unit circle in the left half plane,
+		// with unity DC gain.
+		z, p, k := Buttap(n)
+		if len(z) != 0 || len(p) != n || k != 1 {
+			t.Error("Buttap error:", n, z, p, k)
+		}
+		for _, v := range p {
+			if real(v) >= 0 || !dsputils.Float64Equal(cmplx.Abs(v), 1) {
+				t.Error("Buttap pole error:", n, v)
+			}
+		}
+
+		for _, proto := range []func() ([]complex128, []complex128, float64){
+			func() ([]complex128, []complex128, float64) { return Cheb1ap(n, 1) },
+			func() ([]complex128, []complex128, float64) { return Cheb2ap(n, 40) },
+			func() ([]complex128, []complex128, float64) { return Ellipap(n, 1, 40) },
+		} {
+			z, p, k := proto()
+			if len(p) != n {
+				t.Error("prototype order error:", n, p)
+			}
+			for _, v := range p {
+				if real(v) >= 0 {
+					t.Error("prototype pole error:", n, v)
+				}
+			}
+			for _, v := range z {
+				if !dsputils.Float64Equal(real(v), 0) {
+					t.Error("prototype zero error:", n, v)
+				}
+			}
+			if k <= 0 {
+				t.Error("prototype gain error:", n, k)
+			}
+		}
+	}
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// Biquad is a second-order IIR filter section {b0, b1, b2, a0, a1, a2}, in
+// the same layout as a row of second-order sections. The constructors below
+// implement the designs of Robert Bristow-Johnson's Audio EQ Cookbook, with
+// center or corner frequency f0 in the same units as the sampling frequency
+// fs, and normalized so a0 is 1.
+// Reference: https://www.w3.org/TR/audio-eq-cookbook/
+type Biquad [6]float64
+
+// Cascade returns the second-order sections of the biquads applied one after
+// the other, for use with SosFilt, NewSosStream or FreqzSos.
+func Cascade(bq ...Biquad) [][6]float64 {
+	sos := make([][6]float64, len(bq))
+	for i, b := range bq {
+		sos[i] = b
+	}
+	return sos
+}
+
+// Filter filters x with the biquad, with zero initial state.
+func (bq Biquad) Filter(x []float64) []float64 {
+	return SosFilt(Cascade(bq), x)
+}
+
+// LowpassBiquad returns a second-order lowpass filter with corner frequency
+// f0 and quality factor Q. A Q of 1/sqrt(2) gives a Butterworth response.
+func LowpassBiquad(f0, Q, fs float64) Biquad {
+	c, alpha := biquadParams(f0, Q, fs)
+	return newBiquad((1-c)/2, 1-c, (1-c)/2, 1+alpha, -2*c, 1-alpha)
+}
+
+// HighpassBiquad returns a second-order highpass filter with corner
+// frequency f0 and quality factor Q.
+func HighpassBiquad(f0, Q, fs float64) Biquad {
+	c, alpha := biquadParams(f0, Q, fs)
+	return newBiquad((1+c)/2, -(1 + c), (1+c)/2, 1+alpha, -2*c, 1-alpha)
+}
+
+// BandpassBiquad returns a second-order bandpass filter with center
+// frequency f0, unity (0 dB) peak gain, and quality factor Q.
+func BandpassBiquad(f0, Q, fs float64) Biquad {
+	c, alpha := biquadParams(f0, Q, fs)
+	return newBiquad(alpha, 0, -alpha, 1+alpha, -2*c, 1-alpha)
+}
+
+// AllpassBiquad returns a second-order allpass filter, which has unity gain
+// at all frequencies and a phase shift of -180 degrees at f0.
+func AllpassBiquad(f0, Q, fs float64) Biquad {
+	c, alpha := biquadParams(f0, Q, fs)
+	return newBiquad(1-alpha, -2*c, 1+alpha, 1+alpha, -2*c, 1-alpha)
+}
+
+// PeakingEQ returns a peaking equalizer, which has gain gain (in dB) at f0
+// and unity gain far from it. Q sets the bandwidth.
+func PeakingEQ(f0, Q, gain, fs float64) Biquad {
+	c, alpha := biquadParams(f0, Q, fs)
+	A := math.Pow(10, gain/40)
+	return newBiquad(1+alpha*A, -2*c, 1-alpha*A, 1+alpha/A, -2*c, 1-alpha/A)
+}
+
+// LowShelf returns a low shelving filter, which has gain gain (in dB) at DC,
+// unity gain at high frequencies, and half the gain (in dB) at f0. The shelf
+// slope S is 1 for the steepest slope without overshoot.
+func LowShelf(f0, S, gain, fs float64) Biquad {
+	A, c, sA := shelfParams(f0, S, gain, fs)
+	return newBiquad(
+		A*((A+1)-(A-1)*c+sA),
+		2*A*((A-1)-(A+1)*c),
+		A*((A+1)-(A-1)*c-sA),
+		(A+1)+(A-1)*c+sA,
+		-2*((A-1)+(A+1)*c),
+		(A+1)+(A-1)*c-sA,
+	)
+}
+
+// HighShelf returns a high shelving filter, which has gain gain (in dB) at
+// the Nyquist frequency, unity gain at low frequencies, and half the gain (in
+// dB) at f0. S is as in LowShelf.
+func HighShelf(f0, S, gain, fs float64) Biquad {
+	A, c, sA := shelfParams(f0, S, gain, fs)
+	return newBiquad(
+		A*((A+1)+(A-1)*c+sA),
+		-2*A*((A-1)+(A+1)*c),
+		A*((A+1)+(A-1)*c-sA),
+		(A+1)-(A-1)*c+sA,
+		2*((A-1)-(A+1)*c),
+		(A+1)-(A-1)*c-sA,
+	)
+}
+
+// newBiquad returns the biquad with the given coefficients normalized so a0
+// is 1.
+func newBiquad(b0, b1, b2, a0, a1, a2 float64) Biquad {
+	return Biquad{b0 / a0, b1 / a0, b2 / a0, 1, a1 / a0, a2 / a0}
+}
+
+// biquadParams returns cos(w0) and the cookbook's alpha for the frequency f0
+// and quality factor Q.
+func biquadParams(f0, Q, fs float64) (c, alpha float64) {
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+	if f0 <= 0 || f0 >= fs/2 {
+		panic("f0 must be between 0 and fs/2")
+	}
+	if Q <= 0 {
+		panic("Q must be positive")
+	}
+	w0 := 2 * math.Pi * f0 / fs
+	return math.Cos(w0), math.Sin(w0) / (2 * Q)
+}
+
+// shelfParams returns the amplitude A, cos(w0), and 2*sqrt(A)*alpha for a
+// shelving filter.
+func shelfParams(f0, S, gain, fs float64) (A, c, sA float64) {
+	if S <= 0 {
+		panic("S must be positive")
+	}
+	A = math.Pow(10, gain/40)
+	c, alpha := biquadParams(f0, 1, fs)
+	alpha *= math.Sqrt((A+1/A)*(1/S-1) + 2)
+	return A, c, 2 * math.Sqrt(A) * alpha
+}
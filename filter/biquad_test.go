@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type biquadGain struct {
+	f, gain float64 // frequency (Hz) and expected gain (dB)
+}
+
+type biquadTest struct {
+	name  string
+	bq    Biquad
+	gains []biquadGain
+}
+
+const biquadFs = 48000
+
+var biquadTests = []biquadTest{
+	{
+		"LowpassBiquad",
+		LowpassBiquad(1000, 1/math.Sqrt2, biquadFs),
+		[]biquadGain{{0, 0}, {1000, -10 * math.Log10(2)}},
+	},
+	{
+		"HighpassBiquad",
+		HighpassBiquad(1000, 1/math.Sqrt2, biquadFs),
+		[]biquadGain{{biquadFs / 2, 0}, {1000, -10 * math.Log10(2)}},
+	},
+	{
+		"BandpassBiquad",
+		BandpassBiquad(3000, 2, biquadFs),
+		[]biquadGain{{3000, 0}},
+	},
+	{
+		"AllpassBiquad",
+		AllpassBiquad(3000, 2, biquadFs),
+		[]biquadGain{{0, 0}, {1000, 0}, {3000, 0}, {15000, 0}},
+	},
+	{
+		"PeakingEQ",
+		PeakingEQ(2000, 1, 6, biquadFs),
+		[]biquadGain{{0, 0}, {2000, 6}, {biquadFs / 2, 0}},
+	},
+	{
+		"PeakingEQ cut",
+		PeakingEQ(2000, 4, -12, biquadFs),
+		[]biquadGain{{0, 0}, {2000, -12}, {biquadFs / 2, 0}},
+	},
+	{
+		"LowShelf",
+		LowShelf(500, 1, 9, biquadFs),
+		[]biquadGain{{0, 9}, {250, 8.391228496585992}, {500, 4.5}, {1000, 0.60698569910129}, {biquadFs / 2, 0}},
+	},
+	{
+		// Reference values from the RBJ cookbook formulas.
+		"LowShelf slope",
+		LowShelf(1000, 1, 12, biquadFs),
+		[]biquadGain{{500, 11.106081157598327}, {1000, 6}, {2000, 0.883683977305719}},
+	},
+	{
+		"HighShelf",
+		HighShelf(5000, 0.5, -6, biquadFs),
+		[]biquadGain{{0, 0}, {2500, -1.192466489700799}, {5000, -3}, {10000, -4.974703400611265}, {biquadFs / 2, -6}},
+	},
+}
+
+func TestBiquad(t *testing.T) {
+	for _, bt := range biquadTests {
+		b, a := bt.bq[:3], bt.bq[3:]
+		for _, g := range bt.gains {
+			if o := gainDB(b, a, g.f/biquadFs); math.Abs(o-g.gain) > 1e-6 {
+				t.Error(bt.name, "error\nfrequency:", g.f, "\noutput:", o, "\nexpected:", g.gain)
+			}
+		}
+	}
+
+	// Stop bands.
+	bq := LowpassBiquad(1000, 1/math.Sqrt2, biquadFs)
+	if g := gainDB(bq[:3], bq[3:], 0.5); g > -100 {
+		t.Error("LowpassBiquad Nyquist gain error:", g)
+	}
+	bq = BandpassBiquad(3000, 2, biquadFs)
+	if g := gainDB(bq[:3], bq[3:], 0); g > -100 {
+		t.Error("BandpassBiquad DC gain error:", g)
+	}
+}
+
+func TestCascade(t *testing.T) {
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = math.Sin(float64(i) * 0.3)
+	}
+	b1 := PeakingEQ(2000, 1, 6, biquadFs)
+	b2 := HighpassBiquad(100, 0.5, biquadFs)
+
+	e := b2.Filter(b1.Filter(x))
+	o := SosFilt(Cascade(b1, b2), x)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Cascade error\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Channelizer is a streaming M-channel polyphase analysis filterbank. It
+// splits a complex signal into M uniformly spaced channels, channel k
+// centered on k*fs/M (channels above M/2 are the negative frequencies), each
+// decimated by M. Every block of M input samples costs one pass over the
+// prototype filter and one FFT of length M, rather than M separate filters.
+//
+// Channel k output sample m is
+//
+//	y[k][m] = sum over n of h[n] * exp(2i*pi*k*n/M) * x[m*M-n]
+//
+// for the prototype lowpass filter h.
+type Channelizer struct {
+	m   int
+	h   []float64
+	buf []complex128 // input history and pending input
+	pos int          // index in buf of the input for the next block
+}
+
+// NewChannelizer returns an m channel Channelizer with prototype lowpass
+// filter h, whose cutoff should be about half the channel spacing, fs/(2m).
+// A nil h uses ChannelizerPrototype(m).
+func NewChannelizer(m int, h []float64) *Channelizer {
+	if m < 1 {
+		panic("m must be positive")
+	}
+	if h == nil {
+		h = ChannelizerPrototype(m)
+	}
+	c := &Channelizer{
+		m: m,
+		h: padToMultiple(h, m),
+	}
+	c.Reset()
+	return c
+}
+
+// ChannelizerPrototype returns a 16*m+1 tap Kaiser windowed lowpass filter
+// with cutoff fs/(2m), suitable as the prototype filter of an m channel
+// Channelizer or Synthesizer. Its delay is a multiple of m samples, so the
+// channels keep their relative phase through analysis and synthesis.
+func ChannelizerPrototype(m int) []float64 {
+	return windowedSinc(16*m+1, 1/float64(m), window.KaiserWindow(8).Values)
+}
+
+// Process channelizes the next chunk x of the signal. It returns the new
+// output samples of each channel, indexed by channel then time; there is one
+// new sample per channel for every m input samples.
+func (c *Channelizer) Process(x []complex128) [][]complex128 {
+	c.buf = append(c.buf, x...)
+
+	y := make([][]complex128, c.m)
+	v := make([]complex128, c.m)
+	for ; c.pos < len(c.buf); c.pos += c.m {
+		for r := range v {
+			var sum complex128
+			for q := r; q < len(c.h); q += c.m {
+				sum += complex(c.h[q], 0) * c.buf[c.pos-q]
+			}
+			v[r] = sum
+		}
+		for k, s := range fft.IFFT(v) {
+			y[k] = append(y[k], s*complex(float64(c.m), 0))
+		}
+	}
+
+	// Keep the history needed by the next block.
+	keep := c.pos - (len(c.h) - 1)
+	c.buf = append(c.buf[:0], c.buf[keep:]...)
+	c.pos -= keep
+	return y
+}
+
+// Reset clears the channelizer's history, as at creation.
+func (c *Channelizer) Reset() {
+	c.buf = make([]complex128, len(c.h)-1)
+	c.pos = len(c.buf)
+}
+
+// Synthesizer is a streaming M-channel polyphase synthesis filterbank, the
+// inverse of Channelizer. It upsamples M channel signals by M, shifts
+// channel k to k*fs/M, and sums them:
+//
+//	x[n] = sum over k and m of y[k][m] * g[n-m*M] * exp(2i*pi*k*(n-m*M)/M)
+//
+// for the prototype lowpass filter g.
+type Synthesizer struct {
+	m   int
+	g   []float64
+	acc []complex128 // overlap-add accumulator
+}
+
+// NewSynthesizer returns an m channel Synthesizer with prototype lowpass
+// filter g. A nil g uses ChannelizerPrototype(m) scaled by m, so a signal
+// passed through a Channelizer and Synthesizer with default prototypes is
+// approximately reconstructed, delayed by 16*m samples.
+func NewSynthesizer(m int, g []float64) *Synthesizer {
+	if m < 1 {
+		panic("m must be positive")
+	}
+	if g == nil {
+		g = ChannelizerPrototype(m)
+		for i := range g {
+			g[i] *= float64(m)
+		}
+	}
+	s := &Synthesizer{
+		m: m,
+		g: padToMultiple(g, m),
+	}
+	s.acc = make([]complex128, len(s.g))
+	return s
+}
+
+// Process synthesizes the next chunk of the channel signals y, indexed by
+// channel then time, and returns m output samples for each channel sample.
+// All channels must have the same length.
+func (s *Synthesizer) Process(y [][]complex128) []complex128 {
+	if len(y) != s.m {
+		panic("y must have one slice per channel")
+	}
+	n := len(y[0])
+	for _, ch := range y {
+		if len(ch) != n {
+			panic("channels must have the same length")
+		}
+	}
+
+	x := make([]complex128, 0, n*s.m)
+	col := make([]complex128, s.m)
+	for i := 0; i < n; i++ {
+		for k := range col {
+			col[k] = y[k][i]
+		}
+		w := fft.IFFT(col)
+		for t, v := range s.g {
+			s.acc[t] += complex(v*float64(s.m), 0) * w[t%s.m]
+		}
+
+		// The first m samples receive no later contributions.
+		x = append(x, s.acc[:s.m]...)
+		copy(s.acc, s.acc[s.m:])
+		for t := len(s.acc) - s.m; t < len(s.acc); t++ {
+			s.acc[t] = 0
+		}
+	}
+	return x
+}
+
+// Reset clears the synthesizer's state, as at creation.
+func (s *Synthesizer) Reset() {
+	for i := range s.acc {
+		s.acc[i] = 0
+	}
+}
+
+// padToMultiple returns a copy of h padded with zeros to a multiple of m
+// in length.
+func padToMultiple(h []float64, m int) []float64 {
+	n := (len(h) + m - 1) / m * m
+	r := make([]float64, n)
+	copy(r, h)
+	return r
+}
@@ -0,0 +1,159 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func randComplex(n int) []complex128 {
+	x := make([]complex128, n)
+	for i := range x {
+		x[i] = complex(rand.NormFloat64(), rand.NormFloat64())
+	}
+	return x
+}
+
+func TestChannelizer(t *testing.T) {
+	const M = 4
+	h := []float64{0.1, 0.3, -0.2, 0.5, 0.4, 0.05, -0.1}
+	x := randComplex(45)
+
+	// Compare with the definition.
+	c := NewChannelizer(M, h)
+	y := c.Process(x)
+	for k := 0; k < M; k++ {
+		if len(y[k]) != (len(x)+M-1)/M {
+			t.Fatal("Channelizer length error:", len(y[k]))
+		}
+		for m := range y[k] {
+			var e complex128
+			for n, v := range h {
+				if i := m*M - n; i >= 0 {
+					e += complex(v, 0) * cmplx.Exp(complex(0, 2*math.Pi*float64(k*n)/M)) * x[i]
+				}
+			}
+			if cmplx.Abs(y[k][m]-e) > 1e-9 {
+				t.Fatal("Channelizer error at", k, m, ":", y[k][m], e)
+			}
+		}
+	}
+
+	// Chunked processing gives the same result.
+	c.Reset()
+	var yc [][]complex128
+	for i := 0; i < len(x); i += 7 {
+		end := i + 7
+		if end > len(x) {
+			end = len(x)
+		}
+		o := c.Process(x[i:end])
+		if yc == nil {
+			yc = o
+			continue
+		}
+		for k := range yc {
+			yc[k] = append(yc[k], o[k]...)
+		}
+	}
+	for k := range y {
+		if !dsputils.PrettyCloseC(yc[k], y[k]) {
+			t.Error("Channelizer chunk error\nchannel:", k, "\noutput:", yc[k], "\nexpected:", y[k])
+		}
+	}
+}
+
+func TestChannelizerTone(t *testing.T) {
+	// A tone at the center of channel 3 appears only in channel 3.
+	const M = 8
+	x := make([]complex128, 4096)
+	for i := range x {
+		x[i] = cmplx.Exp(complex(0, 2*math.Pi*3*float64(i)/M))
+	}
+	y := NewChannelizer(M, nil).Process(x)
+	for k := range y {
+		var p float64
+		for _, v := range y[k][64:] {
+			p += real(v)*real(v) + imag(v)*imag(v)
+		}
+		p /= float64(len(y[k]) - 64)
+		if k == 3 && math.Abs(p-1) > 1e-3 {
+			t.Error("Channelizer tone power error:", p)
+		} else if k != 3 && p > 1e-6 {
+			t.Error("Channelizer leakage error in channel", k, ":", p)
+		}
+	}
+}
+
+func TestSynthesizer(t *testing.T) {
+	const M = 4
+	g := []float64{0.3, -0.1, 0.2, 0.6, 0.25}
+	y := make([][]complex128, M)
+	for k := range y {
+		y[k] = randComplex(9)
+	}
+
+	s := NewSynthesizer(M, g)
+	var x []complex128
+	for _, r := range [][2]int{{0, 4}, {4, 5}, {5, 9}} {
+		chunk := make([][]complex128, M)
+		for k := range chunk {
+			chunk[k] = y[k][r[0]:r[1]]
+		}
+		x = append(x, s.Process(chunk)...)
+	}
+	if len(x) != 9*M {
+		t.Fatal("Synthesizer length error:", len(x))
+	}
+
+	// Compare with the definition.
+	for n := range x {
+		var e complex128
+		for k := 0; k < M; k++ {
+			for m := range y[k] {
+				if i := n - m*M; i >= 0 && i < len(g) {
+					e += y[k][m] * complex(g[i], 0) * cmplx.Exp(complex(0, 2*math.Pi*float64(k*i)/M))
+				}
+			}
+		}
+		if cmplx.Abs(x[n]-e) > 1e-9 {
+			t.Fatal("Synthesizer error at", n, ":", x[n], e)
+		}
+	}
+}
+
+func TestChannelizerReconstruction(t *testing.T) {
+	// Tones in the middle of channels survive analysis and synthesis.
+	const M = 8
+	x := make([]complex128, 2048)
+	for i := range x {
+		x[i] = cmplx.Exp(complex(0, 2*math.Pi*2/M*float64(i))) + 0.5*cmplx.Exp(complex(0, -2*math.Pi*1.2/M*float64(i)))
+	}
+	y := NewChannelizer(M, nil).Process(x)
+	o := NewSynthesizer(M, nil).Process(y)
+	d := 16 * M
+	for i := 4 * d; i < len(o); i++ {
+		if cmplx.Abs(o[i]-x[i-d]) > 0.01 {
+			t.Fatal("Channelizer reconstruction error at", i, ":", o[i], x[i-d])
+		}
+	}
+}
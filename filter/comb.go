@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// CombFeedforward returns the transfer function coefficients b and a of a
+// feedforward comb filter, which adds a copy of its input delayed by delay
+// samples and scaled by gain:
+//
+//	y[n] = x[n] + gain*x[n-delay]
+//
+// Its frequency response has nulls (for positive gain) or peaks (for negative
+// gain) at odd multiples of fs/(2*delay). It can remove periodic interference
+// or model a single echo.
+func CombFeedforward(delay int, gain float64) (b, a []float64) {
+	if delay < 1 {
+		panic("delay must be positive")
+	}
+	b = make([]float64, delay+1)
+	b[0] = 1
+	b[delay] = gain
+	return b, []float64{1}
+}
+
+// CombFeedback returns the transfer function coefficients b and a of a
+// feedback comb filter, which adds its output delayed by delay samples and
+// scaled by gain:
+//
+//	y[n] = x[n] + gain*y[n-delay]
+//
+// It produces a decaying train of echoes and resonant peaks at multiples of
+// fs/delay (for positive gain). The magnitude of gain must be less than 1 for
+// the filter to be stable.
+func CombFeedback(delay int, gain float64) (b, a []float64) {
+	if delay < 1 {
+		panic("delay must be positive")
+	}
+	if math.Abs(gain) >= 1 {
+		panic("gain magnitude must be less than 1")
+	}
+	a = make([]float64, delay+1)
+	a[0] = 1
+	a[delay] = -gain
+	return []float64{1}, a
+}
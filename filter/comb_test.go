@@ -0,0 +1,60 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestCombFeedforward(t *testing.T) {
+	b, a := CombFeedforward(3, 0.5)
+	x := []float64{1, 2, 0, 0, 1, 0, 0}
+	e := []float64{1, 2, 0, 0.5, 2, 0, 0}
+	if o := Lfilter(b, a, x); !dsputils.PrettyClose(o, e) {
+		t.Error("CombFeedforward error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+
+	// Unity gain nulls odd multiples of fs/(2*delay).
+	b, a = CombFeedforward(4, 1)
+	for _, f := range []float64{1.0 / 8, 3.0 / 8} {
+		if g := gainDB(b, a, f); g > -100 {
+			t.Error("CombFeedforward null error:", f, g)
+		}
+	}
+	if g := gainDB(b, a, 0.25); math.Abs(g-20*math.Log10(2)) > 1e-9 {
+		t.Error("CombFeedforward peak error:", g)
+	}
+}
+
+func TestCombFeedback(t *testing.T) {
+	b, a := CombFeedback(2, 0.5)
+	e := []float64{1, 0, 0.5, 0, 0.25, 0, 0.125}
+	if o := Impz(b, a, len(e)); !dsputils.PrettyClose(o, e) {
+		t.Error("CombFeedback error\noutput:", o, "\nexpected:", e)
+	}
+
+	// Peaks of 1/(1-gain) at multiples of fs/delay.
+	b, a = CombFeedback(5, 0.9)
+	for _, f := range []float64{0, 0.2, 0.4} {
+		if g := gainDB(b, a, f); math.Abs(g-20) > 1e-9 {
+			t.Error("CombFeedback peak error:", f, g)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// CompressorOptions holds the parameters of a Compressor. Levels are in dB
+// relative to full scale, 1.0.
+type CompressorOptions struct {
+	// Threshold is the level above which the gain is reduced.
+	//
+	// The default value is 0 dB.
+	Threshold float64
+
+	// Ratio is the ratio of the input level above the threshold to the
+	// output level above it; math.Inf(1) makes a limiter.
+	//
+	// The default value is 0, which uses 4.
+	Ratio float64
+
+	// Knee is the width of the soft knee around the threshold, over which
+	// the ratio changes gradually.
+	//
+	// The default value is 0, a hard knee.
+	Knee float64
+
+	// Attack and Release are the time constants, in seconds, with which the
+	// gain reduction rises and falls.
+	//
+	// The default values are 0, which change the gain immediately.
+	Attack, Release float64
+
+	// Makeup is the gain applied after compression.
+	//
+	// The default value is 0 dB.
+	Makeup float64
+}
+
+// Compressor is a streaming dynamic range compressor: it reduces the gain
+// of its input by an amount that depends on the input level, as set by a
+// static gain curve of threshold, ratio and knee, smoothed in dB with
+// separate attack and release time constants.
+// Reference: Giannoulis, Massberg and Reiss, "Digital Dynamic Range
+// Compressor Design - A Tutorial and Analysis", JAES 60(6), 2012.
+type Compressor struct {
+	threshold, slope, knee float64
+	attack, release        float64
+	makeup                 float64
+	reduction              float64 // smoothed gain reduction in dB
+}
+
+// NewCompressor returns a Compressor for signals sampled at fs with the
+// options o. A nil o uses the default options.
+func NewCompressor(fs float64, o *CompressorOptions) *Compressor {
+	var opts CompressorOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Ratio == 0 {
+		opts.Ratio = 4
+	}
+	if opts.Ratio < 1 {
+		panic("ratio must be at least 1")
+	}
+	if opts.Knee < 0 || opts.Attack < 0 || opts.Release < 0 {
+		panic("knee, attack and release must be non-negative")
+	}
+	return &Compressor{
+		threshold: opts.Threshold,
+		slope:     1/opts.Ratio - 1,
+		knee:      opts.Knee,
+		attack:    envelopeCoef(opts.Attack, fs),
+		release:   envelopeCoef(opts.Release, fs),
+		makeup:    opts.Makeup,
+	}
+}
+
+// NewLimiter returns a Compressor with an infinite ratio, hard knee and
+// immediate attack, so its output never exceeds threshold, in dB. The gain
+// recovers with the release time constant, in seconds.
+func NewLimiter(threshold, release, fs float64) *Compressor {
+	return NewCompressor(fs, &CompressorOptions{
+		Threshold: threshold,
+		Ratio:     math.Inf(1),
+		Release:   release,
+	})
+}
+
+// curve returns the static gain reduction, in dB, for the input level l.
+func (c *Compressor) curve(l float64) float64 {
+	d := l - c.threshold
+	switch {
+	case 2*d <= -c.knee:
+		return 0
+	case 2*math.Abs(d) < c.knee:
+		d += c.knee / 2
+		return -c.slope * d * d / (2 * c.knee)
+	}
+	return -c.slope * d
+}
+
+// Next returns the gain, as a factor, for the next sample whose detector
+// input is key: the sample itself, or a sidechain signal.
+func (c *Compressor) Next(key float64) float64 {
+	r := c.curve(20 * math.Log10(math.Abs(key)))
+	g := c.release
+	if r > c.reduction {
+		g = c.attack
+	}
+	c.reduction = g*c.reduction + (1-g)*r
+	return math.Pow(10, (c.makeup-c.reduction)/20)
+}
+
+// Process returns the next chunk x of the signal, compressed.
+func (c *Compressor) Process(x []float64) []float64 {
+	return c.ProcessSidechain(x, x)
+}
+
+// ProcessSidechain returns the next chunk x of the signal, compressed
+// according to the level of the sidechain key instead of x, as for ducking
+// music under a voice. key must be as long as x.
+func (c *Compressor) ProcessSidechain(x, key []float64) []float64 {
+	if len(key) != len(x) {
+		panic("key must be as long as x")
+	}
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = v * c.Next(key[i])
+	}
+	return r
+}
+
+// GainReduction returns the current gain reduction, in dB, before makeup
+// gain.
+func (c *Compressor) GainReduction() float64 {
+	return c.reduction
+}
+
+// Reset clears the gain reduction, as at creation.
+func (c *Compressor) Reset() {
+	c.reduction = 0
+}
@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCompressorCurve(t *testing.T) {
+	tests := []struct {
+		o    CompressorOptions
+		in   float64 // dB
+		gain float64 // dB
+	}{
+		{CompressorOptions{Threshold: -20}, -30, 0},
+		{CompressorOptions{Threshold: -20}, -20, 0},
+		{CompressorOptions{Threshold: -20}, -8, -9},
+		{CompressorOptions{Threshold: -20, Ratio: 2, Makeup: 3}, -10, -2},
+		{CompressorOptions{Threshold: -20, Knee: 10}, -20, -0.9375},
+		{CompressorOptions{Threshold: -20, Knee: 10}, -25, 0},
+		{CompressorOptions{Threshold: -20, Knee: 10}, -15, -3.75},
+		{CompressorOptions{Threshold: -6, Ratio: math.Inf(1)}, 0, -6},
+	}
+	for _, v := range tests {
+		// A constant input, after the gain has settled.
+		x := make([]float64, 10)
+		for i := range x {
+			x[i] = math.Pow(10, v.in/20)
+		}
+		y := NewCompressor(48000, &v.o).Process(x)
+		if g := 20 * math.Log10(y[9]/x[9]); math.Abs(g-v.gain) > 1e-9 {
+			t.Errorf("Compressor %+v at %v dB: got gain %v, expected %v", v.o, v.in, g, v.gain)
+		}
+	}
+}
+
+func TestCompressorTiming(t *testing.T) {
+	const fs = 1000
+	c := NewCompressor(fs, &CompressorOptions{Threshold: -20, Ratio: 2, Attack: 0.01, Release: 0.1})
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = 1
+	}
+	c.Process(x[:10])
+	// After one attack time constant, 63% of the 10 dB reduction.
+	if r := c.GainReduction(); math.Abs(r-10*(1-math.Exp(-1))) > 1e-9 {
+		t.Errorf("attack: got %v dB, expected %v", r, 10*(1-math.Exp(-1)))
+	}
+	c.Process(x[10:])
+	if r := c.GainReduction(); math.Abs(r-10) > 1e-6 {
+		t.Errorf("attack settled: got %v dB, expected 10", r)
+	}
+	c.Process(make([]float64, 100))
+	if r := c.GainReduction(); math.Abs(r-10*math.Exp(-1)) > 1e-6 {
+		t.Errorf("release: got %v dB, expected %v", r, 10*math.Exp(-1))
+	}
+	c.Reset()
+	if r := c.GainReduction(); r != 0 {
+		t.Errorf("Reset: got %v dB, expected 0", r)
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	const fs = 8000
+	l := NewLimiter(-6, 0.05, fs)
+	max := math.Pow(10, -6.0/20)
+	x := make([]float64, 4000)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 440 * float64(i) / fs)
+	}
+	for i, v := range l.Process(x) {
+		if math.Abs(v) > max+1e-12 {
+			t.Fatalf("Limiter: sample %v is %v, above %v", i, v, max)
+		}
+	}
+}
+
+func TestCompressorSidechain(t *testing.T) {
+	c := NewCompressor(1000, &CompressorOptions{Threshold: -20, Ratio: math.Inf(1)})
+	x := []float64{0.1, 0.1, 0.1}
+	key := []float64{0, 1, 0.01}
+	y := c.ProcessSidechain(x, key)
+	e := []float64{0.1, 0.01, 0.1}
+	for i := range y {
+		if math.Abs(y[i]-e[i]) > 1e-12 {
+			t.Error("ProcessSidechain error\ninput:", x, key, "\noutput:", y, "\nexpected:", e)
+			break
+		}
+	}
+}
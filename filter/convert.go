@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// poly returns the coefficients, highest power first, of the monic
+// polynomial with the given roots.
+func poly(roots []complex128) []complex128 {
+	r := make([]complex128, len(roots)+1)
+	r[0] = 1
+	for i, v := range roots {
+		for j := i + 1; j > 0; j-- {
+			r[j] -= v * r[j-1]
+		}
+	}
+	return r
+}
+
+// Zpk2Tf returns the transfer function coefficients b and a of the filter
+// with zeros z, poles p, and gain k. Complex zeros and poles must be in
+// conjugate pairs.
+func Zpk2Tf(z, p []complex128, k float64) (b, a []float64) {
+	pb := poly(z)
+	b = make([]float64, len(pb))
+	for i, v := range pb {
+		b[i] = k * real(v)
+	}
+
+	pa := poly(p)
+	a = make([]float64, len(pa))
+	for i, v := range pa {
+		a[i] = real(v)
+	}
+
+	return b, a
+}
+
+// Tf2Zpk returns the zeros z, poles p, and gain k of the filter with
+// transfer function coefficients b and a.
+func Tf2Zpk(b, a []float64) (z, p []complex128, k float64) {
+	b, a = normalize(b, a)
+
+	// Leading zeros in b would give roots at infinity.
+	for len(b) > 1 && b[0] == 0 {
+		b = b[1:]
+	}
+	if b[0] == 0 {
+		return []complex128{}, dsputils.Roots(a), 0
+	}
+
+	k = b[0]
+	nb := make([]float64, len(b))
+	for i, v := range b {
+		nb[i] = v / k
+	}
+
+	return dsputils.Roots(nb), dsputils.Roots(a), k
+}
+
+// Tf2Sos returns the second-order sections of the filter with transfer
+// function coefficients b and a.
+func Tf2Sos(b, a []float64) [][6]float64 {
+	return Zpk2Sos(Tf2Zpk(b, a))
+}
+
+// Sos2Tf returns the transfer function coefficients b and a of the filter
+// with second-order sections sos.
+func Sos2Tf(sos [][6]float64) (b, a []float64) {
+	b = []float64{1}
+	a = []float64{1}
+	for _, s := range sos {
+		b = polyMul(b, s[:3])
+		a = polyMul(a, s[3:])
+	}
+	return b, a
+}
+
+// polyMul returns the product of the polynomials x and y.
+func polyMul(x, y []float64) []float64 {
+	r := make([]float64, len(x)+len(y)-1)
+	for i, xv := range x {
+		for j, yv := range y {
+			r[i+j] += xv * yv
+		}
+	}
+	return r
+}
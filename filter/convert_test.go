@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestConvert(t *testing.T) {
+	// (1 - z^-1)(1 + 0.5z^-1) / (1 - 0.5z^-1 + 0.25z^-2)
+	b := []float64{2, -1, -1}
+	a := []float64{1, -0.5, 0.25}
+
+	z, p, k := Tf2Zpk(b, a)
+	checkZpk(t, zpkTest{
+		name: "Tf2Zpk",
+		z:    z, p: p, k: k,
+		ez: []complex128{1, -0.5},
+		ep: []complex128{complex(0.25, 0.4330127018922193), complex(0.25, -0.4330127018922193)},
+		ek: 2,
+	})
+
+	bo, ao := Zpk2Tf(z, p, k)
+	if !dsputils.PrettyClose(bo, b) || !dsputils.PrettyClose(ao, a) {
+		t.Error("Zpk2Tf error\noutput:", bo, ao, "\nexpected:", b, a)
+	}
+
+	sos := Tf2Sos(b, a)
+	if len(sos) != 1 {
+		t.Fatal("Tf2Sos section count error:", sos)
+	}
+	bo, ao = Sos2Tf(sos)
+	if !dsputils.PrettyClose(bo, b) || !dsputils.PrettyClose(ao, a) {
+		t.Error("Sos2Tf error\noutput:", bo, ao, "\nexpected:", b, a)
+	}
+}
+
+func TestConvertRoundTrip(t *testing.T) {
+	b, a := Cheby2(8, 40, []float64{0.2, 0.3}, Bandpass, 1)
+	z, p, k := Tf2Zpk(b, a)
+	if len(z) != 16 || len(p) != 16 {
+		t.Fatal("Tf2Zpk order error:", len(z), len(p))
+	}
+
+	sos := Zpk2Sos(z, p, k)
+	if len(sos) != 8 {
+		t.Fatal("Zpk2Sos section count error:", len(sos))
+	}
+
+	bo, ao := Sos2Tf(sos)
+	for i := range b {
+		if d := bo[i] - b[i]; d > 1e-9 || d < -1e-9 {
+			t.Fatal("round trip b error\noutput:", bo, "\nexpected:", b)
+		}
+		if d := ao[i] - a[i]; d > 1e-7 || d < -1e-7 {
+			t.Fatal("round trip a error\noutput:", ao, "\nexpected:", a)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Convolve returns the full linear convolution of x and h, which has length
+// len(x) + len(h) - 1. It is computed directly for short kernels and with
+// FFTConvolve otherwise, whichever is estimated to be faster.
+func Convolve(x, h []float64) []float64 {
+	if len(x) == 0 || len(h) == 0 {
+		return []float64{}
+	}
+	if len(h) > len(x) {
+		x, h = h, x
+	}
+
+	n := oaFFTSize(len(h))
+	step := n - len(h) + 1
+	blocks := (len(x) + step - 1) / step
+	direct := float64(len(x)) * float64(len(h))
+	fast := float64(blocks) * 3 * float64(n) * math.Log2(float64(n))
+	if direct <= fast {
+		return directConvolve(x, h)
+	}
+	return FFTConvolve(x, h)
+}
+
+// FFTConvolve returns the full linear convolution of x and h computed with
+// FFTs, using fft.OverlapAdd so that long signals are processed in blocks
+// sized to the shorter input.
+func FFTConvolve(x, h []float64) []float64 {
+	if len(x) == 0 || len(h) == 0 {
+		return []float64{}
+	}
+	if len(h) > len(x) {
+		x, h = h, x
+	}
+
+	step := oaFFTSize(len(h)) - len(h) + 1
+	o := fft.NewOverlapAdd(dsputils.ToComplex(h), step)
+	y := make([]float64, 0, len(x)+len(h)-1)
+	for start := 0; start < len(x); start += step {
+		end := start + step
+		if end > len(x) {
+			end = len(x)
+		}
+		for _, v := range o.Process(dsputils.ToComplex(x[start:end])) {
+			y = append(y, real(v))
+		}
+	}
+	for _, v := range o.Flush() {
+		y = append(y, real(v))
+	}
+	return y
+}
+
+// FIRFilter filters x with the FIR filter h. The result is the same as
+// Lfilter(h, []float64{1}, x), but is computed with Convolve, which is much
+// faster for long filters.
+func FIRFilter(h, x []float64) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+	return Convolve(x, h)[:len(x)]
+}
+
+// oaFFTSize returns the FFT size used by the overlap-add method for a
+// kernel of length m.
+func oaFFTSize(m int) int {
+	n := dsputils.NextPowerOf2(4 * m)
+	if n < 64 {
+		n = 64
+	}
+	return n
+}
+
+// directConvolve returns the full linear convolution of x and h computed
+// directly, as one dot product of x with the reversed h per output.
+func directConvolve(x, h []float64) []float64 {
+	m := len(h)
+	hr := make([]float64, m)
+	for i, v := range h {
+		hr[m-1-i] = v
+	}
+	xp := make([]float64, len(x)+2*(m-1))
+	copy(xp[m-1:], x)
+
+	y := make([]float64, len(x)+m-1)
+	for i := range y {
+		y[i] = dot(hr, xp[i:])
+	}
+	return y
+}
@@ -0,0 +1,182 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Boundary is the extension of a 2-D signal past its edges used by
+// Convolve2D and Correlate2D.
+type Boundary int
+
+const (
+	// BoundaryZero pads with zeros.
+	BoundaryZero Boundary = iota
+
+	// BoundaryReflect mirrors the signal about its edges, repeating the edge
+	// samples: d c b a | a b c d | d c b a. This is scipy's "symm".
+	BoundaryReflect
+
+	// BoundaryWrap repeats the signal periodically: a b c d | a b c d.
+	BoundaryWrap
+)
+
+// extend returns the index of sample i of a signal of length n extended by
+// b, or -1 if it is zero.
+func (b Boundary) extend(i, n int) int {
+	if i >= 0 && i < n {
+		return i
+	}
+	switch b {
+	case BoundaryZero:
+		return -1
+	case BoundaryReflect:
+		i %= 2 * n
+		if i < 0 {
+			i += 2 * n
+		}
+		if i >= n {
+			i = 2*n - 1 - i
+		}
+		return i
+	case BoundaryWrap:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return i
+	}
+	panic("unknown boundary")
+}
+
+// Convolve2D returns the 2-D convolution of the matrix x with the kernel h,
+// with x extended past its edges by b. The result has the size of x and is
+// centered like scipy.signal.convolve2d with mode "same". It is computed
+// directly for small kernels and with FFT2 otherwise, whichever is
+// estimated to be faster. x and h must not be empty or ragged.
+func Convolve2D(x, h [][]float64, b Boundary) [][]float64 {
+	hr, hc := size2(h)
+	xr, xc := size2(x)
+	direct := float64(xr*xc) * float64(hr*hc)
+	n := float64(dsputils.NextPowerOf2(xr+hr-1) * dsputils.NextPowerOf2(xc+hc-1))
+	return convolve2D(x, h, b, direct > 3*n*math.Log2(n))
+}
+
+// Correlate2D returns the 2-D cross-correlation of the matrix x with the
+// template h, as Convolve2D with h reversed along both axes.
+func Correlate2D(x, h [][]float64, b Boundary) [][]float64 {
+	hr, hc := size2(h)
+	f := make([][]float64, hr)
+	for i := range f {
+		f[i] = make([]float64, hc)
+		for j := range f[i] {
+			f[i][j] = h[hr-1-i][hc-1-j]
+		}
+	}
+	return Convolve2D(x, f, b)
+}
+
+// size2 returns the dimensions of x, panicking if it is empty or ragged.
+func size2(x [][]float64) (rows, cols int) {
+	if len(x) == 0 || len(x[0]) == 0 {
+		panic("empty matrix")
+	}
+	for _, r := range x {
+		if len(r) != len(x[0]) {
+			panic("ragged matrix")
+		}
+	}
+	return len(x), len(x[0])
+}
+
+func convolve2D(x, h [][]float64, b Boundary, useFFT bool) [][]float64 {
+	xr, xc := size2(x)
+	hr, hc := size2(h)
+
+	// out[i][j] is the sum of h[a][c] * ext(x)[i+cr-a][j+cc-c]; p is ext(x)
+	// from row -(hr-1-cr) and column -(hc-1-cc), so that out is the valid
+	// part of the convolution of p with h.
+	cr, cc := (hr-1)/2, (hc-1)/2
+	pr, pc := xr+hr-1, xc+hc-1
+	p := make([][]float64, pr)
+	for i := range p {
+		p[i] = make([]float64, pc)
+		xi := b.extend(i-(hr-1-cr), xr)
+		if xi < 0 {
+			continue
+		}
+		for j := range p[i] {
+			if xj := b.extend(j-(hc-1-cc), xc); xj >= 0 {
+				p[i][j] = x[xi][xj]
+			}
+		}
+	}
+
+	out := make([][]float64, xr)
+	if !useFFT {
+		for i := range out {
+			out[i] = make([]float64, xc)
+			for j := range out[i] {
+				var s float64
+				for a, hrow := range h {
+					prow := p[i+hr-1-a]
+					for c, v := range hrow {
+						s += v * prow[j+hc-1-c]
+					}
+				}
+				out[i][j] = s
+			}
+		}
+		return out
+	}
+
+	// Circular convolution of sizes at least pr x pc wraps only into the
+	// first hr-1 rows and hc-1 columns, which are not part of the valid
+	// result.
+	fr, fc := dsputils.NextPowerOf2(pr), dsputils.NextPowerOf2(pc)
+	P := fft.FFT2Real(pad2(p, fr, fc))
+	H := fft.FFT2Real(pad2(h, fr, fc))
+	for i := range P {
+		for j := range P[i] {
+			P[i][j] *= H[i][j]
+		}
+	}
+	y := fft.IFFT2(P)
+	for i := range out {
+		out[i] = make([]float64, xc)
+		for j := range out[i] {
+			out[i][j] = real(y[i+hr-1][j+hc-1])
+		}
+	}
+	return out
+}
+
+// pad2 returns x zero padded to rows x cols.
+func pad2(x [][]float64, rows, cols int) [][]float64 {
+	r := make([][]float64, rows)
+	for i := range r {
+		r[i] = make([]float64, cols)
+		if i < len(x) {
+			copy(r[i], x[i])
+		}
+	}
+	return r
+}
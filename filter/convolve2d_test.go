@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestConvolve2D(t *testing.T) {
+	x := [][]float64{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}
+	tests := []struct {
+		h   [][]float64
+		b   Boundary
+		out [][]float64
+	}{
+		// scipy.signal.convolve2d(x, h, mode='same', boundary=...)
+		{[][]float64{{0, 1}, {1, 0}}, BoundaryZero, [][]float64{
+			{0, 1, 2},
+			{1, 6, 8},
+			{4, 12, 14},
+		}},
+		{[][]float64{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}}, BoundaryZero, [][]float64{
+			{12, 21, 16},
+			{27, 45, 33},
+			{24, 39, 28},
+		}},
+		{[][]float64{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}}, BoundaryReflect, [][]float64{
+			{21, 27, 33},
+			{39, 45, 51},
+			{57, 63, 69},
+		}},
+		{[][]float64{{1, 1, 1}, {1, 1, 1}, {1, 1, 1}}, BoundaryWrap, [][]float64{
+			{45, 45, 45},
+			{45, 45, 45},
+			{45, 45, 45},
+		}},
+		// An impulse offset by one shifts x, wrapping around.
+		{[][]float64{{0, 0, 0}, {0, 0, 0}, {0, 0, 1}}, BoundaryWrap, [][]float64{
+			{9, 7, 8},
+			{3, 1, 2},
+			{6, 4, 5},
+		}},
+	}
+	for _, v := range tests {
+		for _, useFFT := range []bool{false, true} {
+			o := convolve2D(x, v.h, v.b, useFFT)
+			if !dsputils.PrettyClose2F(o, v.out) {
+				t.Error("Convolve2D error\ninput:", x, v.h, v.b, useFFT, "\noutput:", o, "\nexpected:", v.out)
+			}
+		}
+	}
+	if o := Convolve2D(x, tests[1].h, BoundaryZero); !dsputils.PrettyClose2F(o, tests[1].out) {
+		t.Error("Convolve2D error\noutput:", o, "\nexpected:", tests[1].out)
+	}
+}
+
+func TestConvolve2DFFT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	rand2 := func(rows, cols int) [][]float64 {
+		x := make([][]float64, rows)
+		for i := range x {
+			x[i] = make([]float64, cols)
+			for j := range x[i] {
+				x[i][j] = r.NormFloat64()
+			}
+		}
+		return x
+	}
+	x := rand2(40, 30)
+	for _, b := range []Boundary{BoundaryZero, BoundaryReflect, BoundaryWrap} {
+		for _, h := range [][][]float64{rand2(5, 4), rand2(11, 11), rand2(1, 7)} {
+			d := convolve2D(x, h, b, false)
+			f := convolve2D(x, h, b, true)
+			if !dsputils.PrettyClose2F(f, d) {
+				t.Errorf("Convolve2D FFT error: boundary %v, kernel %vx%v", b, len(h), len(h[0]))
+			}
+		}
+	}
+}
+
+func TestCorrelate2D(t *testing.T) {
+	x := [][]float64{
+		{0, 0, 0, 0},
+		{0, 1, 2, 0},
+		{0, 3, 4, 0},
+		{0, 0, 0, 0},
+	}
+	h := [][]float64{{1, 2}, {3, 4}}
+	o := Correlate2D(x, h, BoundaryZero)
+	e := [][]float64{
+		{0, 0, 0, 0},
+		{0, 4, 11, 6},
+		{0, 14, 30, 14},
+		{0, 6, 11, 4},
+	}
+	// The peak, the template's energy, is at the center of the match, which
+	// for an even sized template is rounded down and right.
+	if !dsputils.PrettyClose2F(o, e) {
+		t.Error("Correlate2D error\ninput:", x, h, "\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestConvolve(t *testing.T) {
+	x := []float64{1, 2, 3}
+	h := []float64{0, 1, 0.5}
+	e := []float64{0, 1, 2.5, 4, 1.5}
+	if o := Convolve(x, h); !dsputils.PrettyClose(o, e) {
+		t.Error("Convolve error\noutput:", o, "\nexpected:", e)
+	}
+	if o := FFTConvolve(h, x); !dsputils.PrettyClose(o, e) {
+		t.Error("FFTConvolve error\noutput:", o, "\nexpected:", e)
+	}
+	if o := Convolve(nil, h); len(o) != 0 {
+		t.Error("Convolve empty error:", o)
+	}
+
+	// Long signals and kernels use overlap-add.
+	for _, lens := range [][2]int{{1000, 3}, {1000, 100}, {5000, 700}, {300, 1000}} {
+		x := make([]float64, lens[0])
+		h := make([]float64, lens[1])
+		for i := range x {
+			x[i] = math.Sin(float64(i) * 0.1)
+		}
+		for i := range h {
+			h[i] = math.Cos(float64(i)*0.37) / float64(i+1)
+		}
+
+		e := directConvolve(x, h)
+		for _, o := range [][]float64{Convolve(x, h), FFTConvolve(x, h)} {
+			if len(o) != len(e) {
+				t.Fatal("Convolve length error:", len(o), len(e))
+			}
+			for i := range o {
+				if math.Abs(o[i]-e[i]) > 1e-9 {
+					t.Fatal("Convolve error at", i, lens, ":", o[i], e[i])
+				}
+			}
+		}
+	}
+}
+
+func TestFIRFilter(t *testing.T) {
+	x := make([]float64, 2000)
+	h := make([]float64, 300)
+	for i := range x {
+		x[i] = float64(i%13) - 6
+	}
+	for i := range h {
+		h[i] = 1 / float64(i+1)
+	}
+
+	o := FIRFilter(h, x)
+	e := Lfilter(h, []float64{1}, x)
+	for i := range o {
+		if math.Abs(o[i]-e[i]) > 1e-9 {
+			t.Fatal("FIRFilter error at", i, ":", o[i], e[i])
+		}
+	}
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// epsilon is the difference between 1 and the next larger float64.
+const epsilon = 2.220446049250313e-16
+
+// GroupDelay returns the group delay gd, in samples, of the filter with
+// transfer function coefficients b and a at the n frequencies freqs used by
+// Freqz. At frequencies where the response is zero the group delay is
+// undefined and is set to 0.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.group_delay.html
+func GroupDelay(b, a []float64, n int, fs float64) (gd, freqs []float64) {
+	if len(a) == 0 || a[0] == 0 {
+		panic("a[0] must be nonzero")
+	}
+
+	// The group delay of b/a is that of b * reversed(a), less the delay
+	// introduced by the reversal.
+	ar := make([]float64, len(a))
+	for i, v := range a {
+		ar[len(a)-1-i] = v
+	}
+	c := polyMul(b, ar)
+	cr := make([]float64, len(c))
+	for i, v := range c {
+		cr[i] = v * float64(i)
+	}
+
+	num := polyResponse(cr, n)
+	den := polyResponse(c, n)
+
+	gd = make([]float64, n)
+	for i := range gd {
+		if cmplx.Abs(den[i]) < 10*epsilon {
+			continue
+		}
+		gd[i] = real(num[i]/den[i]) - float64(len(a)-1)
+	}
+
+	return gd, freqzFreqs(n, fs)
+}
+
+// PhaseDelay returns the phase delay pd, in samples, of the filter with
+// transfer function coefficients b and a at the n frequencies freqs used by
+// Freqz: the negated unwrapped phase divided by frequency. At 0 Hz, where
+// phase delay is undefined, the group delay is used.
+func PhaseDelay(b, a []float64, n int, fs float64) (pd, freqs []float64) {
+	h, freqs := Freqz(b, a, n, fs)
+
+	phase := make([]float64, n)
+	for i, v := range h {
+		phase[i] = cmplx.Phase(v)
+	}
+	phase = dsputils.Unwrap(phase)
+
+	pd = make([]float64, n)
+	for i := 1; i < n; i++ {
+		w := math.Pi * float64(i) / float64(n)
+		pd[i] = -phase[i] / w
+	}
+	if n > 0 {
+		gd, _ := GroupDelay(b, a, 1, fs)
+		pd[0] = gd[0]
+	}
+
+	return pd, freqs
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestGroupDelay(t *testing.T) {
+	// A symmetric FIR filter has constant delay (N-1)/2.
+	b := []float64{1, 2, 3, 2, 1}
+	gd, freqs := GroupDelay(b, []float64{1}, 8, 2)
+	pd, _ := PhaseDelay(b, []float64{1}, 8, 2)
+	for i := range gd {
+		if !dsputils.Float64Equal(gd[i], 2) {
+			t.Error("GroupDelay FIR error at", freqs[i], ":", gd[i])
+		}
+		if !dsputils.Float64Equal(pd[i], 2) {
+			t.Error("PhaseDelay FIR error at", freqs[i], ":", pd[i])
+		}
+	}
+
+	// One-pole IIR: y[n] = x[n] + r y[n-1] has group delay
+	// (r cos w - r^2) / (1 - 2r cos w + r^2).
+	const r = 0.5
+	gd, _ = GroupDelay([]float64{1}, []float64{1, -r}, 16, 1)
+	for i, v := range gd {
+		c := math.Cos(math.Pi * float64(i) / 16)
+		e := (r*c - r*r) / (1 - 2*r*c + r*r)
+		if !dsputils.Float64Equal(v, e) {
+			t.Error("GroupDelay IIR error at", i, ":", v, e)
+		}
+	}
+
+	// A pure delay has phase delay equal to the delay.
+	pd, _ = PhaseDelay([]float64{0, 0, 0, 1}, []float64{1}, 16, 1)
+	for i, v := range pd {
+		if !dsputils.Float64Equal(v, 3) {
+			t.Error("PhaseDelay delay error at", i, ":", v)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// DifferentiatorFIR returns an odd length numtaps FIR differentiator designed
+// by windowing the ideal impulse response, cos(pi*n)/n for n != 0, with wf. A
+// nil wf uses window.Hamming. The output is the derivative in units per
+// sample (multiply by the sampling frequency for units per second), delayed
+// by (numtaps-1)/2 samples. Windowing leaves a small absolute ripple in the
+// gain, so DifferentiatorRemez is more accurate for slowly varying signals.
+func DifferentiatorFIR(numtaps int, wf func(int) []float64) []float64 {
+	if numtaps < 3 || numtaps%2 == 0 {
+		panic("numtaps must be odd and at least 3")
+	}
+	if wf == nil {
+		wf = window.Hamming
+	}
+
+	h := wf(numtaps)
+	M := numtaps / 2
+	for i := range h {
+		n := i - M
+		if n == 0 {
+			h[i] = 0
+		} else {
+			h[i] *= math.Cos(math.Pi*float64(n)) / float64(n)
+		}
+	}
+	return h
+}
+
+// DifferentiatorRemez returns a numtaps equiripple FIR differentiator,
+// designed with Remez, that minimizes the relative error between 0 and the
+// band edge edge, in the same units as the sampling frequency fs. The output
+// is in units per sample, as for DifferentiatorFIR. Even length filters have
+// a half sample delay but are more accurate near the Nyquist frequency.
+func DifferentiatorRemez(numtaps int, edge, fs float64) ([]float64, error) {
+	if edge <= 0 || edge > fs/2 {
+		panic("edge must be between 0 and fs/2")
+	}
+	return Remez(numtaps, []float64{0, edge}, []float64{2 * math.Pi}, nil, fs, RemezDifferentiator)
+}
+
+// Diff returns the first difference of x, x[i+1]-x[i], which has one
+// fewer element than x.
+func Diff(x []float64) []float64 {
+	if len(x) < 2 {
+		return []float64{}
+	}
+	r := make([]float64, len(x)-1)
+	for i := range r {
+		r[i] = x[i+1] - x[i]
+	}
+	return r
+}
+
+// Gradient returns the derivative of x in units per sample, estimated by
+// central differences in the interior and one-sided differences at the ends.
+// The result has the same length as x.
+// Reference: http://docs.scipy.org/doc/numpy/reference/generated/numpy.gradient.html
+func Gradient(x []float64) []float64 {
+	r := make([]float64, len(x))
+	if len(x) < 2 {
+		return r
+	}
+	last := len(x) - 1
+	r[0] = x[1] - x[0]
+	r[last] = x[last] - x[last-1]
+	for i := 1; i < last; i++ {
+		r[i] = (x[i+1] - x[i-1]) / 2
+	}
+	return r
+}
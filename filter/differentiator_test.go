@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestDifferentiatorFIR(t *testing.T) {
+	h := DifferentiatorFIR(31, nil)
+	for f := 0.01; f <= 0.3; f += 0.01 {
+		want := 2 * math.Pi * f
+		if e := math.Abs(response(h, f) - want); e > 0.01 {
+			t.Error("DifferentiatorFIR error at", f, ":", response(h, f), want)
+		}
+	}
+
+	// The derivative of a ramp is its slope, after the filter delay.
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = 0.25 * float64(i)
+	}
+	y := FIRFilter(h, x)
+	for i := len(h); i < len(y); i++ {
+		if math.Abs(y[i]-0.25) > 0.025 {
+			t.Fatal("DifferentiatorFIR ramp error at", i, ":", y[i])
+		}
+	}
+
+	h, err := DifferentiatorRemez(31, 400, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f := 0.01; f <= 0.4; f += 0.01 {
+		want := 2 * math.Pi * f
+		if e := math.Abs(response(h, f)-want) / want; e > 0.01 {
+			t.Error("DifferentiatorRemez error at", f, ":", response(h, f), want)
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	x := []float64{1, 4, 9, 16, 25}
+
+	o := Diff(x)
+	e := []float64{3, 5, 7, 9}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Diff error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+
+	o = Gradient(x)
+	e = []float64{3, 4, 6, 8, 9}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Gradient error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// The functions in this file compute the complete elliptic integral of the
+// first kind and the Jacobi elliptic functions used to design elliptic
+// filters. m is the parameter, the square of the modulus k.
+// Reference: https://dlmf.nist.gov/19.8 and https://dlmf.nist.gov/22.20
+
+// agm returns the arithmetic-geometric mean of a and b.
+func agm(a, b float64) float64 {
+	for math.Abs(a-b) > 1e-15*a {
+		a, b = (a+b)/2, math.Sqrt(a*b)
+	}
+	return a
+}
+
+// ellipk returns the complete elliptic integral of the first kind K(m).
+func ellipk(m float64) float64 {
+	return math.Pi / (2 * agm(1, math.Sqrt(1-m)))
+}
+
+// ellipkm1 returns K(1-m), accurately for small m.
+func ellipkm1(m float64) float64 {
+	return math.Pi / (2 * agm(1, math.Sqrt(m)))
+}
+
+// ellipj returns the Jacobi elliptic functions sn, cn and dn of u with
+// parameter m, by the descending Landen transformation.
+func ellipj(u, m float64) (sn, cn, dn float64) {
+	if m < 1e-16 {
+		s, c := math.Sincos(u)
+		return s, c, 1
+	}
+	if m > 1-1e-16 {
+		t := math.Tanh(u)
+		sech := 1 / math.Cosh(u)
+		return t, sech, sech
+	}
+
+	const maxIter = 16
+	var a, c [maxIter + 1]float64
+	a[0] = 1
+	b := math.Sqrt(1 - m)
+	c[0] = math.Sqrt(m)
+	n := 0
+	for n < maxIter && math.Abs(c[n]) > 1e-16 {
+		a[n+1] = (a[n] + b) / 2
+		c[n+1] = (a[n] - b) / 2
+		b = math.Sqrt(a[n] * b)
+		n++
+	}
+
+	phi := math.Ldexp(a[n]*u, n)
+	prev := phi
+	for ; n > 0; n-- {
+		prev = phi
+		phi = (phi + math.Asin(c[n]/a[n]*math.Sin(phi))) / 2
+	}
+	sn, cn = math.Sincos(phi)
+	return sn, cn, cn / math.Cos(prev-phi)
+}
+
+// ellipdeg returns the selectivity parameter m of an order n elliptic filter
+// with discrimination parameter m1, from the solution of the degree
+// equation K(1-m1)/K(m1) = n K(1-m)/K(m) as a nome series.
+func ellipdeg(n int, m1 float64) float64 {
+	q1 := math.Exp(-math.Pi * ellipkm1(m1) / ellipk(m1))
+	q := math.Pow(q1, 1/float64(n))
+
+	const terms = 7
+	var num, den float64
+	for i := 0; i <= terms; i++ {
+		num += math.Pow(q, float64(i*(i+1)))
+	}
+	for i := 1; i <= terms+1; i++ {
+		den += math.Pow(q, float64(i*i))
+	}
+	den = 1 + 2*den
+	return 16 * q * math.Pow(num/den, 4)
+}
+
+// arcJacSN returns the inverse Jacobi elliptic function sn of w with
+// parameter m, by the descending Landen transformation.
+func arcJacSN(w complex128, m float64) complex128 {
+	complement := func(k complex128) complex128 {
+		return cmplx.Sqrt((1 - k) * (1 + k))
+	}
+
+	ks := []float64{math.Sqrt(m)}
+	for ks[len(ks)-1] != 0 && len(ks) < 10 {
+		kp := real(complement(complex(ks[len(ks)-1], 0)))
+		ks = append(ks, (1-kp)/(1+kp))
+	}
+
+	K := math.Pi / 2
+	for _, k := range ks[1:] {
+		K *= 1 + k
+	}
+
+	for i := 1; i < len(ks); i++ {
+		kn, knext := ks[i-1], ks[i]
+		w = 2 * w / (complex(1+knext, 0) * (1 + complement(complex(kn, 0)*w)))
+	}
+	return complex(K*2/math.Pi, 0) * cmplx.Asin(w)
+}
+
+// arcJacSC1 returns the real v such that sc(v, 1-m) = w, the inverse of
+// the Jacobi elliptic function sc with the complementary parameter.
+func arcJacSC1(w, m float64) float64 {
+	return imag(arcJacSN(complex(0, w), m))
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestEllipk(t *testing.T) {
+	// K(0) = π/2, and K(1/2) = Γ(1/4)²/(4√π).
+	g := math.Gamma(0.25)
+	tests := [][2]float64{
+		{0, math.Pi / 2},
+		{0.5, g * g / (4 * math.Sqrt(math.Pi))},
+	}
+	for _, v := range tests {
+		if o := ellipk(v[0]); !dsputils.Float64Equal(o, v[1]) {
+			t.Error("ellipk error\ninput:", v[0], "\noutput:", o, "\nexpected:", v[1])
+		}
+		if o := ellipkm1(1 - v[0]); !dsputils.Float64Equal(o, v[1]) {
+			t.Error("ellipkm1 error\ninput:", 1-v[0], "\noutput:", o, "\nexpected:", v[1])
+		}
+	}
+}
+
+func TestEllipj(t *testing.T) {
+	for _, m := range []float64{0, 0.1, 0.5, 0.9, 0.999999, 1} {
+		for _, u := range []float64{0, 0.3, 1, 2.5} {
+			sn, cn, dn := ellipj(u, m)
+			if math.Abs(sn*sn+cn*cn-1) > 1e-12 || math.Abs(dn*dn+m*sn*sn-1) > 1e-12 {
+				t.Error("ellipj identity error\ninput:", u, m, "\noutput:", sn, cn, dn)
+			}
+		}
+		if m < 1 {
+			// sn reaches 1 at the quarter period K.
+			if sn, _, _ := ellipj(ellipk(m), m); math.Abs(sn-1) > 1e-12 {
+				t.Error("ellipj quarter period error\ninput:", m, "\noutput:", sn)
+			}
+		}
+	}
+
+	// arcJacSC1 inverts sc = sn/cn with the complementary parameter.
+	for _, m := range []float64{0.01, 0.3} {
+		for _, w := range []float64{0.5, 2, 10} {
+			v := arcJacSC1(w, m)
+			sn, cn, _ := ellipj(v, 1-m)
+			if math.Abs(sn/cn-w) > 1e-9*w {
+				t.Error("arcJacSC1 error\ninput:", w, m, "\noutput:", sn/cn)
+			}
+		}
+	}
+
+	// ellipdeg solves the degree equation.
+	for _, n := range []int{2, 3, 6} {
+		m1 := 1e-4
+		m := ellipdeg(n, m1)
+		l, r := ellipkm1(m1)/ellipk(m1), float64(n)*ellipkm1(m)/ellipk(m)
+		if math.Abs(l-r) > 1e-9*l {
+			t.Error("ellipdeg error\ninput:", n, m1, "\noutput:", m, r, "\nexpected:", l)
+		}
+	}
+}
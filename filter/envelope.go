@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// HilbertEnvelope returns the amplitude envelope of x, the magnitude of its
+// analytic signal from Hilbert.
+func HilbertEnvelope(x []float64) []float64 {
+	a := Hilbert(x)
+	r := make([]float64, len(a))
+	for i, v := range a {
+		r[i] = math.Hypot(real(v), imag(v))
+	}
+	return r
+}
+
+// RectifiedEnvelope returns the amplitude envelope of x sampled at fs, found
+// by full-wave rectification followed by a zero phase second order
+// Butterworth lowpass filter with the given cutoff frequency. The result is
+// scaled by pi/2, so a sinusoid's envelope is its amplitude. The cutoff must
+// be well below the carrier frequency for the ripple to be removed, and the
+// carrier well below fs/2, since the harmonics from rectification alias.
+func RectifiedEnvelope(x []float64, cutoff, fs float64) []float64 {
+	bq := LowpassBiquad(cutoff, math.Sqrt2/2, fs)
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = math.Abs(v) * math.Pi / 2
+	}
+	return FiltFilt(bq[:3], bq[3:], r)
+}
+
+// EnvelopeFollower is a streaming amplitude envelope detector: a rectifier
+// followed by a one-pole lowpass filter whose time constant depends on
+// whether the envelope is rising (attack) or falling (release). A short
+// attack and long release track peaks, as for onset detection and level
+// meters.
+type EnvelopeFollower struct {
+	attack, release float64
+	env             float64
+}
+
+// NewEnvelopeFollower returns an EnvelopeFollower for signals sampled at fs,
+// with attack and release time constants in seconds. A time constant of 0
+// follows the rectified signal immediately.
+func NewEnvelopeFollower(attack, release, fs float64) *EnvelopeFollower {
+	if attack < 0 || release < 0 {
+		panic("attack and release must be non-negative")
+	}
+	return &EnvelopeFollower{
+		attack:  envelopeCoef(attack, fs),
+		release: envelopeCoef(release, fs),
+	}
+}
+
+func envelopeCoef(t, fs float64) float64 {
+	if t == 0 {
+		return 0
+	}
+	return math.Exp(-1 / (t * fs))
+}
+
+// Next returns the envelope after the next sample v.
+func (e *EnvelopeFollower) Next(v float64) float64 {
+	v = math.Abs(v)
+	g := e.release
+	if v > e.env {
+		g = e.attack
+	}
+	e.env = g*e.env + (1-g)*v
+	return e.env
+}
+
+// Process returns the envelope of the next chunk x of the signal.
+func (e *EnvelopeFollower) Process(x []float64) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = e.Next(v)
+	}
+	return r
+}
+
+// Reset sets the envelope to zero, as at creation.
+func (e *EnvelopeFollower) Reset() {
+	e.env = 0
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// amTone returns an AM signal with a 440 Hz carrier at 8 kHz and the given
+// envelope function.
+func amTone(n int, env func(t float64) float64) (x, e []float64) {
+	const fs = 8000.0
+	x = make([]float64, n)
+	e = make([]float64, n)
+	for i := range x {
+		t := float64(i) / fs
+		e[i] = env(t)
+		x[i] = e[i] * math.Cos(2*math.Pi*440*t)
+	}
+	return
+}
+
+func TestHilbertEnvelope(t *testing.T) {
+	x, e := amTone(8000, func(t float64) float64 {
+		return 1 + 0.5*math.Cos(2*math.Pi*5*t)
+	})
+	o := HilbertEnvelope(x)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("HilbertEnvelope error")
+	}
+}
+
+func TestRectifiedEnvelope(t *testing.T) {
+	x, e := amTone(8000, func(t float64) float64 {
+		return 1 + 0.5*math.Cos(2*math.Pi*5*t)
+	})
+	o := RectifiedEnvelope(x, 50, 8000)
+	for i := 1000; i < 7000; i++ {
+		if math.Abs(o[i]-e[i]) > 0.02 {
+			t.Fatal("RectifiedEnvelope error at", i, "\noutput:", o[i], "\nexpected:", e[i])
+		}
+	}
+}
+
+func TestEnvelopeFollower(t *testing.T) {
+	// A burst: the envelope rises quickly and decays slowly.
+	x, _ := amTone(4000, func(t float64) float64 {
+		if t < 0.25 {
+			return 1
+		}
+		return 0
+	})
+	f := NewEnvelopeFollower(0.001, 0.05, 8000)
+	o := f.Process(x)
+	if o[1999] < 0.6 || o[1999] > 1 {
+		t.Error("EnvelopeFollower attack error:", o[1999])
+	}
+	// One release time constant after the burst.
+	if r := o[1999+400] / o[1999]; math.Abs(r-math.Exp(-1)) > 0.01 {
+		t.Error("EnvelopeFollower release error:", r)
+	}
+
+	// Streaming in chunks matches processing at once.
+	f.Reset()
+	var s []float64
+	for i := 0; i < len(x); i += 333 {
+		end := i + 333
+		if end > len(x) {
+			end = len(x)
+		}
+		s = append(s, f.Process(x[i:end])...)
+	}
+	if !dsputils.PrettyClose(s, o) {
+		t.Error("EnvelopeFollower chunk error")
+	}
+}
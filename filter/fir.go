@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+// dot returns the dot product of a and b, which must have the same length.
+// It is the inner loop of FIR filtering, unrolled four ways with separate
+// accumulators so the multiplications and additions of consecutive terms can
+// proceed in parallel.
+func dot(a, b []float64) float64 {
+	b = b[:len(a)]
+	var s0, s1, s2, s3 float64
+	i := 0
+	for ; i <= len(a)-4; i += 4 {
+		s0 += a[i] * b[i]
+		s1 += a[i+1] * b[i+1]
+		s2 += a[i+2] * b[i+2]
+		s3 += a[i+3] * b[i+3]
+	}
+	for ; i < len(a); i++ {
+		s0 += a[i] * b[i]
+	}
+	return (s0 + s1) + (s2 + s3)
+}
+
+// FIR is a streaming FIR filter. It is faster than a Stream for FIR filters
+// of more than a few taps, since each output is a single dot product over
+// contiguous memory. Use one FIR per channel to filter multichannel signals.
+type FIR struct {
+	h   []float64 // taps, reversed
+	buf []float64 // last len(h)-1 inputs followed by room for a chunk
+}
+
+// NewFIR returns a streaming FIR filter with taps h.
+func NewFIR(h []float64) *FIR {
+	if len(h) == 0 {
+		panic("h must be non-empty")
+	}
+	f := &FIR{
+		h:   make([]float64, len(h)),
+		buf: make([]float64, len(h)-1),
+	}
+	for i, v := range h {
+		f.h[len(h)-1-i] = v
+	}
+	return f
+}
+
+// Process filters the next chunk x of the signal and returns the output.
+func (f *FIR) Process(x []float64) []float64 {
+	y := make([]float64, len(x))
+	f.ProcessTo(y, x)
+	return y
+}
+
+// ProcessTo is like Process, but writes the output to y, which must be at
+// least as long as x, and does not allocate once the internal buffer has
+// grown to the chunk size.
+func (f *FIR) ProcessTo(y, x []float64) {
+	if len(y) < len(x) {
+		panic("y is shorter than x")
+	}
+	n := len(f.h) - 1
+	f.buf = append(f.buf[:n], x...)
+	for i := range x {
+		y[i] = dot(f.h, f.buf[i:])
+	}
+	copy(f.buf, f.buf[len(x):])
+	f.buf = f.buf[:n]
+}
+
+// Reset clears the filter's history, as at creation.
+func (f *FIR) Reset() {
+	for i := range f.buf {
+		f.buf[i] = 0
+	}
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestDot(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		a := make([]float64, n)
+		b := make([]float64, n+2)
+		var e float64
+		for i := range a {
+			a[i] = rand.NormFloat64()
+			b[i] = rand.NormFloat64()
+			e += a[i] * b[i]
+		}
+		if o := dot(a, b); math.Abs(o-e) > 1e-12 {
+			t.Error("dot error\ninput:", a, b, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
+
+func TestFIR(t *testing.T) {
+	x := make([]float64, 300)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	for _, n := range []int{1, 2, 7, 64} {
+		h := make([]float64, n)
+		for i := range h {
+			h[i] = rand.NormFloat64()
+		}
+		e := Lfilter(h, []float64{1}, x)
+
+		f := NewFIR(h)
+		var o []float64
+		for i := 0; i < len(x); i += 45 {
+			end := i + 45
+			if end > len(x) {
+				end = len(x)
+			}
+			o = append(o, f.Process(x[i:end])...)
+		}
+		if !dsputils.PrettyClose(o, e) {
+			t.Error("FIR error\ntaps:", n, "\noutput:", o, "\nexpected:", e)
+		}
+
+		f.Reset()
+		y := make([]float64, len(x))
+		f.ProcessTo(y, x)
+		if !dsputils.PrettyClose(y, e) {
+			t.Error("FIR reset error\ntaps:", n, "\noutput:", y, "\nexpected:", e)
+		}
+	}
+}
+
+// BenchmarkFIR filters 10 ms blocks of 16 channels at 48 kHz with 512 taps.
+func BenchmarkFIR(b *testing.B) {
+	const channels, taps, block = 16, 512, 480
+	h := make([]float64, taps)
+	for i := range h {
+		h[i] = rand.NormFloat64()
+	}
+	fs := make([]*FIR, channels)
+	for i := range fs {
+		fs[i] = NewFIR(h)
+	}
+	x := make([]float64, block)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	y := make([]float64, block)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, f := range fs {
+			f.ProcessTo(y, x)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Firwin returns the coefficients of a numtaps-point linear phase FIR filter
+// of type btype designed by the window method: the ideal response, a sum of
+// sinc functions, multiplied by the window wf. A nil wf uses window.Hamming.
+//
+// cutoff holds the cutoff frequencies, in the same units as the sampling
+// frequency fs: one for Lowpass and Highpass, and two (low and high edges)
+// for Bandpass and Bandstop. At the cutoff frequencies the gain is about
+// -6 dB. The coefficients are scaled for unity gain at the center of the
+// first passband: zero frequency for Lowpass and Bandstop, fs/2 for
+// Highpass, and the center of the band for Bandpass. Highpass and Bandstop
+// filters pass fs/2, so numtaps must be odd for them.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.firwin.html
+func Firwin(numtaps int, cutoff []float64, wf func(int) []float64, btype BandType, fs float64) []float64 {
+	if numtaps < 1 {
+		panic("numtaps must be positive")
+	}
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+
+	nc := 1
+	if btype == Bandpass || btype == Bandstop {
+		nc = 2
+	}
+	if len(cutoff) != nc {
+		panic("wrong number of cutoff frequencies")
+	}
+	// Normalize to the Nyquist frequency.
+	c := make([]float64, nc)
+	for i, f := range cutoff {
+		c[i] = 2 * f / fs
+		if c[i] <= 0 || c[i] >= 1 {
+			panic("cutoff frequencies must be between 0 and fs/2")
+		}
+	}
+	if nc == 2 && c[0] >= c[1] {
+		panic("cutoff frequencies must be increasing")
+	}
+
+	var bands []float64
+	switch btype {
+	case Lowpass:
+		bands = []float64{0, c[0]}
+	case Highpass:
+		bands = []float64{c[0], 1}
+	case Bandpass:
+		bands = []float64{c[0], c[1]}
+	case Bandstop:
+		bands = []float64{0, c[0], c[1], 1}
+	default:
+		panic("unknown band type")
+	}
+	if bands[len(bands)-1] == 1 && numtaps%2 == 0 {
+		panic("numtaps must be odd for a highpass or bandstop filter")
+	}
+
+	if wf == nil {
+		wf = window.Hamming
+	}
+	return firwin(numtaps, bands, wf)
+}
+
+// firwin returns the numtaps-point window method FIR filter that passes the
+// bands given by pairs of edges, as fractions of the Nyquist frequency,
+// scaled for unity gain at the center of the first band.
+func firwin(numtaps int, bands []float64, wf func(int) []float64) []float64 {
+	h := wf(numtaps)
+	M := float64(numtaps-1) / 2
+	ideal := func(f, t float64) float64 {
+		if t == 0 {
+			return f
+		}
+		return math.Sin(math.Pi*f*t) / (math.Pi * t)
+	}
+	for n := range h {
+		t := float64(n) - M
+		var s float64
+		for i := 0; i < len(bands); i += 2 {
+			s += ideal(bands[i+1], t) - ideal(bands[i], t)
+		}
+		h[n] *= s
+	}
+
+	var f float64
+	switch l, r := bands[0], bands[1]; {
+	case l == 0:
+		f = 0
+	case r == 1:
+		f = 1
+	default:
+		f = (l + r) / 2
+	}
+	var sum float64
+	for n, v := range h {
+		sum += v * math.Cos(math.Pi*f*(float64(n)-M))
+	}
+	for n := range h {
+		h[n] /= sum
+	}
+
+	return h
+}
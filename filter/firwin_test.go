@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/window"
+)
+
+func TestFirwin(t *testing.T) {
+	tests := []struct {
+		numtaps int
+		cutoff  []float64
+		btype   BandType
+		out     []float64
+	}{
+		{5, []float64{0.5}, Lowpass, []float64{0, 0.2037123692007372, 0.5925752615985256, 0.20371236920073724, 0}},
+		{5, []float64{0.5}, Highpass, []float64{0, -0.20371236920073718, 0.5925752615985256, -0.2037123692007372, 0}},
+		{7, []float64{0.2, 0.5}, Bandpass, []float64{-0.03453014781991538, -0.09783581770694455, 0.2106561322189544, 0.6255052841316859, 0.2106561322189545, -0.09783581770694455, -0.03453014781991538}},
+		{7, []float64{0.2, 0.5}, Bandstop, []float64{0.026501880312347473, 0.07508896702824606, -0.161678531822238, 1.1201753689632887, -0.16167853182223804, 0.07508896702824606, 0.026501880312347473}},
+	}
+	for _, v := range tests {
+		o := Firwin(v.numtaps, v.cutoff, nil, v.btype, 2)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error("Firwin error\ninput:", v.numtaps, v.cutoff, v.btype, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+
+	// The window method with any window has the expected unity gain.
+	h := Firwin(31, []float64{1000, 3000}, window.Blackman, Bandpass, 8000)
+	if r, f := Freqz(h, []float64{1}, 8, 8000); f[4] != 2000 || math.Abs(cmplx.Abs(r[4])-1) > 1e-12 {
+		t.Error("Firwin gain error\noutput:", f[4], r[4])
+	}
+}
+
+func TestFirwinPanics(t *testing.T) {
+	tests := []func(){
+		func() { Firwin(4, []float64{0.5}, nil, Highpass, 2) },
+		func() { Firwin(5, []float64{0.5}, nil, Bandpass, 2) },
+		func() { Firwin(5, []float64{1}, nil, Lowpass, 2) },
+		func() { Firwin(5, []float64{0.5, 0.2}, nil, Bandstop, 2) },
+	}
+	for i, f := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("Firwin did not panic:", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
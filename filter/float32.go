@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+// The float32 functions below filter float32 signals, as used by audio
+// callbacks and embedded code, without converting whole buffers to float64.
+// Coefficients stay float64, as returned by the design functions, and the
+// filter state and arithmetic are float64 too: float32 state makes high
+// order and low frequency IIR filters noisy or unstable, and costs little in
+// memory traffic.
+
+// Lfilter32 is like Lfilter, but for a float32 signal.
+func Lfilter32(b, a []float64, x []float32) []float32 {
+	y := make([]float32, len(x))
+	copy(y, x)
+	NewStream32(b, a).ProcessInPlace(y)
+	return y
+}
+
+// Stream32 is like Stream, but for float32 signals.
+type Stream32 struct {
+	b, a []float64
+	z    []float64
+
+	sos  [][6]float64
+	zsos [][2]float64
+}
+
+// NewStream32 returns a Stream32 for the filter with transfer function
+// coefficients b and a.
+func NewStream32(b, a []float64) *Stream32 {
+	b, a = normalize(b, a)
+	return &Stream32{
+		b: b,
+		a: a,
+		z: make([]float64, len(a)),
+	}
+}
+
+// NewSosStream32 returns a Stream32 for the second-order sections sos.
+func NewSosStream32(sos [][6]float64) *Stream32 {
+	s := make([][6]float64, len(sos))
+	for i, c := range sos {
+		if c[3] == 0 {
+			panic("a0 must be nonzero")
+		}
+		for j := range c {
+			s[i][j] = c[j] / c[3]
+		}
+	}
+	return &Stream32{
+		sos:  s,
+		zsos: make([][2]float64, len(sos)),
+	}
+}
+
+// Process filters the next chunk x of the signal and returns the output.
+func (s *Stream32) Process(x []float32) []float32 {
+	y := make([]float32, len(x))
+	copy(y, x)
+	s.ProcessInPlace(y)
+	return y
+}
+
+// ProcessInPlace filters the next chunk x of the signal, replacing it with
+// the output. It does not allocate.
+func (s *Stream32) ProcessInPlace(x []float32) {
+	if s.sos != nil {
+		for i, c := range s.sos {
+			z0, z1 := s.zsos[i][0], s.zsos[i][1]
+			for j, v := range x {
+				xi := float64(v)
+				yi := c[0]*xi + z0
+				z0 = c[1]*xi + z1 - c[4]*yi
+				z1 = c[2]*xi - c[5]*yi
+				x[j] = float32(yi)
+			}
+			s.zsos[i][0], s.zsos[i][1] = z0, z1
+		}
+		return
+	}
+
+	b, a, z := s.b, s.a, s.z
+	for i, v := range x {
+		xi := float64(v)
+		yi := b[0]*xi + z[0]
+		for j := 1; j < len(a); j++ {
+			z[j-1] = b[j]*xi + z[j] - a[j]*yi
+		}
+		x[i] = float32(yi)
+	}
+}
+
+// Reset clears the filter's delay values, as at creation.
+func (s *Stream32) Reset() {
+	for i := range s.z {
+		s.z[i] = 0
+	}
+	for i := range s.zsos {
+		s.zsos[i] = [2]float64{}
+	}
+}
+
+// Filter32 is like Filter, but for a float32 signal.
+func (bq Biquad) Filter32(x []float32) []float32 {
+	return NewSosStream32(Cascade(bq)).Process(x)
+}
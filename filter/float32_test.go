@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func closeFloat32(a []float32, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(float64(a[i])-b[i]) > 1e-5*math.Max(1, math.Abs(b[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestFloat32(t *testing.T) {
+	x := make([]float64, 300)
+	x32 := make([]float32, len(x))
+	for i := range x {
+		x32[i] = float32(rand.NormFloat64())
+		x[i] = float64(x32[i])
+	}
+
+	b, a := Cheby1(4, 1, []float64{0.15}, Lowpass, 1)
+	if o := Lfilter32(b, a, x32); !closeFloat32(o, Lfilter(b, a, x)) {
+		t.Error("Lfilter32 error")
+	}
+
+	s := NewStream32(b, a)
+	o := append(s.Process(x32[:100]), s.Process(x32[100:])...)
+	if !closeFloat32(o, Lfilter(b, a, x)) {
+		t.Error("Stream32 error")
+	}
+
+	sos := Cheby2Sos(8, 50, []float64{0.05}, Lowpass, 1)
+	s = NewSosStream32(sos)
+	o = s.Process(x32[:77])
+	y := make([]float32, len(x32)-77)
+	copy(y, x32[77:])
+	s.ProcessInPlace(y)
+	if !closeFloat32(append(o, y...), SosFilt(sos, x)) {
+		t.Error("Stream32 sos error")
+	}
+	s.Reset()
+	if o := s.Process(x32); !closeFloat32(o, SosFilt(sos, x)) {
+		t.Error("Stream32 reset error")
+	}
+
+	bq := PeakingEQ(1000, 2, -6, 44100)
+	if o := bq.Filter32(x32); !closeFloat32(o, bq.Filter(x)) {
+		t.Error("Biquad Filter32 error")
+	}
+}
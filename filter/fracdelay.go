@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Thiran returns the transfer function coefficients b and a of an order n
+// Thiran allpass filter with a delay of delay samples. The group delay is
+// maximally flat at DC and the gain is exactly 1 at all frequencies. For
+// best accuracy delay should be within half a sample of n; the filter is
+// stable for delay > n-1.
+// Reference: https://ccrma.stanford.edu/~jos/pasp/Thiran_Allpass_Interpolators.html
+func Thiran(n int, delay float64) (b, a []float64) {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	if delay <= float64(n-1) {
+		panic("delay must be greater than n-1")
+	}
+
+	a = make([]float64, n+1)
+	binom := 1.0
+	for k := 0; k <= n; k++ {
+		v := binom
+		if k%2 == 1 {
+			v = -v
+		}
+		for i := 0; i <= n; i++ {
+			v *= (delay - float64(n) + float64(i)) / (delay - float64(n) + float64(k) + float64(i))
+		}
+		a[k] = v
+		binom = binom * float64(n-k) / float64(k+1)
+	}
+
+	b = make([]float64, n+1)
+	for k := range b {
+		b[k] = a[n-k]
+	}
+	return b, a
+}
+
+// FractionalDelayFIR returns a numtaps FIR filter that delays its input by
+// delay samples, which need not be an integer, designed by windowing a
+// shifted sinc with wf (window.Blackman if nil). The gain is normalized to 1
+// at DC. Accuracy is best when delay is near the center, (numtaps-1)/2, and
+// falls off toward the Nyquist frequency.
+func FractionalDelayFIR(numtaps int, delay float64, wf func(int) []float64) []float64 {
+	if numtaps < 2 {
+		panic("numtaps must be at least 2")
+	}
+	if delay < 0 || delay > float64(numtaps-1) {
+		panic("delay must be between 0 and numtaps-1")
+	}
+	if wf == nil {
+		wf = window.Blackman
+	}
+
+	// Sample the window at the same fractional offset as the sinc, by
+	// linear interpolation of a finely sampled window.
+	const over = 64
+	w := wf(over*(numtaps-1) + 1)
+	shift := delay - float64(numtaps-1)/2
+
+	h := make([]float64, numtaps)
+	var sum float64
+	for i := range h {
+		t := float64(i) - delay
+		pos := (float64(i) - shift) * over
+		var wv float64
+		if pos >= 0 && pos <= float64(len(w)-1) {
+			j := int(pos)
+			if j == len(w)-1 {
+				wv = w[j]
+			} else {
+				frac := pos - float64(j)
+				wv = w[j]*(1-frac) + w[j+1]*frac
+			}
+		}
+		if t == 0 {
+			h[i] = wv
+		} else {
+			h[i] = wv * math.Sin(math.Pi*t) / (math.Pi * t)
+		}
+		sum += h[i]
+	}
+
+	for i := range h {
+		h[i] /= sum
+	}
+	return h
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestThiran(t *testing.T) {
+	// First order: a1 = (1-D)/(1+D).
+	b, a := Thiran(1, 0.5)
+	if e := []float64{1, 1.0 / 3}; !dsputils.PrettyClose(a, e) {
+		t.Error("Thiran error\noutput:", a, "\nexpected:", e)
+	}
+	if e := []float64{1.0 / 3, 1}; !dsputils.PrettyClose(b, e) {
+		t.Error("Thiran error\noutput:", b, "\nexpected:", e)
+	}
+
+	for _, v := range []struct {
+		n int
+		d float64
+	}{{1, 1.3}, {2, 2.25}, {3, 2.7}, {4, 4.4}} {
+		b, a := Thiran(v.n, v.d)
+		if g := gainDB(b, a, 0.2); math.Abs(g) > 1e-9 {
+			t.Error("Thiran gain error:", v.n, v.d, g)
+		}
+		gd, _ := GroupDelay(b, a, 64, 1)
+		if math.Abs(gd[0]-v.d) > 1e-6 || math.Abs(gd[6]-v.d) > 0.03 {
+			t.Error("Thiran delay error:", v.n, v.d, gd[0], gd[6])
+		}
+	}
+}
+
+func TestFractionalDelayFIR(t *testing.T) {
+	for _, d := range []float64{10, 10.3, 9.75, 11.5} {
+		h := FractionalDelayFIR(21, d, nil)
+		gd, freqs := GroupDelay(h, []float64{1}, 64, 1)
+		for i, f := range freqs {
+			if f > 0.3 {
+				break
+			}
+			if math.Abs(gd[i]-d) > 0.01 {
+				t.Error("FractionalDelayFIR delay error:", d, f, gd[i])
+				break
+			}
+		}
+		if g := gainDB(h, []float64{1}, 0.2); math.Abs(g) > 0.05 {
+			t.Error("FractionalDelayFIR gain error:", d, g)
+		}
+	}
+
+	// An integer delay at the center is a pure delay.
+	h := FractionalDelayFIR(5, 2, nil)
+	if e := []float64{0, 0, 1, 0, 0}; !dsputils.PrettyClose(h, e) {
+		t.Error("FractionalDelayFIR error\noutput:", h, "\nexpected:", e)
+	}
+}
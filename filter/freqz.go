@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Freqz returns the complex frequency response h of the filter with transfer
+// function coefficients b and a at n equally spaced frequencies freqs from 0
+// up to (but not including) the Nyquist frequency fs/2. An FIR filter has
+// a = []float64{1}.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.freqz.html
+func Freqz(b, a []float64, n int, fs float64) (h []complex128, freqs []float64) {
+	freqs = freqzFreqs(n, fs)
+	num := polyResponse(b, n)
+	den := polyResponse(a, n)
+
+	h = make([]complex128, n)
+	for i := range h {
+		h[i] = num[i] / den[i]
+	}
+
+	return h, freqs
+}
+
+// FreqzSos is like Freqz, but for the second-order sections sos.
+func FreqzSos(sos [][6]float64, n int, fs float64) (h []complex128, freqs []float64) {
+	freqs = freqzFreqs(n, fs)
+	h = make([]complex128, n)
+	for i := range h {
+		h[i] = 1
+	}
+
+	for _, s := range sos {
+		num := polyResponse(s[:3], n)
+		den := polyResponse(s[3:], n)
+		for i := range h {
+			h[i] *= num[i] / den[i]
+		}
+	}
+
+	return h, freqs
+}
+
+func freqzFreqs(n int, fs float64) []float64 {
+	freqs := make([]float64, n)
+	for i := range freqs {
+		freqs[i] = float64(i) * fs / float64(2*n)
+	}
+	return freqs
+}
+
+// polyResponse returns the values of the polynomial in z^-1 with
+// coefficients c at n equally spaced points on the upper half of the unit
+// circle.
+func polyResponse(c []float64, n int) []complex128 {
+	if len(c) <= 2*n {
+		return fft.FFTReal(dsputils.ZeroPadF(c, 2*n))[:n]
+	}
+
+	r := make([]complex128, n)
+	for i := range r {
+		zi := cmplx.Exp(complex(0, -math.Pi*float64(i)/float64(n)))
+		var v complex128
+		for j := len(c) - 1; j >= 0; j-- {
+			v = v*zi + complex(c[j], 0)
+		}
+		r[i] = v
+	}
+	return r
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestFreqz(t *testing.T) {
+	// Two-point moving average: H = (1 + e^-jw) / 2.
+	h, freqs := Freqz([]float64{0.5, 0.5}, []float64{1}, 4, 8)
+	eh := make([]complex128, 4)
+	for i := range eh {
+		w := math.Pi * float64(i) / 4
+		eh[i] = (1 + cmplx.Exp(complex(0, -w))) / 2
+	}
+	if !dsputils.PrettyCloseC(h, eh) {
+		t.Error("Freqz error\noutput:", h, "\nexpected:", eh)
+	}
+	if ef := []float64{0, 1, 2, 3}; !dsputils.PrettyClose(freqs, ef) {
+		t.Error("Freqz freqs error\noutput:", freqs, "\nexpected:", ef)
+	}
+
+	// One-pole IIR: H = 1 / (1 - 0.5e^-jw), evaluated for a filter longer
+	// than 2n to exercise direct evaluation.
+	b := []float64{1, 0, 0, 0, 0, 0, 0}
+	h, _ = Freqz(b, []float64{1, -0.5}, 3, 1)
+	for i := range h {
+		w := math.Pi * float64(i) / 3
+		e := 1 / (1 - 0.5*cmplx.Exp(complex(0, -w)))
+		if !dsputils.ComplexEqual(h[i], e) {
+			t.Error("Freqz IIR error at", i, ":", h[i], e)
+		}
+	}
+}
+
+func TestFreqzSos(t *testing.T) {
+	b, a := Cheby2(6, 40, []float64{0.2}, Lowpass, 1)
+	sos := Cheby2Sos(6, 40, []float64{0.2}, Lowpass, 1)
+	h, _ := Freqz(b, a, 64, 1)
+	hs, _ := FreqzSos(sos, 64, 1)
+	for i := range h {
+		if cmplx.Abs(h[i]-hs[i]) > 1e-8 {
+			t.Fatal("FreqzSos error at", i, ":", hs[i], h[i])
+		}
+	}
+}
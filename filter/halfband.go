@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Halfband returns a numtaps linear phase half-band lowpass FIR filter,
+// designed by windowing the ideal response with wf (window.Hamming if nil).
+// The cutoff is a quarter of the sampling frequency, and every other tap
+// apart from the center one is zero, which HalfbandDecimator and
+// HalfbandInterpolator exploit. numtaps must be 3 more than a multiple of 4,
+// so the outermost taps are nonzero.
+func Halfband(numtaps int, wf func(int) []float64) []float64 {
+	if numtaps < 3 || numtaps%4 != 3 {
+		panic("numtaps must be 3 more than a multiple of 4")
+	}
+	if wf == nil {
+		wf = window.Hamming
+	}
+	h := wf(numtaps)
+	M := numtaps / 2
+	var sum float64
+	for i := range h {
+		n := i - M
+		if n < 0 {
+			n = -n
+		}
+		if n%2 == 0 {
+			h[i] = 0
+		} else {
+			h[i] *= math.Sin(math.Pi*float64(n)/2) / (math.Pi * float64(n))
+			sum += h[i]
+		}
+	}
+
+	// Normalize to unity gain at DC, keeping the center tap at exactly 1/2.
+	for i := range h {
+		h[i] *= 0.5 / sum
+	}
+	h[M] = 0.5
+	return h
+}
+
+// HalfbandDecimator is a streaming 2:1 decimator using a half-band filter.
+// It computes only the retained outputs, and skips the zero taps, so it costs
+// about a quarter of a direct FIR filter at the input rate.
+type HalfbandDecimator struct {
+	h    []float64 // nonzero even-index taps
+	c    float64   // center tap
+	m    int       // center index
+	hist []float64 // last len(filter)-1 inputs
+	odd  bool      // whether the next input is at an odd index
+}
+
+// NewHalfbandDecimator returns a HalfbandDecimator using the filter h from
+// Halfband. Its output is the same as UpFirDn(h, x, 1, 2), truncated to
+// ceil(len(x)/2) samples.
+func NewHalfbandDecimator(h []float64) *HalfbandDecimator {
+	if len(h)%4 != 3 {
+		panic("h must be a half-band filter")
+	}
+	d := &HalfbandDecimator{
+		m:    len(h) / 2,
+		c:    h[len(h)/2],
+		hist: make([]float64, len(h)-1),
+	}
+	for k := 0; k < len(h); k += 2 {
+		d.h = append(d.h, h[k])
+	}
+	return d
+}
+
+// Process decimates the next chunk x of the signal and returns the output.
+func (d *HalfbandDecimator) Process(x []float64) []float64 {
+	n := len(d.hist)
+	buf := append(d.hist, x...)
+
+	var y []float64
+	start := n
+	if d.odd {
+		start++
+	}
+	for p := start; p < len(buf); p += 2 {
+		// Even taps pair with inputs of the same parity as p; the center
+		// tap is the only one on the other parity.
+		sum := d.c * buf[p-d.m]
+		for j, v := range d.h {
+			sum += v * buf[p-2*j]
+		}
+		y = append(y, sum)
+	}
+
+	if len(x)%2 == 1 {
+		d.odd = !d.odd
+	}
+	d.hist = append(d.hist[:0], buf[len(buf)-n:]...)
+	return y
+}
+
+// Reset clears the decimator's history, as at creation.
+func (d *HalfbandDecimator) Reset() {
+	for i := range d.hist {
+		d.hist[i] = 0
+	}
+	d.odd = false
+}
+
+// HalfbandInterpolator is a streaming 1:2 interpolator using a half-band
+// filter. Every other output is a delayed copy of the input, so only half of
+// the outputs require filtering.
+type HalfbandInterpolator struct {
+	h    []float64 // nonzero even-index taps, doubled
+	m    int       // input delay of the odd outputs
+	hist []float64 // last len(h)-1 inputs
+}
+
+// NewHalfbandInterpolator returns a HalfbandInterpolator using the filter h
+// from Halfband. Its output is the same as UpFirDn(h, x, 2, 1) scaled by 2,
+// truncated to 2*len(x) samples.
+func NewHalfbandInterpolator(h []float64) *HalfbandInterpolator {
+	if len(h)%4 != 3 {
+		panic("h must be a half-band filter")
+	}
+	d := &HalfbandInterpolator{m: len(h) / 4}
+	for k := 0; k < len(h); k += 2 {
+		d.h = append(d.h, 2*h[k])
+	}
+	d.hist = make([]float64, len(d.h)-1)
+	return d
+}
+
+// Process interpolates the next chunk x of the signal and returns the
+// output, which has 2*len(x) samples.
+func (d *HalfbandInterpolator) Process(x []float64) []float64 {
+	n := len(d.hist)
+	buf := append(d.hist, x...)
+
+	y := make([]float64, 2*len(x))
+	for i := range x {
+		p := n + i
+		var sum float64
+		for j, v := range d.h {
+			sum += v * buf[p-j]
+		}
+		y[2*i] = sum
+		// The center tap is 1/2, doubled by the interpolation gain.
+		y[2*i+1] = buf[p-d.m]
+	}
+
+	d.hist = append(d.hist[:0], buf[len(buf)-n:]...)
+	return y
+}
+
+// Reset clears the interpolator's history, as at creation.
+func (d *HalfbandInterpolator) Reset() {
+	for i := range d.hist {
+		d.hist[i] = 0
+	}
+}
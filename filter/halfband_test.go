@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestHalfband(t *testing.T) {
+	h := Halfband(31, nil)
+	M := len(h) / 2
+	for i := range h {
+		if math.Abs(h[i]-h[len(h)-1-i]) > 1e-15 {
+			t.Fatal("Halfband symmetry error:", i)
+		}
+		if i != M && (i-M)%2 == 0 && h[i] != 0 {
+			t.Fatal("Halfband zero tap error:", i, h[i])
+		}
+	}
+	if h[0] == 0 {
+		t.Error("Halfband end tap is zero")
+	}
+
+	// The zero-phase amplitude response is antisymmetric about a quarter of
+	// the sampling rate.
+	amp := func(f float64) float64 {
+		a := h[M]
+		for n := 1; n <= M; n++ {
+			a += 2 * h[M+n] * math.Cos(2*math.Pi*f*float64(n))
+		}
+		return a
+	}
+	for _, f := range []float64{0, 0.05, 0.1, 0.2} {
+		if g := amp(f) + amp(0.5-f); math.Abs(g-1) > 1e-9 {
+			t.Error("Halfband response error at", f, ":", g)
+		}
+	}
+	if g := response(h, 0.25); math.Abs(g-0.5) > 1e-9 {
+		t.Error("Halfband cutoff error:", g)
+	}
+}
+
+func TestHalfbandDecimator(t *testing.T) {
+	x := make([]float64, 101)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	h := Halfband(23, nil)
+	e := UpFirDn(h, x, 1, 2)[:(len(x)+1)/2]
+
+	d := NewHalfbandDecimator(h)
+	var o []float64
+	for _, n := range []int{7, 10, 1, 33, 50} {
+		o = append(o, d.Process(x[:n])...)
+		x = x[n:]
+	}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("HalfbandDecimator error\noutput:", o, "\nexpected:", e)
+	}
+}
+
+func TestHalfbandInterpolator(t *testing.T) {
+	x := make([]float64, 60)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	h := Halfband(19, nil)
+	e := UpFirDn(h, x, 2, 1)[:2*len(x)]
+	for i := range e {
+		e[i] *= 2
+	}
+
+	d := NewHalfbandInterpolator(h)
+	o := append(d.Process(x[:13]), d.Process(x[13:])...)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("HalfbandInterpolator error\noutput:", o, "\nexpected:", e)
+	}
+
+	d.Reset()
+	if o := d.Process(x); !dsputils.PrettyClose(o, e) {
+		t.Error("HalfbandInterpolator reset error\noutput:", o, "\nexpected:", e)
+	}
+}
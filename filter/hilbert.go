@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/window"
+)
+
+// HilbertFIR returns an odd length numtaps FIR Hilbert transformer designed
+// by windowing the ideal impulse response, 2/(pi*n) for odd n and 0 for even
+// n, with wf. A nil wf uses window.Hamming. The filter shifts the phase of
+// positive frequencies by -90 degrees with a delay of (numtaps-1)/2 samples;
+// its gain falls off near DC and the Nyquist frequency, more so for shorter
+// filters.
+func HilbertFIR(numtaps int, wf func(int) []float64) []float64 {
+	if numtaps < 3 || numtaps%2 == 0 {
+		panic("numtaps must be odd and at least 3")
+	}
+	if wf == nil {
+		wf = window.Hamming
+	}
+
+	h := wf(numtaps)
+	M := numtaps / 2
+	for i := range h {
+		n := i - M
+		if n%2 == 0 {
+			h[i] = 0
+		} else {
+			h[i] *= 2 / (math.Pi * float64(n))
+		}
+	}
+	return h
+}
+
+// HilbertRemez returns an odd length numtaps equiripple FIR Hilbert
+// transformer with unity gain between trans and fs/2-trans, designed with
+// Remez. trans is the width of the transition bands at DC and the Nyquist
+// frequency, in the same units as the sampling frequency fs.
+func HilbertRemez(numtaps int, trans, fs float64) ([]float64, error) {
+	if numtaps < 3 || numtaps%2 == 0 {
+		panic("numtaps must be odd and at least 3")
+	}
+	if trans <= 0 || trans >= fs/4 {
+		panic("trans must be between 0 and fs/4")
+	}
+	return Remez(numtaps, []float64{trans, fs/2 - trans}, []float64{1}, nil, fs, RemezHilbert)
+}
+
+// Analytic returns the analytic signal of x computed with the FIR Hilbert
+// transformer h, such as from HilbertFIR: the real part is x and the
+// imaginary part is its Hilbert transform. Both are delayed by the
+// (len(h)-1)/2 samples of filter delay, so the result lags x. Unlike an FFT
+// based transform, h may also be used with NewStream to compute the
+// imaginary part in consecutive chunks.
+func Analytic(h, x []float64) []complex128 {
+	d := (len(h) - 1) / 2
+	im := FIRFilter(h, x)
+	r := make([]complex128, len(x))
+	for i := range r {
+		var re float64
+		if i >= d {
+			re = x[i-d]
+		}
+		r[i] = complex(re, im[i])
+	}
+	return r
+}
+
+// Hilbert returns the analytic signal of x computed with the FFT: the real
+// part is x and the imaginary part is its Hilbert transform, with no delay.
+// The signal is treated as periodic, so its ends may show artifacts.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.hilbert.html
+func Hilbert(x []float64) []complex128 {
+	n := len(x)
+	if n == 0 {
+		return []complex128{}
+	}
+	X := fft.FFT(dsputils.ToComplex(x))
+
+	// Double the positive frequencies and zero the negative ones, keeping DC
+	// and, for even n, Nyquist.
+	for i := 1; i < n; i++ {
+		switch {
+		case 2*i < n:
+			X[i] *= 2
+		case 2*i > n:
+			X[i] = 0
+		}
+	}
+	return fft.IFFT(X)
+}
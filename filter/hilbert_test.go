@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestHilbertFIR(t *testing.T) {
+	const n = 63
+	h := HilbertFIR(n, nil)
+	for i := range h {
+		if math.Abs(h[i]+h[n-1-i]) > 1e-12 {
+			t.Fatal("HilbertFIR symmetry error:", i, h[i], h[n-1-i])
+		}
+		if (i-n/2)%2 == 0 && h[i] != 0 {
+			t.Fatal("HilbertFIR even tap error:", i, h[i])
+		}
+	}
+	for _, f := range []float64{0.1, 0.25, 0.4} {
+		if g := response(h, f); math.Abs(g-1) > 0.01 {
+			t.Error("HilbertFIR gain error:", f, g)
+		}
+	}
+
+	h2, err := HilbertRemez(n, 0.05, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []float64{0.05, 0.1, 0.25, 0.4, 0.45} {
+		if g := response(h2, f); math.Abs(g-1) > 0.01 {
+			t.Error("HilbertRemez gain error:", f, g)
+		}
+	}
+}
+
+func TestAnalytic(t *testing.T) {
+	// The analytic signal of a cosine is a complex exponential.
+	const f = 0.1
+	x := make([]float64, 400)
+	for i := range x {
+		x[i] = math.Cos(2 * math.Pi * f * float64(i))
+	}
+	h := HilbertFIR(63, nil)
+	y := Analytic(h, x)
+	d := len(h) / 2
+	for i := len(h); i < len(y); i++ {
+		e := cmplx.Exp(complex(0, 2*math.Pi*f*float64(i-d)))
+		if cmplx.Abs(y[i]-e) > 0.01 {
+			t.Fatal("Analytic error at", i, ":", y[i], e)
+		}
+	}
+}
+
+func TestHilbert(t *testing.T) {
+	// A whole number of cycles of a cosine gives exp(i*w*n).
+	for _, n := range []int{64, 65} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = math.Cos(2 * math.Pi * 5 * float64(i) / float64(n))
+		}
+		a := Hilbert(x)
+		for i, v := range a {
+			e := cmplx.Exp(complex(0, 2*math.Pi*5*float64(i)/float64(n)))
+			if cmplx.Abs(v-e) > 1e-12 {
+				t.Fatal("Hilbert error\nlength:", n, "index:", i, "\noutput:", v, "\nexpected:", e)
+			}
+		}
+	}
+}
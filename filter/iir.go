@@ -0,0 +1,131 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// BandType is the type of frequency band selected by an IIR filter.
+type BandType int
+
+const (
+	Lowpass BandType = iota
+	Highpass
+	Bandpass
+	Bandstop
+)
+
+// Butter returns the transfer function coefficients b and a of an order n
+// digital Butterworth filter, which has a maximally flat passband.
+//
+// wn holds the critical frequencies, in the same units as the sampling
+// frequency fs: one for Lowpass and Highpass, and two (low and high edges) for
+// Bandpass and Bandstop. At the critical frequencies the gain is -3 dB.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.butter.html
+func Butter(n int, wn []float64, btype BandType, fs float64) (b, a []float64) {
+	z, p, k := Buttap(n)
+	return Zpk2Tf(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// Cheby1 returns the transfer function coefficients b and a of an order n
+// digital Chebyshev type I filter with at most rp dB of ripple in the
+// passband. Type I filters have a steeper rolloff than Butterworth filters in
+// exchange for passband ripple.
+//
+// wn holds the critical frequencies, in the same units as the sampling
+// frequency fs: one for Lowpass and Highpass, and two (low and high edges) for
+// Bandpass and Bandstop. At the critical frequencies the gain first drops
+// below -rp dB.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.cheby1.html
+func Cheby1(n int, rp float64, wn []float64, btype BandType, fs float64) (b, a []float64) {
+	z, p, k := Cheb1ap(n, rp)
+	return Zpk2Tf(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// Cheby2 returns the transfer function coefficients b and a of an order n
+// digital Chebyshev type II filter with at least rs dB of attenuation in the
+// stopband. Type II filters have a flat passband and ripple in the stopband.
+//
+// wn holds the critical frequencies as in Cheby1. At the critical frequencies
+// the gain first reaches -rs dB.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.cheby2.html
+func Cheby2(n int, rs float64, wn []float64, btype BandType, fs float64) (b, a []float64) {
+	z, p, k := Cheb2ap(n, rs)
+	return Zpk2Tf(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// Ellip returns the transfer function coefficients b and a of an order n
+// digital elliptic (Cauer) filter with at most rp dB of ripple in the
+// passband and at least rs dB of attenuation in the stopband. Elliptic
+// filters have the steepest rolloff for a given order.
+//
+// wn holds the critical frequencies as in Cheby1. At the critical frequencies
+// the gain first drops below -rp dB.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.ellip.html
+func Ellip(n int, rp, rs float64, wn []float64, btype BandType, fs float64) (b, a []float64) {
+	z, p, k := Ellipap(n, rp, rs)
+	return Zpk2Tf(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// iirDesign transforms the analog lowpass prototype z, p, k to the digital
+// filter of type btype with critical frequencies wn, returning its zeros,
+// poles, and gain.
+func iirDesign(z, p []complex128, k float64, wn []float64, btype BandType, fs float64) ([]complex128, []complex128, float64) {
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+
+	nwn := 1
+	if btype == Bandpass || btype == Bandstop {
+		nwn = 2
+	}
+	if len(wn) != nwn {
+		panic("wrong number of critical frequencies")
+	}
+
+	// Pre-warp the frequencies for the bilinear transform, using an internal
+	// sampling frequency of 2 and frequencies normalized to Nyquist.
+	const ifs = 2.0
+	warped := make([]float64, nwn)
+	for i, w := range wn {
+		w = 2 * w / fs
+		if w <= 0 || w >= 1 {
+			panic("critical frequencies must be between 0 and fs/2")
+		}
+		warped[i] = 2 * ifs * math.Tan(math.Pi*w/ifs)
+	}
+
+	switch btype {
+	case Lowpass:
+		z, p, k = Lp2lp(z, p, k, warped[0])
+	case Highpass:
+		z, p, k = Lp2hp(z, p, k, warped[0])
+	case Bandpass:
+		bw := warped[1] - warped[0]
+		wo := math.Sqrt(warped[0] * warped[1])
+		z, p, k = Lp2bp(z, p, k, wo, bw)
+	case Bandstop:
+		bw := warped[1] - warped[0]
+		wo := math.Sqrt(warped[0] * warped[1])
+		z, p, k = Lp2bs(z, p, k, wo, bw)
+	default:
+		panic("unknown band type")
+	}
+
+	return Bilinear(z, p, k, ifs)
+}
@@ -0,0 +1,210 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// gainDB returns the gain in dB of the transfer function b, a at frequency
+// f, normalized to the sampling frequency.
+func gainDB(b, a []float64, f float64) float64 {
+	zi := cmplx.Exp(complex(0, -2*math.Pi*f))
+	var num, den complex128
+	for i := len(b) - 1; i >= 0; i-- {
+		num = num*zi + complex(b[i], 0)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		den = den*zi + complex(a[i], 0)
+	}
+	return 20 * math.Log10(cmplx.Abs(num/den))
+}
+
+type iirBand struct {
+	lo, hi   float64 // frequency range, normalized to fs
+	min, max float64 // gain limits in dB
+}
+
+type iirTest struct {
+	name  string
+	b, a  []float64
+	order int
+	bands []iirBand
+}
+
+func TestCheby(t *testing.T) {
+	const fs = 1000
+	tests := []iirTest{}
+	add := func(name string, order int, b, a []float64, bands ...iirBand) {
+		tests = append(tests, iirTest{name, b, a, order, bands})
+	}
+
+	b, a := Cheby1(4, 1, []float64{100}, Lowpass, fs)
+	add("cheby1 lowpass", 4, b, a,
+		iirBand{0, 0.1, -1.0001, 0.0001},
+		iirBand{0.15, 0.5, math.Inf(-1), -20},
+	)
+	b, a = Cheby1(5, 0.5, []float64{200}, Highpass, fs)
+	add("cheby1 highpass", 5, b, a,
+		iirBand{0, 0.15, math.Inf(-1), -20},
+		iirBand{0.2, 0.5, -0.5001, 0.0001},
+	)
+	b, a = Cheby1(3, 1, []float64{100, 200}, Bandpass, fs)
+	add("cheby1 bandpass", 6, b, a,
+		iirBand{0, 0.05, math.Inf(-1), -20},
+		iirBand{0.1, 0.2, -1.0001, 0.0001},
+		iirBand{0.3, 0.5, math.Inf(-1), -20},
+	)
+	b, a = Cheby2(4, 40, []float64{150}, Lowpass, fs)
+	add("cheby2 lowpass", 4, b, a,
+		iirBand{0, 0.05, -1, 0.0001},
+		iirBand{0.15, 0.5, math.Inf(-1), -39.999},
+	)
+	b, a = Cheby2(5, 50, []float64{100}, Highpass, fs)
+	add("cheby2 highpass", 5, b, a,
+		iirBand{0, 0.1, math.Inf(-1), -49.999},
+		iirBand{0.25, 0.5, -1, 0.0001},
+	)
+	b, a = Cheby2(3, 40, []float64{100, 300}, Bandstop, fs)
+	add("cheby2 bandstop", 6, b, a,
+		iirBand{0, 0.01, -1, 0.0001},
+		iirBand{0.1, 0.3, math.Inf(-1), -39.999},
+		iirBand{0.49, 0.5, -1, 0.0001},
+	)
+
+	for _, v := range tests {
+		if len(v.b) != v.order+1 || len(v.a) != v.order+1 {
+			t.Error(v.name, "order error:", len(v.b), len(v.a))
+			continue
+		}
+		if v.a[0] != 1 {
+			t.Error(v.name, "a[0] error:", v.a[0])
+		}
+		for _, band := range v.bands {
+			for f := band.lo; f <= band.hi; f += 0.001 {
+				if g := gainDB(v.b, v.a, f); g < band.min || g > band.max {
+					t.Error(v.name, "gain error at", f, ":", g, "not in", band.min, band.max)
+					break
+				}
+			}
+		}
+	}
+
+	// Chebyshev type I filters are -rp dB at the cutoff.
+	b, a = Cheby1(4, 3, []float64{100}, Lowpass, fs)
+	if g := gainDB(b, a, 0.1); math.Abs(g+3) > 1e-6 {
+		t.Error("cheby1 cutoff gain error:", g)
+	}
+
+	// Chebyshev type II filters are -rs dB at the stopband edge.
+	b, a = Cheby2(4, 30, []float64{100}, Lowpass, fs)
+	if g := gainDB(b, a, 0.1); math.Abs(g+30) > 1e-6 {
+		t.Error("cheby2 cutoff gain error:", g)
+	}
+}
+
+func TestButter(t *testing.T) {
+	// Analytically, a 2nd order lowpass at fs/4 has b = [1, 2, 1]/(2+√2) and
+	// a = [1, 0, (2-√2)/(2+√2)].
+	b, a := Butter(2, []float64{0.25}, Lowpass, 1)
+	g := 1 / (2 + math.Sqrt2)
+	eb := []float64{g, 2 * g, g}
+	ea := []float64{1, 0, (2 - math.Sqrt2) * g}
+	if !dsputils.PrettyClose(b, eb) || !dsputils.PrettyClose(a, ea) {
+		t.Error("Butter error\noutput:", b, a, "\nexpected:", eb, ea)
+	}
+
+	// Butterworth filters are -3 dB at the cutoff and monotonic.
+	for _, btype := range []BandType{Lowpass, Highpass, Bandpass, Bandstop} {
+		wn := []float64{100}
+		if btype == Bandpass || btype == Bandstop {
+			wn = []float64{100, 200}
+		}
+		b, a := Butter(5, wn, btype, 1000)
+		sos := ButterSos(5, wn, btype, 1000)
+		for _, w := range wn {
+			if g := gainDB(b, a, w/1000); math.Abs(g+10*math.Log10(2)) > 1e-6 {
+				t.Error("Butter cutoff gain error:", btype, w, g)
+			}
+		}
+		h, _ := FreqzSos(sos, 64, 1000)
+		hb, _ := Freqz(b, a, 64, 1000)
+		if !dsputils.PrettyCloseC(h, hb) {
+			t.Error("ButterSos error:", btype)
+		}
+	}
+}
+
+func TestEllip(t *testing.T) {
+	const fs = 1000
+	for _, n := range []int{1, 2, 3, 4, 5} {
+		b, a := Ellip(n, 1, 40, []float64{100}, Lowpass, fs)
+		if len(b) != n+1 || len(a) != n+1 {
+			t.Error("Ellip order error:", n, len(b), len(a))
+			continue
+		}
+
+		// The passband ripples between 0 and -rp dB, reaching -rp at the
+		// cutoff.
+		min, max := math.Inf(1), math.Inf(-1)
+		for f := 0.0; f <= 0.1; f += 0.0001 {
+			g := gainDB(b, a, f)
+			min = math.Min(min, g)
+			max = math.Max(max, g)
+		}
+		if max > 1e-9 || min < -1-1e-9 || (n > 1 && max < -1e-4) {
+			t.Error("Ellip passband error:", n, min, max)
+		}
+		if g := gainDB(b, a, 0.1); math.Abs(g+1) > 1e-6 {
+			t.Error("Ellip cutoff gain error:", n, g)
+		}
+
+		// The stopband ripples peak at -rs dB.
+		if n > 1 {
+			max = math.Inf(-1)
+			for f := 0.5; f >= 0.1; f -= 0.00001 {
+				g := gainDB(b, a, f)
+				if g > -40+1e-6 {
+					break
+				}
+				max = math.Max(max, g)
+			}
+			if math.Abs(max+40) > 1e-3 {
+				t.Error("Ellip stopband error:", n, max)
+			}
+		}
+	}
+
+	// Elliptic filters are steeper than Butterworth filters of the same
+	// order.
+	b, a := Ellip(4, 1, 40, []float64{100}, Lowpass, fs)
+	bb, ab := Butter(4, []float64{100}, Lowpass, fs)
+	if g, gb := gainDB(b, a, 0.15), gainDB(bb, ab, 0.15); g > gb {
+		t.Error("Ellip rolloff error:", g, gb)
+	}
+	sos := EllipSos(4, 0.5, 60, []float64{100, 200}, Bandstop, fs)
+	b, a = Ellip(4, 0.5, 60, []float64{100, 200}, Bandstop, fs)
+	h, _ := FreqzSos(sos, 64, fs)
+	hb, _ := Freqz(b, a, 64, fs)
+	if !dsputils.PrettyCloseC(h, hb) {
+		t.Error("EllipSos error\noutput:", h, "\nexpected:", hb)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+// Impz returns the first n samples of the impulse response of the filter
+// with transfer function coefficients b and a.
+func Impz(b, a []float64, n int) []float64 {
+	x := make([]float64, n)
+	if n > 0 {
+		x[0] = 1
+	}
+	return Lfilter(b, a, x)
+}
+
+// Stepz returns the first n samples of the step response of the filter with
+// transfer function coefficients b and a.
+func Stepz(b, a []float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1
+	}
+	return Lfilter(b, a, x)
+}
+
+// ImpzSos is like Impz, but for the second-order sections sos.
+func ImpzSos(sos [][6]float64, n int) []float64 {
+	x := make([]float64, n)
+	if n > 0 {
+		x[0] = 1
+	}
+	return SosFilt(sos, x)
+}
+
+// StepzSos is like Stepz, but for the second-order sections sos.
+func StepzSos(sos [][6]float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = 1
+	}
+	return SosFilt(sos, x)
+}
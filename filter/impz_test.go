@@ -0,0 +1,47 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestImpz(t *testing.T) {
+	b := []float64{1}
+	a := []float64{1, -0.5}
+
+	if o, e := Impz(b, a, 4), []float64{1, 0.5, 0.25, 0.125}; !dsputils.PrettyClose(o, e) {
+		t.Error("Impz error\noutput:", o, "\nexpected:", e)
+	}
+	if o, e := Stepz(b, a, 4), []float64{1, 1.5, 1.75, 1.875}; !dsputils.PrettyClose(o, e) {
+		t.Error("Stepz error\noutput:", o, "\nexpected:", e)
+	}
+	if o := Impz(b, a, 0); len(o) != 0 {
+		t.Error("Impz empty error:", o)
+	}
+
+	sos := Cheby1Sos(4, 1, []float64{0.2}, Lowpass, 1)
+	bs, as := Sos2Tf(sos)
+	if o, e := ImpzSos(sos, 20), Impz(bs, as, 20); !dsputils.PrettyClose(o, e) {
+		t.Error("ImpzSos error\noutput:", o, "\nexpected:", e)
+	}
+	if o, e := StepzSos(sos, 20), Stepz(bs, as, 20); !dsputils.PrettyClose(o, e) {
+		t.Error("StepzSos error\noutput:", o, "\nexpected:", e)
+	}
+}
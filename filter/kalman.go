@@ -0,0 +1,294 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ScalarKalman is a Kalman filter for a single random walk state observed
+// directly with noise, suitable for smoothing a slowly varying measurement.
+// Reference: https://en.wikipedia.org/wiki/Kalman_filter
+type ScalarKalman struct {
+	x, p float64 // state estimate and its variance
+	q, r float64 // process and measurement noise variances
+}
+
+// NewScalarKalman returns a ScalarKalman with initial estimate x0 of
+// variance p0, process noise variance q added at each step, and measurement
+// noise variance r. The ratio q/r sets the amount of smoothing: smaller
+// values smooth more.
+func NewScalarKalman(x0, p0, q, r float64) *ScalarKalman {
+	if p0 < 0 || q < 0 || r <= 0 {
+		panic("variances must be non-negative, and r positive")
+	}
+	return &ScalarKalman{x: x0, p: p0, q: q, r: r}
+}
+
+// Predict advances the filter one step, increasing the estimate's variance
+// by the process noise.
+func (k *ScalarKalman) Predict() {
+	k.p += k.q
+}
+
+// Update corrects the estimate with the measurement z and returns the new
+// estimate.
+func (k *ScalarKalman) Update(z float64) float64 {
+	g := k.p / (k.p + k.r)
+	k.x += g * (z - k.x)
+	k.p = k.p * k.r / (k.p + k.r)
+	return k.x
+}
+
+// Filter predicts and updates once for each measurement in z and returns the
+// estimates.
+func (k *ScalarKalman) Filter(z []float64) []float64 {
+	r := make([]float64, len(z))
+	for i, v := range z {
+		k.Predict()
+		r[i] = k.Update(v)
+	}
+	return r
+}
+
+// State returns the current estimate and its variance.
+func (k *ScalarKalman) State() (x, p float64) {
+	return k.x, k.p
+}
+
+// Kalman is a linear Kalman filter with n states and m measurements. The
+// state evolves as x' = F*x + w, and is measured as z = H*x + v, where w and
+// v are zero-mean Gaussian noise with covariances Q and R. Matrices are
+// slices of rows.
+// Reference: https://en.wikipedia.org/wiki/Kalman_filter
+type Kalman struct {
+	x          []float64
+	p          [][]float64
+	f, h, q, r [][]float64
+}
+
+// NewKalman returns a Kalman filter with n by n state transition matrix F,
+// m by n measurement matrix H, process noise covariance Q (n by n),
+// measurement noise covariance R (m by m), initial state x0 (length n), and
+// initial state covariance P0 (n by n). Both n and m must be positive. The
+// matrices are copied.
+func NewKalman(F, H, Q, R [][]float64, x0 []float64, P0 [][]float64) *Kalman {
+	if err := checkKalman(F, H, Q, R, x0, P0); err != nil {
+		panic(err.Error())
+	}
+	return &Kalman{
+		x: append([]float64(nil), x0...),
+		p: matCopy(P0),
+		f: matCopy(F),
+		h: matCopy(H),
+		q: matCopy(Q),
+		r: matCopy(R),
+	}
+}
+
+// checkKalman returns an error describing the first inconsistent dimension
+// of the arguments to NewKalman, or nil if there is none.
+func checkKalman(F, H, Q, R [][]float64, x0 []float64, P0 [][]float64) error {
+	n := len(x0)
+	if n == 0 {
+		return errors.New("x0 must be non-empty")
+	}
+	m := len(H)
+	if m == 0 {
+		return errors.New("H must be non-empty")
+	}
+	for _, c := range []struct {
+		a          [][]float64
+		rows, cols int
+		name       string
+	}{
+		{F, n, n, "F"},
+		{H, m, n, "H"},
+		{Q, n, n, "Q"},
+		{R, m, m, "R"},
+		{P0, n, n, "P0"},
+	} {
+		if len(c.a) != c.rows {
+			return fmt.Errorf("%s must have %d rows", c.name, c.rows)
+		}
+		for _, row := range c.a {
+			if len(row) != c.cols {
+				return fmt.Errorf("%s must have %d columns", c.name, c.cols)
+			}
+		}
+	}
+	return nil
+}
+
+// Predict advances the state estimate and its covariance one step.
+func (k *Kalman) Predict() {
+	k.x = matVec(k.f, k.x)
+	k.p = matAdd(matMul(matMul(k.f, k.p), matTranspose(k.f)), k.q)
+}
+
+// Update corrects the state estimate with the measurement z (length m). An
+// error is returned if the innovation covariance is singular, in which case
+// the estimate is unchanged.
+func (k *Kalman) Update(z []float64) error {
+	if len(z) != len(k.h) {
+		panic("z must have one element per row of H")
+	}
+
+	// Innovation y = z - H*x and its covariance S = H*P*H' + R.
+	y := matVec(k.h, k.x)
+	for i := range y {
+		y[i] = z[i] - y[i]
+	}
+	pht := matMul(k.p, matTranspose(k.h))
+	s := matAdd(matMul(k.h, pht), k.r)
+	si, err := matInverse(s)
+	if err != nil {
+		return err
+	}
+
+	// Gain K = P*H'*S^-1.
+	g := matMul(pht, si)
+	for i, v := range matVec(g, y) {
+		k.x[i] += v
+	}
+
+	// P = (I - K*H)*P, symmetrized to limit rounding error.
+	kh := matMul(g, k.h)
+	for i := range kh {
+		for j := range kh[i] {
+			kh[i][j] = -kh[i][j]
+		}
+		kh[i][i]++
+	}
+	k.p = matMul(kh, k.p)
+	for i := range k.p {
+		for j := 0; j < i; j++ {
+			v := (k.p[i][j] + k.p[j][i]) / 2
+			k.p[i][j], k.p[j][i] = v, v
+		}
+	}
+	return nil
+}
+
+// State returns a copy of the current state estimate.
+func (k *Kalman) State() []float64 {
+	return append([]float64(nil), k.x...)
+}
+
+// Covariance returns a copy of the current state covariance.
+func (k *Kalman) Covariance() [][]float64 {
+	return matCopy(k.p)
+}
+
+func matCopy(a [][]float64) [][]float64 {
+	r := make([][]float64, len(a))
+	for i, row := range a {
+		r[i] = append([]float64(nil), row...)
+	}
+	return r
+}
+
+func matVec(a [][]float64, x []float64) []float64 {
+	r := make([]float64, len(a))
+	for i, row := range a {
+		for j, v := range row {
+			r[i] += v * x[j]
+		}
+	}
+	return r
+}
+
+func matMul(a, b [][]float64) [][]float64 {
+	r := make([][]float64, len(a))
+	for i, row := range a {
+		r[i] = make([]float64, len(b[0]))
+		for k, v := range row {
+			for j, w := range b[k] {
+				r[i][j] += v * w
+			}
+		}
+	}
+	return r
+}
+
+func matAdd(a, b [][]float64) [][]float64 {
+	r := matCopy(a)
+	for i, row := range b {
+		for j, v := range row {
+			r[i][j] += v
+		}
+	}
+	return r
+}
+
+func matTranspose(a [][]float64) [][]float64 {
+	r := make([][]float64, len(a[0]))
+	for j := range r {
+		r[j] = make([]float64, len(a))
+		for i := range a {
+			r[j][i] = a[i][j]
+		}
+	}
+	return r
+}
+
+// matInverse returns the inverse of the square matrix a, computed by
+// Gauss-Jordan elimination with partial pivoting.
+func matInverse(a [][]float64) ([][]float64, error) {
+	n := len(a)
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, 2*n)
+		copy(m[i], a[i])
+		m[i][n+i] = 1
+	}
+
+	for c := 0; c < n; c++ {
+		p := c
+		for i := c + 1; i < n; i++ {
+			if math.Abs(m[i][c]) > math.Abs(m[p][c]) {
+				p = i
+			}
+		}
+		if m[p][c] == 0 {
+			return nil, fmt.Errorf("filter: kalman: singular matrix")
+		}
+		m[c], m[p] = m[p], m[c]
+
+		d := m[c][c]
+		for j := range m[c] {
+			m[c][j] /= d
+		}
+		for i := range m {
+			if i == c || m[i][c] == 0 {
+				continue
+			}
+			f := m[i][c]
+			for j := range m[i] {
+				m[i][j] -= f * m[c][j]
+			}
+		}
+	}
+
+	r := make([][]float64, n)
+	for i := range r {
+		r[i] = m[i][n:]
+	}
+	return r, nil
+}
@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestScalarKalman(t *testing.T) {
+	// With no process noise, the estimate is the running mean of the
+	// measurements and its variance is r/n.
+	k := NewScalarKalman(0, 1e12, 0, 4)
+	z := []float64{2, 4, 6, 8}
+	o := k.Filter(z)
+	e := []float64{2, 3, 4, 5}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("ScalarKalman error\ninput:", z, "\noutput:", o, "\nexpected:", e)
+	}
+	if _, p := k.State(); math.Abs(p-1) > 1e-6 {
+		t.Error("ScalarKalman variance error:", p)
+	}
+}
+
+func TestKalman(t *testing.T) {
+	// Track position and velocity of a constant velocity target from noisy
+	// position measurements.
+	const dt, v, noise = 0.1, 3.0, 0.5
+	rng := rand.New(rand.NewSource(1))
+	k := NewKalman(
+		[][]float64{{1, dt}, {0, 1}},
+		[][]float64{{1, 0}},
+		[][]float64{{1e-6, 0}, {0, 1e-6}},
+		[][]float64{{noise * noise}},
+		[]float64{0, 0},
+		[][]float64{{100, 0}, {0, 100}},
+	)
+	for i := 1; i <= 500; i++ {
+		k.Predict()
+		pos := v * dt * float64(i)
+		if err := k.Update([]float64{pos + noise*rng.NormFloat64()}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	x := k.State()
+	if math.Abs(x[0]-v*dt*500) > 0.2 || math.Abs(x[1]-v) > 0.05 {
+		t.Error("Kalman error\noutput:", x, "\nexpected:", []float64{v * dt * 500, v})
+	}
+	P := k.Covariance()
+	if P[0][1] != P[1][0] || P[0][0] <= 0 || P[1][1] <= 0 {
+		t.Error("Kalman covariance error:", P)
+	}
+
+	// A scalar model matches ScalarKalman.
+	s := NewScalarKalman(1, 2, 0.1, 0.5)
+	m := NewKalman([][]float64{{1}}, [][]float64{{1}}, [][]float64{{0.1}}, [][]float64{{0.5}}, []float64{1}, [][]float64{{2}})
+	for _, z := range []float64{1.5, 0.5, 2, 3} {
+		s.Predict()
+		m.Predict()
+		e := s.Update(z)
+		m.Update([]float64{z})
+		if o := m.State()[0]; math.Abs(o-e) > 1e-12 {
+			t.Error("Kalman scalar error\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
+
+func TestKalmanPanics(t *testing.T) {
+	one := [][]float64{{1}}
+	tests := []func(){
+		func() { NewKalman(one, one, one, one, nil, one) },
+		func() { NewKalman(one, nil, one, nil, []float64{0}, one) },
+		func() { NewKalman(one, [][]float64{}, one, [][]float64{}, []float64{0}, one) },
+		func() { NewKalman(one, one, one, nil, []float64{0}, one) },
+	}
+	for i, f := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Error("NewKalman did not panic:", i)
+				}
+			}()
+			f()
+		}()
+	}
+}
+
+func TestMatInverse(t *testing.T) {
+	a := [][]float64{{4, 7, 2}, {3, 6, 1}, {2, 5, 3}}
+	ai, err := matInverse(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, row := range matMul(a, ai) {
+		e := make([]float64, len(row))
+		e[i] = 1
+		if !dsputils.PrettyClose(row, e) {
+			t.Error("matInverse error\noutput:", row, "\nexpected:", e)
+		}
+	}
+
+	if _, err := matInverse([][]float64{{1, 2}, {2, 4}}); err == nil {
+		t.Error("matInverse expected singular error")
+	}
+}
@@ -0,0 +1,175 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package filter provides digital filter design and filtering functions.
+package filter
+
+// normalize returns copies of b and a, zero-padded to the same length and
+// scaled so that a[0] is 1.
+func normalize(b, a []float64) ([]float64, []float64) {
+	if len(a) == 0 || a[0] == 0 {
+		panic("a[0] must be nonzero")
+	}
+	if len(b) == 0 {
+		panic("empty numerator")
+	}
+
+	n := len(b)
+	if len(a) > n {
+		n = len(a)
+	}
+
+	nb := make([]float64, n)
+	na := make([]float64, n)
+	for i, v := range b {
+		nb[i] = v / a[0]
+	}
+	for i, v := range a {
+		na[i] = v / a[0]
+	}
+
+	return nb, na
+}
+
+// Lfilter filters x with the rational transfer function defined by the
+// numerator coefficients b and denominator coefficients a:
+//
+//	        b[0] + b[1]z^-1 + ... + b[M]z^-M
+//	Y(z) = ---------------------------------- X(z)
+//	        a[0] + a[1]z^-1 + ... + a[N]z^-N
+//
+// using the direct form II transposed structure, with zero initial state.
+// a[0] must be nonzero; the coefficients are normalized by it. b and a may
+// have any lengths; the shorter is padded with zeros. An FIR filter has
+// a = []float64{1}. To filter a signal in chunks, use LfilterState or a
+// Stream.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.lfilter.html
+func Lfilter(b, a, x []float64) []float64 {
+	y, _ := LfilterState(b, a, x, nil)
+	return y
+}
+
+// LfilterState is like Lfilter, but starts from the filter delay values zi
+// and also returns the final delay values zf. zi and zf have length
+// max(len(a), len(b)) - 1; a nil zi is all zeros. Passing zf as zi to the
+// next call filters a signal in consecutive chunks.
+func LfilterState(b, a, x, zi []float64) (y, zf []float64) {
+	b, a = normalize(b, a)
+	z := make([]float64, len(a))
+	if zi != nil {
+		if len(zi) != len(a)-1 {
+			panic("incorrect zi length")
+		}
+		copy(z, zi)
+	}
+	y = make([]float64, len(x))
+
+	for i, xi := range x {
+		yi := b[0]*xi + z[0]
+		for j := 1; j < len(a); j++ {
+			z[j-1] = b[j]*xi + z[j] - a[j]*yi
+		}
+		y[i] = yi
+	}
+
+	return y, z[:len(z)-1]
+}
+
+// Lfiltic returns the delay values for LfilterState that correspond to the
+// past inputs x and outputs y, most recent first: x[0] and y[0] are the
+// samples immediately before the ones to be filtered. Missing values are
+// taken to be zero.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.lfiltic.html
+func Lfiltic(b, a, y, x []float64) []float64 {
+	b, a = normalize(b, a)
+	zi := make([]float64, len(a)-1)
+	for m := range zi {
+		for i := m + 1; i < len(a); i++ {
+			if k := i - m - 1; k < len(x) {
+				zi[m] += b[i] * x[k]
+			}
+			if k := i - m - 1; k < len(y) {
+				zi[m] -= a[i] * y[k]
+			}
+		}
+	}
+	return zi
+}
+
+// LfilterZi returns the delay values for LfilterState corresponding to the
+// steady state of the step response. Scaling it by the first input sample
+// gives initial conditions that avoid a startup transient.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.lfilter_zi.html
+func LfilterZi(b, a []float64) []float64 {
+	b, a = normalize(b, a)
+
+	var sb, sa float64
+	for i := range a {
+		sb += b[i]
+		sa += a[i]
+	}
+	if sa == 0 {
+		panic("filter has a pole at z = 1")
+	}
+	g := sb / sa
+
+	// In steady state the input is 1 and the output is the DC gain g.
+	x := make([]float64, len(a))
+	y := make([]float64, len(a))
+	for i := range x {
+		x[i] = 1
+		y[i] = g
+	}
+	return Lfiltic(b, a, y, x)
+}
+
+// FiltFilt applies the filter with transfer function coefficients b and a to
+// x forward and backward, giving a zero-phase result with squared magnitude
+// response. The edges of x are extended by odd reflection and the filter
+// state is initialized with LfilterZi to reduce transients.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.filtfilt.html
+func FiltFilt(b, a, x []float64) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	n := len(a)
+	if len(b) > n {
+		n = len(b)
+	}
+	padlen := 3 * n
+	if padlen > len(x)-1 {
+		padlen = len(x) - 1
+	}
+
+	ext := oddExtend(x, padlen)
+	zi := LfilterZi(b, a)
+
+	scaled := func(v float64) []float64 {
+		r := make([]float64, len(zi))
+		for i, z := range zi {
+			r[i] = z * v
+		}
+		return r
+	}
+
+	y, _ := LfilterState(b, a, ext, scaled(ext[0]))
+	reverse(y)
+	y, _ = LfilterState(b, a, y, scaled(y[0]))
+	reverse(y)
+
+	return y[padlen : len(y)-padlen]
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type lfilterTest struct {
+	b, a, x, y []float64
+}
+
+// Expected outputs are from scipy.signal.lfilter.
+var lfilterTests = []lfilterTest{
+	{ // empty input
+		[]float64{1},
+		[]float64{1},
+		[]float64{},
+		[]float64{},
+	},
+	{ // FIR
+		[]float64{0.2, 0.3},
+		[]float64{1},
+		[]float64{1, 2, 3, 4, 5},
+		[]float64{0.2, 0.7, 1.2, 1.7, 2.2},
+	},
+	{ // one-pole IIR
+		[]float64{1},
+		[]float64{1, -0.5},
+		[]float64{1, 0, 0, 0, 0},
+		[]float64{1, 0.5, 0.25, 0.125, 0.0625},
+	},
+	{
+		[]float64{0.5, 0.5},
+		[]float64{1, -0.2},
+		[]float64{1, 2, 3, 4},
+		[]float64{0.5, 1.6, 2.82, 4.064},
+	},
+	{ // unnormalized a, len(a) > len(b)
+		[]float64{1, 2, 1},
+		[]float64{2, -1.2, 0.4},
+		[]float64{1, 0, 0, 0, 0, 0},
+		[]float64{0.5, 1.3, 1.18, 0.448, 0.0328, -0.06992},
+	},
+}
+
+func TestLfilter(t *testing.T) {
+	for _, v := range lfilterTests {
+		o := Lfilter(v.b, v.a, v.x)
+		if !dsputils.PrettyClose(o, v.y) {
+			t.Error("Lfilter error\ninput:", v.b, v.a, v.x, "\noutput:", o, "\nexpected:", v.y)
+		}
+	}
+}
+
+func TestLfilterState(t *testing.T) {
+	b, a := Cheby1(4, 1, []float64{0.2}, Lowpass, 1)
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = float64(i%7) - 3
+	}
+	y := Lfilter(b, a, x)
+
+	// Filtering in chunks gives the same result as all at once.
+	var yc []float64
+	var zi []float64
+	for i := 0; i < len(x); i += 30 {
+		end := i + 30
+		if end > len(x) {
+			end = len(x)
+		}
+		var o []float64
+		o, zi = LfilterState(b, a, x[i:end], zi)
+		yc = append(yc, o...)
+	}
+	if !dsputils.PrettyClose(yc, y) {
+		t.Error("LfilterState chunk error\noutput:", yc, "\nexpected:", y)
+	}
+
+	// Lfiltic reconstructs the state from past inputs and outputs.
+	zi = Lfiltic(b, a, []float64{y[49], y[48], y[47], y[46]}, []float64{x[49], x[48], x[47], x[46]})
+	o, _ := LfilterState(b, a, x[50:], zi)
+	if !dsputils.PrettyClose(o, y[50:]) {
+		t.Error("Lfiltic error\noutput:", o, "\nexpected:", y[50:])
+	}
+}
+
+func TestLfilterZi(t *testing.T) {
+	b, a := Cheby2(3, 30, []float64{0.1}, Lowpass, 1)
+	zi := LfilterZi(b, a)
+	for i := range zi {
+		zi[i] *= 2
+	}
+
+	// Starting from the steady state, a step input gives a constant output.
+	x := []float64{2, 2, 2, 2, 2, 2}
+	y, _ := LfilterState(b, a, x, zi)
+	for _, v := range y {
+		if !dsputils.Float64Equal(v, y[0]) {
+			t.Fatal("LfilterZi error:", y)
+		}
+	}
+
+	sos := Cheby2Sos(3, 30, []float64{0.1}, Lowpass, 1)
+	szi := SosFiltZi(sos)
+	for i := range szi {
+		szi[i][0] *= 2
+		szi[i][1] *= 2
+	}
+	ys, _ := SosFiltState(sos, x, szi)
+	if !dsputils.PrettyClose(ys, y) {
+		t.Error("SosFiltZi error\noutput:", ys, "\nexpected:", y)
+	}
+}
+
+func TestFiltFilt(t *testing.T) {
+	b, a := Cheby2(4, 40, []float64{0.1}, Lowpass, 1)
+	sos := Cheby2Sos(4, 40, []float64{0.1}, Lowpass, 1)
+	x := make([]float64, 300)
+	for i := range x {
+		x[i] = math.Sin(2*math.Pi*0.01*float64(i)) + 0.2*math.Sin(2*math.Pi*0.4*float64(i))
+	}
+	y := FiltFilt(b, a, x)
+	ys := SosFiltFilt(sos, x)
+	for i := range y {
+		if math.Abs(y[i]-ys[i]) > 1e-6 {
+			t.Fatal("FiltFilt error at", i, ":", y[i], ys[i])
+		}
+	}
+}
+
+// This example filters a signal that arrives in chunks, carrying the delay
+// values from each chunk to the next.
+func ExampleLfilterState() {
+	b := []float64{0.5, 0.5}
+	a := []float64{1, -0.2}
+
+	var z []float64
+	for _, chunk := range [][]float64{{1, 2}, {3, 4}} {
+		var y []float64
+		y, z = LfilterState(b, a, chunk, z)
+		fmt.Printf("%.4f\n", y)
+	}
+	// Output:
+	// [0.5000 1.6000]
+	// [2.8200 4.0640]
+}
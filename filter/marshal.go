@@ -0,0 +1,345 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Filters with state implement encoding.BinaryMarshaler and
+// encoding.BinaryUnmarshaler, so a long running process can save its
+// coefficients and delay values and resume filtering after a restart. The
+// encoding starts with a kind and version byte and is not compatible between
+// different types.
+
+const stateVersion = 1
+
+const (
+	stateStream byte = iota + 1
+	stateAverager
+	stateExpSmoother
+	stateScalarKalman
+	stateKalman
+)
+
+// stateEncoder builds a binary encoding of filter state.
+type stateEncoder struct {
+	buf bytes.Buffer
+}
+
+func newStateEncoder(kind byte) *stateEncoder {
+	e := &stateEncoder{}
+	e.buf.WriteByte(kind)
+	e.buf.WriteByte(stateVersion)
+	return e
+}
+
+func (e *stateEncoder) uint(v uint64) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	e.buf.Write(b[:])
+}
+
+func (e *stateEncoder) float(v float64) {
+	e.uint(math.Float64bits(v))
+}
+
+func (e *stateEncoder) bool(v bool) {
+	if v {
+		e.buf.WriteByte(1)
+	} else {
+		e.buf.WriteByte(0)
+	}
+}
+
+func (e *stateEncoder) floats(v []float64) {
+	e.uint(uint64(len(v)))
+	for _, f := range v {
+		e.float(f)
+	}
+}
+
+func (e *stateEncoder) matrix(m [][]float64) {
+	e.uint(uint64(len(m)))
+	for _, row := range m {
+		e.floats(row)
+	}
+}
+
+// stateDecoder reads a binary encoding of filter state. The first error is
+// kept in err, after which all reads return zero values.
+type stateDecoder struct {
+	data []byte
+	err  error
+}
+
+func newStateDecoder(data []byte, kind byte) *stateDecoder {
+	d := &stateDecoder{data: data}
+	if len(data) < 2 {
+		d.err = fmt.Errorf("filter: unmarshal: data too short")
+	} else if data[0] != kind {
+		d.err = fmt.Errorf("filter: unmarshal: wrong kind %d, expected %d", data[0], kind)
+	} else if data[1] != stateVersion {
+		d.err = fmt.Errorf("filter: unmarshal: unsupported version %d", data[1])
+	} else {
+		d.data = data[2:]
+	}
+	return d
+}
+
+func (d *stateDecoder) next(n int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	if len(d.data) < n {
+		d.err = fmt.Errorf("filter: unmarshal: data too short")
+		return nil
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *stateDecoder) uint() uint64 {
+	b := d.next(8)
+	if b == nil {
+		return 0
+	}
+	return binary.LittleEndian.Uint64(b)
+}
+
+func (d *stateDecoder) float() float64 {
+	return math.Float64frombits(d.uint())
+}
+
+func (d *stateDecoder) bool() bool {
+	b := d.next(1)
+	return b != nil && b[0] != 0
+}
+
+// length reads a slice length, each of whose elements take at least size
+// bytes, failing if the remaining data is too short.
+func (d *stateDecoder) length(size int) int {
+	n := d.uint()
+	if d.err == nil && n > uint64(len(d.data)/size) {
+		d.err = fmt.Errorf("filter: unmarshal: invalid length %d", n)
+	}
+	if d.err != nil {
+		return 0
+	}
+	return int(n)
+}
+
+func (d *stateDecoder) floats() []float64 {
+	v := make([]float64, d.length(8))
+	for i := range v {
+		v[i] = d.float()
+	}
+	return v
+}
+
+func (d *stateDecoder) matrix() [][]float64 {
+	m := make([][]float64, d.length(8))
+	for i := range m {
+		m[i] = d.floats()
+	}
+	return m
+}
+
+// done returns the decoding error, if any, including for unread data.
+func (d *stateDecoder) done() error {
+	if d.err == nil && len(d.data) != 0 {
+		d.err = fmt.Errorf("filter: unmarshal: %d bytes of extra data", len(d.data))
+	}
+	return d.err
+}
+
+// MarshalBinary encodes the stream's coefficients and delay values. It
+// returns an error for a Stream not created by NewStream or NewSosStream.
+func (s *Stream) MarshalBinary() ([]byte, error) {
+	if s.sos == nil && len(s.a) == 0 {
+		return nil, fmt.Errorf("filter: marshal: uninitialized Stream")
+	}
+	e := newStateEncoder(stateStream)
+	// Whether the stream uses second-order sections, which may be empty.
+	e.bool(s.sos != nil)
+	e.floats(s.b)
+	e.floats(s.a)
+	e.floats(s.z)
+	e.uint(uint64(len(s.sos)))
+	for i, c := range s.sos {
+		e.floats(c[:])
+		e.floats(s.zsos[i][:])
+	}
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a Stream encoded by MarshalBinary.
+func (s *Stream) UnmarshalBinary(data []byte) error {
+	d := newStateDecoder(data, stateStream)
+	var n Stream
+	sos := d.bool()
+	n.b = d.floats()
+	n.a = d.floats()
+	n.z = d.floats()
+	if d.err == nil {
+		if sos && len(n.a) != 0 {
+			d.err = fmt.Errorf("filter: unmarshal: transfer function in sos stream")
+		} else if !sos && (len(n.a) == 0 || len(n.b) != len(n.a) || len(n.z) != len(n.a)-1) {
+			d.err = fmt.Errorf("filter: unmarshal: inconsistent stream lengths")
+		}
+	}
+	nsos := d.length(8 + 6*8 + 8 + 2*8)
+	if d.err == nil && !sos && nsos != 0 {
+		d.err = fmt.Errorf("filter: unmarshal: sections in transfer function stream")
+	}
+	if sos {
+		n.sos = make([][6]float64, 0, nsos)
+		n.zsos = make([][2]float64, 0, nsos)
+	}
+	for i := 0; i < nsos; i++ {
+		c, z := d.floats(), d.floats()
+		if d.err == nil && (len(c) != 6 || len(z) != 2) {
+			d.err = fmt.Errorf("filter: unmarshal: invalid section")
+		}
+		if d.err != nil {
+			break
+		}
+		n.sos = append(n.sos, [6]float64{c[0], c[1], c[2], c[3], c[4], c[5]})
+		n.zsos = append(n.zsos, [2]float64{z[0], z[1]})
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+	if sos {
+		n.b, n.a, n.z = nil, nil, nil
+	}
+	*s = n
+	return nil
+}
+
+// MarshalBinary encodes the averager's history.
+func (m *Averager) MarshalBinary() ([]byte, error) {
+	e := newStateEncoder(stateAverager)
+	e.floats(m.buf)
+	e.uint(uint64(m.pos))
+	e.float(m.sum)
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores an Averager encoded by MarshalBinary.
+func (m *Averager) UnmarshalBinary(data []byte) error {
+	d := newStateDecoder(data, stateAverager)
+	var n Averager
+	n.buf = d.floats()
+	pos := d.uint()
+	n.sum = d.float()
+	if d.err == nil && (len(n.buf) == 0 || pos >= uint64(len(n.buf))) {
+		d.err = fmt.Errorf("filter: unmarshal: invalid averager position")
+	}
+	if err := d.done(); err != nil {
+		return err
+	}
+	n.pos = int(pos)
+	*m = n
+	return nil
+}
+
+// MarshalBinary encodes the smoother's parameters and state.
+func (s *ExpSmoother) MarshalBinary() ([]byte, error) {
+	e := newStateEncoder(stateExpSmoother)
+	e.float(s.alpha)
+	e.float(s.beta)
+	e.bool(s.double)
+	e.float(s.level)
+	e.float(s.trend)
+	e.bool(s.started)
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores an ExpSmoother encoded by MarshalBinary.
+func (s *ExpSmoother) UnmarshalBinary(data []byte) error {
+	d := newStateDecoder(data, stateExpSmoother)
+	var n ExpSmoother
+	n.alpha = d.float()
+	n.beta = d.float()
+	n.double = d.bool()
+	n.level = d.float()
+	n.trend = d.float()
+	n.started = d.bool()
+	if err := d.done(); err != nil {
+		return err
+	}
+	*s = n
+	return nil
+}
+
+// MarshalBinary encodes the filter's parameters and state.
+func (k *ScalarKalman) MarshalBinary() ([]byte, error) {
+	e := newStateEncoder(stateScalarKalman)
+	e.float(k.x)
+	e.float(k.p)
+	e.float(k.q)
+	e.float(k.r)
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a ScalarKalman encoded by MarshalBinary.
+func (k *ScalarKalman) UnmarshalBinary(data []byte) error {
+	d := newStateDecoder(data, stateScalarKalman)
+	var n ScalarKalman
+	n.x = d.float()
+	n.p = d.float()
+	n.q = d.float()
+	n.r = d.float()
+	if err := d.done(); err != nil {
+		return err
+	}
+	if n.p < 0 || n.q < 0 || n.r <= 0 {
+		return fmt.Errorf("filter: unmarshal: invalid variances")
+	}
+	*k = n
+	return nil
+}
+
+// MarshalBinary encodes the filter's model matrices and state.
+func (k *Kalman) MarshalBinary() ([]byte, error) {
+	e := newStateEncoder(stateKalman)
+	e.floats(k.x)
+	for _, m := range [][][]float64{k.p, k.f, k.h, k.q, k.r} {
+		e.matrix(m)
+	}
+	return e.buf.Bytes(), nil
+}
+
+// UnmarshalBinary restores a Kalman encoded by MarshalBinary.
+func (k *Kalman) UnmarshalBinary(data []byte) error {
+	d := newStateDecoder(data, stateKalman)
+	x := d.floats()
+	p, f, h, q, r := d.matrix(), d.matrix(), d.matrix(), d.matrix(), d.matrix()
+	if err := d.done(); err != nil {
+		return err
+	}
+	if err := checkKalman(f, h, q, r, x, p); err != nil {
+		return fmt.Errorf("filter: unmarshal: %v", err)
+	}
+	*k = *NewKalman(f, h, q, r, x, p)
+	return nil
+}
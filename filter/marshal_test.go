@@ -0,0 +1,178 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"encoding"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type stateful interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	Process([]float64) []float64
+}
+
+func TestMarshalBinary(t *testing.T) {
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	b, a := Cheby1(4, 1, []float64{0.2}, Lowpass, 1)
+
+	tests := []struct {
+		name string
+		f    stateful
+		zero stateful
+	}{
+		{"Stream", NewStream(b, a), &Stream{}},
+		{"SosStream", NewSosStream(Cascade(PeakingEQ(1000, 1, 6, 48000), LowShelf(200, 1, -3, 48000))), &Stream{}},
+		{"Averager", NewAverager(7), &Averager{}},
+		{"ExpSmoother", NewHoltSmoother(0.3, 0.1), &ExpSmoother{}},
+		{"ScalarKalman", scalarKalmanProcess{NewScalarKalman(0, 1, 0.01, 1)}, scalarKalmanProcess{&ScalarKalman{}}},
+	}
+	for _, st := range tests {
+		// Filter half the signal, checkpoint into a new filter, and
+		// continue; the result matches filtering without interruption.
+		st.f.Process(x[:100])
+		data, err := st.f.MarshalBinary()
+		if err != nil {
+			t.Fatal(st.name, err)
+		}
+		e := st.f.Process(x[100:])
+
+		if err := st.zero.UnmarshalBinary(data); err != nil {
+			t.Fatal(st.name, err)
+		}
+		o := st.zero.Process(x[100:])
+		if !dsputils.PrettyClose(o, e) {
+			t.Error(st.name, "MarshalBinary error\noutput:", o, "\nexpected:", e)
+		}
+
+		if err := st.zero.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error(st.name, "expected error for truncated data")
+		}
+	}
+
+	var s Stream
+	if _, err := s.MarshalBinary(); err == nil {
+		t.Error("expected error for uninitialized Stream")
+	}
+	data, _ := NewAverager(3).MarshalBinary()
+	if err := s.UnmarshalBinary(data); err == nil {
+		t.Error("expected error for wrong kind")
+	}
+}
+
+func TestMarshalBinaryEmptySos(t *testing.T) {
+	// A stream with no sections passes its input through, before and after
+	// a round trip.
+	x := []float64{1, -2, 3}
+	for _, sos := range [][][6]float64{nil, {}} {
+		data, err := NewSosStream(sos).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var s Stream
+		if err := s.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if o := s.Process(x); !dsputils.PrettyClose(o, x) {
+			t.Error("empty SosStream MarshalBinary error\noutput:", o, "\nexpected:", x)
+		}
+		if o := s.Next(4); o != 4 {
+			t.Error("empty SosStream Next error\noutput:", o, "\nexpected:", 4)
+		}
+	}
+}
+
+func TestMarshalBinaryKalman(t *testing.T) {
+	k := NewKalman(
+		[][]float64{{1, 1}, {0, 1}},
+		[][]float64{{1, 0}},
+		[][]float64{{0.01, 0}, {0, 0.01}},
+		[][]float64{{1}},
+		[]float64{0, 0},
+		[][]float64{{10, 0}, {0, 10}},
+	)
+	for i := 0; i < 10; i++ {
+		k.Predict()
+		k.Update([]float64{float64(i)})
+	}
+	data, err := k.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n Kalman
+	if err := n.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []*Kalman{k, &n} {
+		f.Predict()
+		f.Update([]float64{10})
+	}
+	if !dsputils.PrettyClose(n.State(), k.State()) {
+		t.Error("Kalman MarshalBinary error\noutput:", n.State(), "\nexpected:", k.State())
+	}
+}
+
+func TestUnmarshalBinaryKalmanInvalid(t *testing.T) {
+	// Encodings of inconsistent models are rejected without panicking.
+	one := [][]float64{{1}}
+	for i, k := range []*Kalman{
+		{x: []float64{0}, p: one, f: one, q: one, r: one},
+		{x: []float64{0}, p: one, f: one, h: one, q: one},
+		{x: []float64{0}, p: one, f: [][]float64{{1, 0}}, h: one, q: one, r: one},
+		{p: one, f: one, h: one, q: one, r: one},
+	} {
+		data, err := k.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var n Kalman
+		if err := n.UnmarshalBinary(data); err == nil {
+			t.Error("Kalman UnmarshalBinary did not fail:", i)
+		}
+	}
+
+	for i, k := range []*ScalarKalman{
+		{p: -1, q: 0.01, r: 1},
+		{p: 1, q: -0.01, r: 1},
+		{p: 1, q: 0.01, r: 0},
+	} {
+		data, err := k.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var n ScalarKalman
+		if err := n.UnmarshalBinary(data); err == nil {
+			t.Error("ScalarKalman UnmarshalBinary did not fail:", i)
+		}
+	}
+}
+
+// scalarKalmanProcess adapts ScalarKalman to the stateful interface.
+type scalarKalmanProcess struct {
+	*ScalarKalman
+}
+
+func (k scalarKalmanProcess) Process(x []float64) []float64 {
+	return k.Filter(x)
+}
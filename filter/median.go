@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"sort"
+)
+
+// Median applies a median filter to x with an odd window length kernel. The
+// input is padded with zeros at both ends, so the output has the same length
+// as x. Median filters remove impulsive noise (spikes) while preserving
+// edges.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.medfilt.html
+func Median(x []float64, kernel int) []float64 {
+	return Order(x, kernel, kernel/2)
+}
+
+// Order applies a rank-order filter to x with an odd window length kernel:
+// each output is the element of rank rank (0 is the smallest) among the
+// kernel samples centered on the corresponding input. The input is padded
+// with zeros at both ends. Rank 0 gives a minimum (erosion) filter, rank
+// kernel-1 a maximum (dilation) filter and rank kernel/2 a median filter.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.order_filter.html
+func Order(x []float64, kernel, rank int) []float64 {
+	if kernel < 1 || kernel%2 == 0 {
+		panic("kernel must be odd and positive")
+	}
+	if rank < 0 || rank >= kernel {
+		panic("rank out of range")
+	}
+
+	half := kernel / 2
+	at := func(i int) float64 {
+		if i < 0 || i >= len(x) {
+			return 0
+		}
+		return x[i]
+	}
+
+	// The window is kept sorted: each step removes the oldest sample and
+	// inserts the newest by binary search.
+	w := make([]float64, 0, kernel)
+	for i := -half; i <= half; i++ {
+		w = append(w, at(i))
+	}
+	sort.Float64s(w)
+
+	y := make([]float64, len(x))
+	for i := range y {
+		y[i] = w[rank]
+		if i == len(y)-1 {
+			break
+		}
+
+		old := at(i - half)
+		j := sort.SearchFloat64s(w, old)
+		copy(w[j:], w[j+1:])
+		w = w[:len(w)-1]
+
+		v := at(i + half + 1)
+		j = sort.SearchFloat64s(w, v)
+		w = append(w, 0)
+		copy(w[j+1:], w[j:])
+		w[j] = v
+	}
+
+	return y
+}
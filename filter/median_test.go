@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type medianTest struct {
+	in     []float64
+	kernel int
+	out    []float64
+}
+
+var medianTests = []medianTest{
+	{
+		[]float64{1, 2, 3, 4, 5},
+		1,
+		[]float64{1, 2, 3, 4, 5},
+	},
+	{
+		[]float64{1, 9, 3, 4, -7, 6},
+		3,
+		[]float64{1, 3, 4, 3, 4, 0},
+	},
+	{
+		[]float64{2, 6, 5, 4, 0, 3, 5, 7, 9, 2, 0, 1},
+		5,
+		[]float64{2, 4, 4, 4, 4, 4, 5, 5, 5, 2, 1, 0},
+	},
+}
+
+func TestMedian(t *testing.T) {
+	for _, mt := range medianTests {
+		o := Median(mt.in, mt.kernel)
+		if !dsputils.PrettyClose(o, mt.out) {
+			t.Error("Median error\ninput:", mt.in, mt.kernel, "\noutput:", o, "\nexpected:", mt.out)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = float64(rand.Intn(20))
+	}
+
+	const kernel = 7
+	for rank := 0; rank < kernel; rank++ {
+		o := Order(x, kernel, rank)
+		e := make([]float64, len(x))
+		for i := range e {
+			w := make([]float64, kernel)
+			for j := range w {
+				if k := i + j - kernel/2; k >= 0 && k < len(x) {
+					w[j] = x[k]
+				}
+			}
+			sort.Float64s(w)
+			e[i] = w[rank]
+		}
+		if !dsputils.PrettyClose(o, e) {
+			t.Error("Order error\nrank:", rank, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
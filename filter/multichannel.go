@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"runtime"
+	"sync"
+)
+
+// Processor is a streaming filter that processes a signal in consecutive
+// chunks, such as a Stream, FIR, Averager or Resampler.
+type Processor interface {
+	Process(x []float64) []float64
+}
+
+// MultiChannel applies one Processor per channel to a multichannel signal,
+// processing the channels concurrently.
+type MultiChannel struct {
+	ch      []Processor
+	workers int
+}
+
+// NewMultiChannel returns a MultiChannel with the per-channel filters ch.
+// Each filter must be a separate value, since filters carry state between
+// chunks.
+func NewMultiChannel(ch []Processor) *MultiChannel {
+	c := make([]Processor, len(ch))
+	copy(c, ch)
+	return &MultiChannel{ch: c}
+}
+
+// NewMultiChannelFunc returns a MultiChannel with n channels, each using the
+// filter returned by a call to f. For example, to apply the same second-order
+// sections to every channel:
+//
+//	m := NewMultiChannelFunc(16, func() Processor { return NewSosStream(sos) })
+func NewMultiChannelFunc(n int, f func() Processor) *MultiChannel {
+	ch := make([]Processor, n)
+	for i := range ch {
+		ch[i] = f()
+	}
+	return &MultiChannel{ch: ch}
+}
+
+// SetWorkers sets the number of channels processed at once. If n is 0 (the
+// default), then GOMAXPROCS workers are used.
+func (m *MultiChannel) SetWorkers(n int) {
+	if n < 0 {
+		n = 0
+	}
+	m.workers = n
+}
+
+// Channels returns the number of channels.
+func (m *MultiChannel) Channels() int {
+	return len(m.ch)
+}
+
+// Process filters the next chunk of each channel, x[i] with the filter of
+// channel i, and returns the outputs.
+func (m *MultiChannel) Process(x [][]float64) [][]float64 {
+	if len(x) != len(m.ch) {
+		panic("x must have one slice per channel")
+	}
+
+	workers := m.workers
+	if workers == 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if workers > len(x) {
+		workers = len(x)
+	}
+
+	y := make([][]float64, len(x))
+	idx := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for i := range idx {
+				y[i] = m.ch[i].Process(x[i])
+			}
+		}()
+	}
+	for i := range x {
+		idx <- i
+	}
+	close(idx)
+	wg.Wait()
+
+	return y
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestMultiChannel(t *testing.T) {
+	const channels = 9
+	x := make([][]float64, channels)
+	for i := range x {
+		x[i] = make([]float64, 200)
+		for j := range x[i] {
+			x[i][j] = rand.NormFloat64()
+		}
+	}
+
+	sos := Cheby2Sos(6, 40, []float64{0.1}, Lowpass, 1)
+	h := []float64{0.25, 0.5, 0.25}
+	for _, workers := range []int{0, 1, 4, 20} {
+		m := NewMultiChannelFunc(channels, func() Processor { return NewSosStream(sos) })
+		m.SetWorkers(workers)
+		if m.Channels() != channels {
+			t.Fatal("MultiChannel channels error:", m.Channels())
+		}
+
+		// Per-channel filters.
+		ch := make([]Processor, channels)
+		for i := range ch {
+			if i%2 == 0 {
+				ch[i] = NewFIR(h)
+			} else {
+				ch[i] = NewAverager(i)
+			}
+		}
+		p := NewMultiChannel(ch)
+		p.SetWorkers(workers)
+
+		var y, z [][]float64
+		for _, r := range [][2]int{{0, 50}, {50, 200}} {
+			chunk := make([][]float64, channels)
+			for i := range chunk {
+				chunk[i] = x[i][r[0]:r[1]]
+			}
+			o := m.Process(chunk)
+			q := p.Process(chunk)
+			if y == nil {
+				y, z = o, q
+				continue
+			}
+			for i := range y {
+				y[i] = append(y[i], o[i]...)
+				z[i] = append(z[i], q[i]...)
+			}
+		}
+
+		for i := range x {
+			if e := SosFilt(sos, x[i]); !dsputils.PrettyClose(y[i], e) {
+				t.Error("MultiChannel error\nworkers:", workers, "\nchannel:", i)
+			}
+			var e []float64
+			if i%2 == 0 {
+				e = FIRFilter(h, x[i])
+			} else {
+				e = MovingAverage(x[i], i)
+			}
+			if !dsputils.PrettyClose(z[i], e) {
+				t.Error("MultiChannel per-channel error\nworkers:", workers, "\nchannel:", i)
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// IirNotch returns the transfer function coefficients b and a of a second
+// order IIR notch filter, which removes the frequency f0 (in the same units
+// as the sampling frequency fs) and passes all others with unity gain. The
+// quality factor Q is f0 divided by the -3 dB bandwidth; higher Q gives a
+// narrower notch.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.iirnotch.html
+func IirNotch(f0, Q, fs float64) (b, a []float64) {
+	w0, gain := iirNotchParams(f0, Q, fs)
+	c := math.Cos(w0)
+	b = []float64{gain, -2 * gain * c, gain}
+	a = []float64{1, -2 * gain * c, 2*gain - 1}
+	return b, a
+}
+
+// IirPeak returns the transfer function coefficients b and a of a second
+// order IIR peak (resonator) filter, which passes the frequency f0 with unity
+// gain and attenuates all others. Q is as in IirNotch.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.iirpeak.html
+func IirPeak(f0, Q, fs float64) (b, a []float64) {
+	w0, gain := iirNotchParams(f0, Q, fs)
+	c := math.Cos(w0)
+	b = []float64{1 - gain, 0, gain - 1}
+	a = []float64{1, -2 * gain * c, 2*gain - 1}
+	return b, a
+}
+
+// iirNotchParams returns the center frequency in radians per sample and the
+// gain term shared by IirNotch and IirPeak.
+func iirNotchParams(f0, Q, fs float64) (w0, gain float64) {
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+	if f0 <= 0 || f0 >= fs/2 {
+		panic("f0 must be between 0 and fs/2")
+	}
+	if Q <= 0 {
+		panic("Q must be positive")
+	}
+
+	w0 = 2 * math.Pi * f0 / fs
+	bw := w0 / Q
+	gain = 1 / (1 + math.Tan(bw/2))
+	return w0, gain
+}
@@ -0,0 +1,63 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestIirNotch(t *testing.T) {
+	// 60 Hz hum removal at 1 kHz.
+	b, a := IirNotch(60, 30, 1000)
+	eb := []float64{0.99375596, -1.84794186, 0.99375596}
+	ea := []float64{1, -1.84794186, 0.98751193}
+	if !dsputils.PrettyClose(b, eb) || !dsputils.PrettyClose(a, ea) {
+		t.Error("IirNotch error\noutput:", b, a, "\nexpected:", eb, ea)
+	}
+
+	const fs = 1000
+	for _, f0 := range []float64{50, 60, 200} {
+		for _, Q := range []float64{2, 30} {
+			b, a := IirNotch(f0, Q, fs)
+			if g := gainDB(b, a, f0/fs); g > -100 {
+				t.Error("IirNotch center gain error:", f0, Q, g)
+			}
+			for _, f := range []float64{0, fs / 2} {
+				if g := gainDB(b, a, f/fs); math.Abs(g) > 1e-9 {
+					t.Error("IirNotch passband gain error:", f0, Q, f, g)
+				}
+			}
+			// The -3 dB points are at f0 ± about f0/(2Q).
+			lo := gainDB(b, a, (f0-f0/(2*Q))/fs)
+			hi := gainDB(b, a, (f0+f0/(2*Q))/fs)
+			if math.Abs(lo+3) > 1 || math.Abs(hi+3) > 1 {
+				t.Error("IirNotch bandwidth error:", f0, Q, lo, hi)
+			}
+
+			b, a = IirPeak(f0, Q, fs)
+			if g := gainDB(b, a, f0/fs); math.Abs(g) > 1e-9 {
+				t.Error("IirPeak center gain error:", f0, Q, g)
+			}
+			if g := gainDB(b, a, 0); g > -100 {
+				t.Error("IirPeak DC gain error:", f0, Q, g)
+			}
+		}
+	}
+}
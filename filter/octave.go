@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+)
+
+// OctaveBand is one band of a fractional-octave filterbank.
+type OctaveBand struct {
+	// Center, Lower and Upper are the exact (not nominal) center and edge
+	// frequencies of the band, in Hz.
+	Center, Lower, Upper float64
+
+	// Sos is the band's filter as second-order sections: a 6th order
+	// Butterworth bandpass (3rd order prototype) with -3 dB points at the
+	// band edges, as permitted by IEC 61260 class 1.
+	Sos [][6]float64
+}
+
+// OctaveBands returns the 1/b octave bands, with base ten center
+// frequencies as defined by IEC 61260-1 and ANSI S1.11, whose centers lie
+// between fmin and fmax. Use b = 1 for octave and b = 3 for third-octave
+// bands. Bands whose upper edge is not below the Nyquist frequency fs/2 are
+// omitted.
+// Reference: https://en.wikipedia.org/wiki/Octave_band
+func OctaveBands(b int, fmin, fmax, fs float64) []OctaveBand {
+	if b < 1 {
+		panic("b must be positive")
+	}
+	if fmin <= 0 || fmax < fmin {
+		panic("frequency range must be positive and increasing")
+	}
+
+	// Octave ratio G and exact center frequencies relative to 1 kHz.
+	G := math.Pow(10, 0.3)
+	center := func(x int) float64 {
+		if b%2 == 1 {
+			return 1000 * math.Pow(G, float64(x)/float64(b))
+		}
+		return 1000 * math.Pow(G, float64(2*x+1)/float64(2*b))
+	}
+
+	x := int(math.Floor(float64(b) * math.Log(fmin/1000) / math.Log(G)))
+	for center(x) < fmin {
+		x++
+	}
+
+	var bands []OctaveBand
+	for ; center(x) <= fmax; x++ {
+		fm := center(x)
+		edge := math.Pow(G, 1/(2*float64(b)))
+		band := OctaveBand{
+			Center: fm,
+			Lower:  fm / edge,
+			Upper:  fm * edge,
+		}
+		if band.Upper >= fs/2 {
+			break
+		}
+		z, p, k := Buttap(3)
+		band.Sos = Zpk2Sos(iirDesign(z, p, k, []float64{band.Lower, band.Upper}, Bandpass, fs))
+		bands = append(bands, band)
+	}
+	return bands
+}
+
+// BandLevels filters x with each band and returns the band levels in dB,
+// 10*log10 of the mean square of the filtered signal. Add the level of the
+// reference, e.g. -20*log10(20e-6) for sound pressure in pascals, to get
+// absolute levels.
+func BandLevels(bands []OctaveBand, x []float64) []float64 {
+	r := make([]float64, len(bands))
+	for i, band := range bands {
+		var ms float64
+		for _, v := range SosFilt(band.Sos, x) {
+			ms += v * v
+		}
+		if len(x) > 0 {
+			ms /= float64(len(x))
+		}
+		r[i] = 10 * math.Log10(ms)
+	}
+	return r
+}
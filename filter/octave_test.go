@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOctaveBands(t *testing.T) {
+	const fs = 48000
+
+	bands := OctaveBands(1, 30, 17000, fs)
+	var centers []float64
+	for _, b := range bands {
+		centers = append(centers, b.Center)
+	}
+	e := []float64{31.623, 63.096, 125.89, 251.19, 501.19, 1000, 1995.3, 3981.1, 7943.3, 15849}
+	if len(centers) != len(e) {
+		t.Fatal("OctaveBands error\noutput:", centers, "\nexpected:", e)
+	}
+	for i := range e {
+		if math.Abs(centers[i]-e[i])/e[i] > 1e-4 {
+			t.Error("OctaveBands error\noutput:", centers, "\nexpected:", e)
+			break
+		}
+	}
+
+	bands = OctaveBands(3, 900, 1300, fs)
+	centers = centers[:0]
+	for _, b := range bands {
+		centers = append(centers, b.Center)
+	}
+	e = []float64{1000, 1258.9}
+	if len(centers) != 2 || math.Abs(centers[0]-e[0]) > 1e-9 || math.Abs(centers[1]-e[1]) > 0.1 {
+		t.Error("OctaveBands third error\noutput:", centers, "\nexpected:", e)
+	}
+
+	// Butterworth bands have -3 dB at the edges and, apart from bilinear
+	// transform warping near Nyquist, unity gain at the center.
+	for _, b := range OctaveBands(3, 20, 20000, fs) {
+		for _, v := range []struct{ f, g float64 }{{b.Center, 0}, {b.Lower, -10 * math.Log10(2)}, {b.Upper, -10 * math.Log10(2)}} {
+			g := sosGainDB(b.Sos, v.f/fs)
+			if math.Abs(g-v.g) > 0.01 {
+				t.Error("OctaveBands gain error at", b.Center, v.f, ":", g, v.g)
+			}
+		}
+	}
+
+	// Even fractions are offset by half a band from 1 kHz.
+	bands = OctaveBands(2, 900, 1500, fs)
+	if len(bands) != 1 || math.Abs(bands[0].Center-1000*math.Pow(10, 0.075)) > 1e-9 {
+		t.Error("OctaveBands even error:", bands)
+	}
+}
+
+func sosGainDB(sos [][6]float64, f float64) float64 {
+	var g float64
+	for _, s := range sos {
+		g += gainDB(s[:3], s[3:], f)
+	}
+	return g
+}
+
+func TestBandLevels(t *testing.T) {
+	const fs = 48000
+	x := make([]float64, fs)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 1000 * float64(i) / fs)
+	}
+	bands := OctaveBands(1, 250, 4000, fs)
+	levels := BandLevels(bands, x)
+	for i, b := range bands {
+		if b.Center == 1000 {
+			if math.Abs(levels[i]+10*math.Log10(2)) > 0.05 {
+				t.Error("BandLevels error at", b.Center, ":", levels[i])
+			}
+		} else if levels[i] > -15 {
+			t.Error("BandLevels rejection error at", b.Center, ":", levels[i])
+		}
+	}
+}
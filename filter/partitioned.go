@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Convolver is a streaming partitioned convolution engine, for applying
+// long impulse responses such as reverbs in real time. The impulse response
+// is split into partitions whose spectra are multiplied with a frequency
+// domain delay line of input block spectra (uniformly partitioned
+// overlap-save), so the cost per sample grows with the logarithm of the
+// block size rather than the length of the impulse response.
+//
+// The output is delayed by Latency samples, the smallest block size.
+// Reference: Gardner, "Efficient Convolution without Input-Output Delay",
+// JAES 43(3), 1995.
+type Convolver struct {
+	block  int
+	hlen   int
+	stages []*convStage
+
+	in   []float64 // input of the current block
+	fifo []float64 // outputs ready to return
+	acc  []float64 // partial sums of future outputs
+}
+
+// convStage convolves with a segment of the impulse response, starting at
+// offset, in partitions of size block.
+type convStage struct {
+	block, offset int
+	parts         [][]complex128 // spectra of the partitions
+	fdl           [][]complex128 // input spectra, newest first
+	buf           []float64      // last 2*block inputs
+	fill          int            // new inputs in buf
+}
+
+// NewConvolver returns a Convolver for the impulse response h with uniform
+// partitions of size block, which must be a power of 2. Smaller blocks have
+// lower latency but cost more per sample.
+func NewConvolver(h []float64, block int) *Convolver {
+	return newConvolver(h, block, block)
+}
+
+// NewNonUniformConvolver returns a Convolver for the impulse response h
+// with partitions that grow from block to at most maxBlock, both powers of
+// 2. The start of h is convolved in blocks of size block, for low latency,
+// and the rest in larger blocks, which cost less per sample, placed late
+// enough in h that their latency is hidden. This is much faster than
+// NewConvolver for impulse responses of many seconds.
+func NewNonUniformConvolver(h []float64, block, maxBlock int) *Convolver {
+	if maxBlock < block {
+		panic("maxBlock must be at least block")
+	}
+	return newConvolver(h, block, maxBlock)
+}
+
+func newConvolver(h []float64, block, maxBlock int) *Convolver {
+	if len(h) == 0 {
+		panic("h must be non-empty")
+	}
+	if !dsputils.IsPowerOf2(block) || !dsputils.IsPowerOf2(maxBlock) {
+		panic("block sizes must be powers of 2")
+	}
+	c := &Convolver{
+		block: block,
+		hlen:  len(h),
+		fifo:  make([]float64, block),
+	}
+
+	// Each stage has 4 partitions, and the next stage 4 times larger blocks,
+	// so a stage of block size b starts at an offset of at least b - block,
+	// which is what its extra latency requires. The last stage takes the
+	// rest of h.
+	for offset, b := 0, block; offset < len(h); b *= 4 {
+		n := 4 * b
+		if b*4 > maxBlock {
+			n = len(h) - offset
+		}
+		end := offset + n
+		if end > len(h) {
+			end = len(h)
+		}
+		c.stages = append(c.stages, newConvStage(h[offset:end], b, offset))
+		offset = end
+		if b*4 > maxBlock {
+			break
+		}
+	}
+	return c
+}
+
+func newConvStage(h []float64, block, offset int) *convStage {
+	s := &convStage{
+		block:  block,
+		offset: offset,
+		buf:    make([]float64, 2*block),
+	}
+	for p := 0; p < len(h); p += block {
+		end := p + block
+		if end > len(h) {
+			end = len(h)
+		}
+		seg := make([]float64, 2*block)
+		copy(seg, h[p:end])
+		s.parts = append(s.parts, fft.FFTReal(seg))
+		s.fdl = append(s.fdl, make([]complex128, 2*block))
+	}
+	return s
+}
+
+// Latency returns the delay of the output in samples.
+func (c *Convolver) Latency() int {
+	return c.block
+}
+
+// Process filters the next chunk x of the signal and returns the output,
+// which has len(x) samples and is delayed by Latency samples.
+func (c *Convolver) Process(x []float64) []float64 {
+	y := make([]float64, len(x))
+	for i, v := range x {
+		c.in = append(c.in, v)
+		if len(c.in) == c.block {
+			c.run()
+		}
+		y[i] = c.fifo[0]
+		c.fifo = c.fifo[1:]
+	}
+	return y
+}
+
+// run convolves the completed input block, and queues the outputs it
+// completes.
+func (c *Convolver) run() {
+	for _, s := range c.stages {
+		s.push(c.in, c)
+	}
+	c.in = c.in[:0]
+
+	if len(c.acc) < c.block {
+		c.acc = append(c.acc, make([]float64, c.block-len(c.acc))...)
+	}
+	c.fifo = append(c.fifo, c.acc[:c.block]...)
+	c.acc = c.acc[c.block:]
+}
+
+// add adds y to the outputs starting at index i of acc.
+func (c *Convolver) add(i int, y []float64) {
+	if n := i + len(y); n > len(c.acc) {
+		c.acc = append(c.acc, make([]float64, n-len(c.acc))...)
+	}
+	for j, v := range y {
+		c.acc[i+j] += v
+	}
+}
+
+// push adds the input block x to the stage, and when a stage block is
+// complete adds its contribution to c's outputs.
+func (s *convStage) push(x []float64, c *Convolver) {
+	copy(s.buf[s.block+s.fill:], x)
+	s.fill += len(x)
+	if s.fill < s.block {
+		return
+	}
+	s.fill = 0
+
+	copy(s.fdl[1:], s.fdl)
+	s.fdl[0] = fft.FFTReal(s.buf)
+	copy(s.buf, s.buf[s.block:])
+
+	Y := make([]complex128, 2*s.block)
+	for p, H := range s.parts {
+		X := s.fdl[p]
+		for k := range Y {
+			Y[k] += X[k] * H[k]
+		}
+	}
+	out := fft.IFFT(Y)[s.block:]
+	y := make([]float64, s.block)
+	for i, v := range out {
+		y[i] = real(v)
+	}
+
+	// The stage block covers the inputs since s.block samples ago; acc
+	// starts at the base block just completed.
+	c.add(s.offset-(s.block-c.block), y)
+}
+
+// Flush returns the remaining output after the last chunk: the tail of the
+// impulse response and the samples delayed by the latency. Together with
+// the outputs of Process, it forms the full convolution of the input with
+// h, preceded by Latency zeros.
+func (c *Convolver) Flush() []float64 {
+	return c.Process(make([]float64, c.hlen-1+c.block))
+}
+
+// Reset clears the convolver's history, as at creation.
+func (c *Convolver) Reset() {
+	for _, s := range c.stages {
+		for i := range s.buf {
+			s.buf[i] = 0
+		}
+		for _, X := range s.fdl {
+			for i := range X {
+				X[i] = 0
+			}
+		}
+		s.fill = 0
+	}
+	c.in = c.in[:0]
+	c.fifo = make([]float64, c.block)
+	c.acc = c.acc[:0]
+}
@@ -0,0 +1,91 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestConvolver(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	randn := func(n int) []float64 {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = r.NormFloat64()
+		}
+		return x
+	}
+
+	tests := []struct {
+		hlen, block, maxBlock int
+	}{
+		{1, 16, 16},
+		{100, 16, 16},
+		{1000, 64, 64},
+		{1000, 16, 256},
+		{5000, 32, 1024},
+		{20, 8, 1024},
+	}
+	for _, v := range tests {
+		h := randn(v.hlen)
+		x := randn(3000)
+		e := Convolve(x, h)
+
+		c := NewNonUniformConvolver(h, v.block, v.maxBlock)
+		if v.block == v.maxBlock {
+			c = NewConvolver(h, v.block)
+		}
+		var y []float64
+		// Process in chunks that don't align with the blocks.
+		for i := 0; i < len(x); i += 77 {
+			j := i + 77
+			if j > len(x) {
+				j = len(x)
+			}
+			y = append(y, c.Process(x[i:j])...)
+		}
+		y = append(y, c.Flush()...)
+
+		lat := c.Latency()
+		if lat != v.block {
+			t.Errorf("Latency: got %v, expected %v", lat, v.block)
+		}
+		for i := 0; i < lat; i++ {
+			if y[i] != 0 {
+				t.Fatalf("Convolver %+v: latency sample %v is %v", v, i, y[i])
+			}
+		}
+		if !closeTo(y[lat:], e, 1e-9) {
+			t.Errorf("Convolver %+v error", v)
+		}
+	}
+}
+
+func TestConvolverReset(t *testing.T) {
+	h := []float64{1, 2, 3}
+	c := NewConvolver(h, 4)
+	x := []float64{1, 0, 0, 0, 0, 0, 0, 0}
+	y := c.Process(x)
+	c.Reset()
+	if o := c.Process(x); !closeTo(o, y, 0) {
+		t.Error("Reset error\noutput:", o, "\nexpected:", y)
+	}
+	if e := []float64{0, 0, 0, 0, 1, 2, 3, 0}; !closeTo(y, e, 1e-12) {
+		t.Error("Convolver error\noutput:", y, "\nexpected:", e)
+	}
+}
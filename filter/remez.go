@@ -0,0 +1,371 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"fmt"
+	"math"
+)
+
+// RemezType is the type of filter designed by Remez.
+type RemezType int
+
+const (
+	// RemezBandpass designs a linear phase filter with a piecewise constant
+	// desired response (lowpass, highpass, bandpass, bandstop, multiband).
+	RemezBandpass RemezType = iota
+
+	// RemezDifferentiator designs an antisymmetric filter whose desired
+	// response in each band is desired[i] times the frequency. The weight in
+	// each band is divided by frequency, so the relative error is minimized.
+	RemezDifferentiator
+
+	// RemezHilbert designs an antisymmetric Hilbert transformer.
+	RemezHilbert
+)
+
+const (
+	remezMaxIter     = 25
+	remezGridDensity = 16
+)
+
+// Remez returns the numtaps coefficients of the optimal (minimax) linear
+// phase FIR filter with the given band edges, computed by the Parks-McClellan
+// (Remez exchange) algorithm.
+//
+// bands is a monotonic sequence of band edge pairs in the range [0, fs/2].
+// desired holds the desired gain of each band, and weight the relative weight
+// of each band's error; a nil weight weights all bands equally. fs is the
+// sampling frequency. An error is returned if the algorithm fails to converge.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.remez.html
+func Remez(numtaps int, bands, desired, weight []float64, fs float64, typ RemezType) ([]float64, error) {
+	if numtaps < 1 {
+		panic("numtaps must be positive")
+	}
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+	if len(bands) == 0 || len(bands)%2 != 0 {
+		panic("bands must contain pairs of band edges")
+	}
+	nbands := len(bands) / 2
+	if len(desired) != nbands {
+		panic("desired must have one value per band")
+	}
+	if weight == nil {
+		weight = make([]float64, nbands)
+		for i := range weight {
+			weight[i] = 1
+		}
+	} else if len(weight) != nbands {
+		panic("weight must have one value per band")
+	}
+
+	edges := make([]float64, len(bands))
+	for i, v := range bands {
+		edges[i] = v / fs
+		if edges[i] < 0 || edges[i] > 0.5 || (i > 0 && edges[i] < edges[i-1]) {
+			panic("band edges must be monotonic and between 0 and fs/2")
+		}
+	}
+
+	positive := typ == RemezBandpass
+	odd := numtaps%2 == 1
+
+	r := numtaps / 2
+	if odd && positive {
+		r++
+	}
+
+	grid, D, W := remezGrid(r, edges, desired, weight, positive, odd)
+	if len(grid) <= r {
+		return nil, fmt.Errorf("filter: remez: too few grid points; increase band widths")
+	}
+
+	if typ == RemezDifferentiator {
+		for i, f := range grid {
+			D[i] *= f
+			if D[i] > 0.0001 {
+				W[i] /= f
+			}
+		}
+	}
+
+	// Convert the problem to one for an even length, symmetric filter.
+	for i, f := range grid {
+		var c float64
+		switch {
+		case positive && odd:
+			continue
+		case positive:
+			c = math.Cos(math.Pi * f)
+		case odd:
+			c = math.Sin(2 * math.Pi * f)
+		default:
+			c = math.Sin(math.Pi * f)
+		}
+		D[i] /= c
+		W[i] *= c
+	}
+
+	ext := make([]int, r+1)
+	for i := range ext {
+		ext[i] = i * (len(grid) - 1) / r
+	}
+
+	E := make([]float64, len(grid))
+	var p *remezParams
+	converged := false
+	for iter := 0; iter < remezMaxIter; iter++ {
+		p = newRemezParams(r, ext, grid, D, W)
+		for i, f := range grid {
+			E[i] = W[i] * (D[i] - p.a(f))
+		}
+
+		if !remezSearch(r, ext, E) {
+			return nil, fmt.Errorf("filter: remez: extremal search failed")
+		}
+
+		if remezDone(ext, E) {
+			converged = true
+			break
+		}
+	}
+	if !converged {
+		return nil, fmt.Errorf("filter: remez: failed to converge after %d iterations", remezMaxIter)
+	}
+	p = newRemezParams(r, ext, grid, D, W)
+
+	// Sample the frequency response and undo the conversion above.
+	A := make([]float64, numtaps/2+1)
+	for i := range A {
+		f := float64(i) / float64(numtaps)
+		var c float64
+		switch {
+		case positive && odd:
+			c = 1
+		case positive:
+			c = math.Cos(math.Pi * f)
+		case odd:
+			c = math.Sin(2 * math.Pi * f)
+		default:
+			c = math.Sin(math.Pi * f)
+		}
+		A[i] = p.a(f) * c
+	}
+
+	return remezFreqSample(numtaps, A, positive), nil
+}
+
+// remezGrid returns the dense frequency grid with desired values and weights.
+// Frequencies at which the symmetry conversion in Remez would divide by zero
+// are excluded.
+func remezGrid(r int, edges, desired, weight []float64, positive, odd bool) (grid, D, W []float64) {
+	delf := 0.5 / float64(remezGridDensity*r)
+
+	for b := 0; b < len(edges)/2; b++ {
+		lowf := edges[2*b]
+		highf := edges[2*b+1]
+		if !positive && lowf < delf {
+			lowf = delf
+		}
+		if highf > 0.5-delf && ((!positive && odd) || (positive && !odd)) {
+			highf = 0.5 - delf
+		}
+		if highf < lowf {
+			continue
+		}
+
+		k := int((highf-lowf)/delf + 0.5)
+		if k < 1 {
+			k = 1
+		}
+		for i := 0; i < k; i++ {
+			f := lowf + float64(i)*delf
+			if i == k-1 {
+				f = highf
+			}
+			grid = append(grid, f)
+			D = append(D, desired[b])
+			W = append(W, weight[b])
+		}
+	}
+
+	return
+}
+
+// remezParams holds the barycentric Lagrange interpolation parameters of
+// the current approximation.
+type remezParams struct {
+	x, y, ad []float64
+}
+
+func newRemezParams(r int, ext []int, grid, D, W []float64) *remezParams {
+	p := &remezParams{
+		x:  make([]float64, r+1),
+		y:  make([]float64, r+1),
+		ad: make([]float64, r+1),
+	}
+
+	for i, e := range ext {
+		p.x[i] = math.Cos(2 * math.Pi * grid[e])
+	}
+
+	// Interleave the products to avoid overflow and underflow.
+	ld := (r-1)/15 + 1
+	for i := range ext {
+		denom := 1.0
+		xi := p.x[i]
+		for j := 0; j < ld; j++ {
+			for k := j; k <= r; k += ld {
+				if k != i {
+					denom *= 2 * (xi - p.x[k])
+				}
+			}
+		}
+		if math.Abs(denom) < 0.00001 {
+			denom = 0.00001
+		}
+		p.ad[i] = 1 / denom
+	}
+
+	var numer, denom float64
+	sign := 1.0
+	for i, e := range ext {
+		numer += p.ad[i] * D[e]
+		denom += sign * p.ad[i] / W[e]
+		sign = -sign
+	}
+	delta := numer / denom
+
+	sign = 1
+	for i, e := range ext {
+		p.y[i] = D[e] - sign*delta/W[e]
+		sign = -sign
+	}
+
+	return p
+}
+
+// a returns the interpolated amplitude response at frequency f.
+func (p *remezParams) a(f float64) float64 {
+	var numer, denom float64
+	xc := math.Cos(2 * math.Pi * f)
+	for i, x := range p.x {
+		c := xc - x
+		if math.Abs(c) < 1e-7 {
+			return p.y[i]
+		}
+		c = p.ad[i] / c
+		denom += c
+		numer += c * p.y[i]
+	}
+	return numer / denom
+}
+
+// remezSearch finds the r+1 largest alternating extrema of the error E and
+// stores their grid indexes in ext. It returns false if too few are found.
+func remezSearch(r int, ext []int, E []float64) bool {
+	n := len(E)
+	var found []int
+
+	if (E[0] > 0 && E[0] > E[1]) || (E[0] < 0 && E[0] < E[1]) {
+		found = append(found, 0)
+	}
+	for i := 1; i < n-1; i++ {
+		if (E[i] >= E[i-1] && E[i] > E[i+1] && E[i] > 0) ||
+			(E[i] <= E[i-1] && E[i] < E[i+1] && E[i] < 0) {
+			found = append(found, i)
+		}
+	}
+	if j := n - 1; (E[j] > 0 && E[j] > E[j-1]) || (E[j] < 0 && E[j] < E[j-1]) {
+		found = append(found, j)
+	}
+
+	if len(found) < r+1 {
+		return false
+	}
+
+	for len(found) > r+1 {
+		// Delete the smaller of the first pair of extrema that don't
+		// alternate. If all alternate, delete the smaller end.
+		l := -1
+		for j := 1; j < len(found); j++ {
+			if (E[found[j]] > 0) == (E[found[j-1]] > 0) {
+				l = j
+				if math.Abs(E[found[j-1]]) < math.Abs(E[found[j]]) {
+					l = j - 1
+				}
+				break
+			}
+		}
+		if l < 0 {
+			l = 0
+			if math.Abs(E[found[len(found)-1]]) < math.Abs(E[found[0]]) {
+				l = len(found) - 1
+			}
+		}
+		found = append(found[:l], found[l+1:]...)
+	}
+
+	copy(ext, found)
+	return true
+}
+
+// remezDone returns true if the errors at the extrema are equal enough.
+func remezDone(ext []int, E []float64) bool {
+	min := math.Abs(E[ext[0]])
+	max := min
+	for _, e := range ext {
+		v := math.Abs(E[e])
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	return (max-min)/max < 0.0001
+}
+
+// remezFreqSample returns the N-point impulse response with the amplitude
+// response samples A, taken at frequencies k/N.
+func remezFreqSample(N int, A []float64, positive bool) []float64 {
+	h := make([]float64, N)
+	M := float64(N-1) / 2
+
+	for n := range h {
+		x := 2 * math.Pi * (float64(n) - M) / float64(N)
+		var val float64
+		if positive {
+			val = A[0]
+		} else if N%2 == 0 {
+			val = A[N/2] * math.Sin(math.Pi*(float64(n)-M))
+		}
+
+		for k := 1; k <= (N-1)/2; k++ {
+			if positive {
+				val += 2 * A[k] * math.Cos(x*float64(k))
+			} else {
+				val += 2 * A[k] * math.Sin(x*float64(k))
+			}
+		}
+		h[n] = val / float64(N)
+	}
+
+	return h
+}
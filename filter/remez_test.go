@@ -0,0 +1,119 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+// response returns the magnitude response of the FIR filter h at frequency
+// f, normalized to the sampling frequency.
+func response(h []float64, f float64) float64 {
+	var r complex128
+	for n, v := range h {
+		r += complex(v, 0) * cmplx.Exp(complex(0, -2*math.Pi*f*float64(n)))
+	}
+	return cmplx.Abs(r)
+}
+
+type remezTest struct {
+	name          string
+	numtaps       int
+	bands         []float64
+	desired       []float64
+	weight        []float64
+	typ           RemezType
+	antisymmetric bool
+	tol           []float64
+}
+
+var remezTests = []remezTest{
+	{"lowpass", 41, []float64{0, 0.2, 0.25, 0.5}, []float64{1, 0}, nil, RemezBandpass, false, []float64{0.05, 0.05}},
+	{"lowpass even", 40, []float64{0, 0.2, 0.25, 0.45}, []float64{1, 0}, nil, RemezBandpass, false, []float64{0.05, 0.05}},
+	{"highpass weighted", 51, []float64{0, 0.15, 0.2, 0.5}, []float64{0, 1}, []float64{10, 1}, RemezBandpass, false, []float64{0.005, 0.05}},
+	{"bandpass", 63, []float64{0, 0.1, 0.15, 0.3, 0.35, 0.5}, []float64{0, 1, 0}, nil, RemezBandpass, false, []float64{0.02, 0.02, 0.02}},
+	{"hilbert", 31, []float64{0.05, 0.45}, []float64{1}, nil, RemezHilbert, true, []float64{0.02}},
+	{"hilbert even", 30, []float64{0.05, 0.5}, []float64{1}, nil, RemezHilbert, true, []float64{0.02}},
+}
+
+func TestRemez(t *testing.T) {
+	for _, v := range remezTests {
+		h, err := Remez(v.numtaps, v.bands, v.desired, v.weight, 1, v.typ)
+		if err != nil {
+			t.Error(v.name, err)
+			continue
+		}
+		if len(h) != v.numtaps {
+			t.Error(v.name, "length error:", len(h))
+			continue
+		}
+
+		for i := range h {
+			s := h[len(h)-1-i]
+			if v.antisymmetric {
+				s = -s
+			}
+			if math.Abs(h[i]-s) > 1e-10 {
+				t.Error(v.name, "symmetry error:", h)
+				break
+			}
+		}
+
+		for b := 0; b < len(v.bands)/2; b++ {
+			for f := v.bands[2*b]; f <= v.bands[2*b+1]; f += 0.001 {
+				if e := math.Abs(response(h, f) - v.desired[b]); e > v.tol[b] {
+					t.Error(v.name, "response error at", f, ":", e)
+					break
+				}
+			}
+		}
+	}
+}
+
+func TestRemezDifferentiator(t *testing.T) {
+	h, err := Remez(32, []float64{0, 0.45}, []float64{2 * math.Pi}, nil, 1, RemezDifferentiator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for f := 0.01; f <= 0.45; f += 0.01 {
+		want := 2 * math.Pi * f
+		if e := math.Abs(response(h, f)-want) / want; e > 0.01 {
+			t.Error("differentiator error at", f, ":", response(h, f), want)
+		}
+	}
+}
+
+func TestRemezEquiripple(t *testing.T) {
+	h, err := Remez(25, []float64{0, 0.2, 0.3, 0.5}, []float64{1, 0}, nil, 1, RemezBandpass)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The peak passband and stopband errors are equal for equal weights.
+	var pass, stop float64
+	for f := 0.0; f <= 0.2; f += 0.0005 {
+		pass = math.Max(pass, math.Abs(response(h, f)-1))
+	}
+	for f := 0.3; f <= 0.5; f += 0.0005 {
+		stop = math.Max(stop, response(h, f))
+	}
+	if math.Abs(pass-stop)/pass > 0.02 {
+		t.Error("equiripple error: passband", pass, "stopband", stop)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Interp returns x upsampled by the integer factor p: p-1 zeros are inserted
+// between samples, and the images this creates are removed with a lowpass
+// FIR filter. The filter's delay is compensated, so the result has length
+// len(x)*p and result[i*p] corresponds to x[i].
+func Interp(x []float64, p int) []float64 {
+	if p < 1 {
+		panic("interpolation factor must be positive")
+	}
+	return Resample(x, p, 1)
+}
+
+// Resample returns x resampled by the rational factor p/q using a polyphase
+// filter: x is upsampled by p, lowpass filtered, and downsampled by q. The
+// filter's delay is compensated, so the result has length
+// ceil(len(x)*p/q) and is aligned with x. For example, p = 160, q = 147
+// converts 44.1 kHz audio to 48 kHz.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.resample_poly.html
+func Resample(x []float64, p, q int) []float64 {
+	if p < 1 || q < 1 {
+		panic("resampling factors must be positive")
+	}
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	g := gcd(p, q)
+	p /= g
+	q /= g
+	if p == 1 && q == 1 {
+		r := make([]float64, len(x))
+		copy(r, x)
+		return r
+	}
+
+	maxRate := p
+	if q > maxRate {
+		maxRate = q
+	}
+	half := 10 * maxRate
+	h := windowedSinc(2*half+1, 1/float64(maxRate), window.KaiserWindow(5).Values)
+	for i := range h {
+		h[i] *= float64(p)
+	}
+
+	// Pad the filter so that its delay is an integer number of output
+	// samples, and long enough to produce all of the output.
+	nOut := (len(x)*p + q - 1) / q
+	prePad := q - half%q
+	preRemove := (half + prePad) / q
+	postPad := 0
+	for upFirDnLen(len(h)+prePad+postPad, len(x), p, q) < nOut+preRemove {
+		postPad++
+	}
+	hp := make([]float64, prePad+len(h)+postPad)
+	copy(hp[prePad:], h)
+
+	return UpFirDn(hp, x, p, q)[preRemove : preRemove+nOut]
+}
+
+// UpFirDn upsamples x by up, filters it with the FIR filter h, and
+// downsamples it by down. It uses an efficient polyphase implementation that
+// never computes the discarded samples or multiplies by the inserted zeros.
+// The result has length ((len(x)-1)*up + len(h) - 1) / down + 1.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.upfirdn.html
+func UpFirDn(h, x []float64, up, down int) []float64 {
+	if up < 1 || down < 1 {
+		panic("resampling factors must be positive")
+	}
+	if len(h) == 0 {
+		panic("empty filter")
+	}
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	y := make([]float64, upFirDnLen(len(h), len(x), up, down))
+	for k := range y {
+		n := k * down
+
+		// Only filter taps aligned with nonzero upsampled samples
+		// contribute: j = n - i*up for input index i.
+		i := n / up
+		j := n - i*up
+		if i >= len(x) {
+			j += (i - len(x) + 1) * up
+			i = len(x) - 1
+		}
+		var sum float64
+		for ; j < len(h) && i >= 0; j, i = j+up, i-1 {
+			sum += h[j] * x[i]
+		}
+		y[k] = sum
+	}
+
+	return y
+}
+
+func upFirDnLen(nh, nx, up, down int) int {
+	return ((nx-1)*up+nh-1)/down + 1
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// windowedSinc returns a numtaps-point linear phase lowpass FIR filter with
+// the given cutoff, as a fraction of the Nyquist frequency, designed by
+// windowing the ideal sinc response with wf.
+func windowedSinc(numtaps int, cutoff float64, wf func(int) []float64) []float64 {
+	return firwin(numtaps, []float64{0, cutoff}, wf)
+}
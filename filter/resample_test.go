@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestInterp(t *testing.T) {
+	const f = 0.02
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * f * float64(i))
+	}
+
+	for _, p := range []int{1, 2, 3, 5} {
+		y := Interp(x, p)
+		if len(y) != len(x)*p {
+			t.Fatal("Interp length error:", p, len(y))
+		}
+
+		// Away from the edges, the result is the same sinusoid sampled p
+		// times faster.
+		for i := 30 * p; i < len(y)-30*p; i++ {
+			e := math.Sin(2 * math.Pi * f / float64(p) * float64(i))
+			if math.Abs(y[i]-e) > 1e-3 {
+				t.Fatal("Interp error at", p, i, ":", y[i], e)
+			}
+		}
+	}
+}
+
+func TestUpFirDn(t *testing.T) {
+	tests := []struct {
+		h, x     []float64
+		up, down int
+		y        []float64
+	}{
+		{[]float64{1}, []float64{1, 2, 3}, 1, 1, []float64{1, 2, 3}},
+		{[]float64{1}, []float64{1, 2, 3}, 3, 1, []float64{1, 0, 0, 2, 0, 0, 3}},
+		{[]float64{1, 1, 1}, []float64{1, 2, 3}, 3, 1, []float64{1, 1, 1, 2, 2, 2, 3, 3, 3}},
+		{[]float64{0.5, 1, 0.5}, []float64{1, 2, 3}, 2, 1, []float64{0.5, 1, 1.5, 2, 2.5, 3, 1.5}},
+		{[]float64{1}, []float64{1, 2, 3, 4, 5, 6, 7}, 1, 3, []float64{1, 4, 7}},
+		{[]float64{1, 1}, []float64{1, 2, 3, 4}, 2, 3, []float64{1, 2, 4}},
+	}
+
+	for _, v := range tests {
+		// Compare against upsampling, filtering, and downsampling directly.
+		up := make([]float64, (len(v.x)-1)*v.up+1)
+		for i, xv := range v.x {
+			up[i*v.up] = xv
+		}
+		full := directConvolve(up, v.h)
+		var e []float64
+		for i := 0; i < len(full); i += v.down {
+			e = append(e, full[i])
+		}
+		if !dsputils.PrettyClose(e, v.y) {
+			t.Fatal("UpFirDn test error\nexpected:", v.y, "\ndirect:", e)
+		}
+
+		if o := UpFirDn(v.h, v.x, v.up, v.down); !dsputils.PrettyClose(o, v.y) {
+			t.Error("UpFirDn error\noutput:", o, "\nexpected:", v.y)
+		}
+	}
+}
+
+func TestResample(t *testing.T) {
+	const f = 0.01
+	x := make([]float64, 441)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * f * float64(i))
+	}
+
+	for _, r := range [][2]int{{160, 147}, {147, 160}, {1, 2}, {3, 1}, {2, 2}} {
+		p, q := r[0], r[1]
+		y := Resample(x, p, q)
+		if n := (len(x)*p + q - 1) / q; len(y) != n {
+			t.Fatal("Resample length error:", p, q, len(y), n)
+		}
+
+		ratio := float64(q) / float64(p)
+		for i := len(y) / 8; i < len(y)*7/8; i++ {
+			e := math.Sin(2 * math.Pi * f * ratio * float64(i))
+			if math.Abs(y[i]-e) > 2e-3 {
+				t.Fatal("Resample error at", p, q, i, ":", y[i], e)
+			}
+		}
+	}
+}
+
+func TestResampleEmpty(t *testing.T) {
+	for _, r := range [][2]int{{160, 147}, {1, 2}, {3, 1}, {2, 2}} {
+		if y := Resample([]float64{}, r[0], r[1]); y == nil || len(y) != 0 {
+			t.Error("Resample empty error\nfactors:", r, "\noutput:", y)
+		}
+		if y := Resample(nil, r[0], r[1]); y == nil || len(y) != 0 {
+			t.Error("Resample nil error\nfactors:", r, "\noutput:", y)
+		}
+	}
+	for _, p := range []int{1, 2, 5} {
+		if y := Interp([]float64{}, p); y == nil || len(y) != 0 {
+			t.Error("Interp empty error\nfactor:", p, "\noutput:", y)
+		}
+	}
+}
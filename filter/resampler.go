@@ -0,0 +1,242 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"sync"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// ResamplerQuality selects the tradeoff between the accuracy and speed of a
+// Resampler.
+type ResamplerQuality int
+
+const (
+	// ResamplerFast uses a short kernel with about 50 dB of stopband
+	// attenuation and a passband to 90% of the Nyquist frequency.
+	ResamplerFast ResamplerQuality = iota
+
+	// ResamplerMedium gives about 85 dB of attenuation and a passband to
+	// 94% of the Nyquist frequency.
+	ResamplerMedium
+
+	// ResamplerHigh gives about 100 dB of attenuation and a passband to 96%
+	// of the Nyquist frequency.
+	ResamplerHigh
+
+	// ResamplerBest gives about 135 dB of attenuation and a passband to 97%
+	// of the Nyquist frequency, at about four times the cost of
+	// ResamplerMedium.
+	ResamplerBest
+)
+
+// resamplerKernel is a Kaiser windowed sinc interpolation kernel.
+type resamplerKernel struct {
+	zeros      int       // sinc zero crossings on each side
+	oversample int       // table entries per zero crossing
+	beta       float64   // Kaiser window beta
+	cutoff     float64   // passband edge as a fraction of Nyquist
+	table      []float64 // right half of the kernel
+	once       sync.Once
+}
+
+var resamplerKernels = [...]*resamplerKernel{
+	ResamplerFast:   {zeros: 8, oversample: 128, beta: 5, cutoff: 0.90},
+	ResamplerMedium: {zeros: 16, oversample: 512, beta: 8.6, cutoff: 0.94},
+	ResamplerHigh:   {zeros: 32, oversample: 1024, beta: 10, cutoff: 0.96},
+	ResamplerBest:   {zeros: 64, oversample: 2048, beta: 14, cutoff: 0.97},
+}
+
+// kernel returns the kernel for q, computing its table on first use.
+func (q ResamplerQuality) kernel() *resamplerKernel {
+	if q < ResamplerFast || q > ResamplerBest {
+		panic("unknown quality")
+	}
+	k := resamplerKernels[q]
+	k.once.Do(func() {
+		n := k.zeros * k.oversample
+		w := window.Kaiser(2*n+1, k.beta)[n:]
+		for i := 1; i < len(w); i++ {
+			t := math.Pi * float64(i) / float64(k.oversample)
+			w[i] *= math.Sin(t) / t
+		}
+		k.table = w
+	})
+	return k
+}
+
+// at returns the kernel at u, in zero crossings, by linear interpolation
+// between table entries: the polyphase coefficients for fractional phases.
+func (k *resamplerKernel) at(u float64) float64 {
+	pos := math.Abs(u) * float64(k.oversample)
+	i := int(pos)
+	if i >= len(k.table)-1 {
+		return 0
+	}
+	frac := pos - float64(i)
+	return k.table[i]*(1-frac) + k.table[i+1]*frac
+}
+
+// Resampler converts the sample rate of a stream by an arbitrary, possibly
+// irrational and time-varying, ratio using bandlimited (Kaiser windowed sinc)
+// interpolation with a polyphase kernel table. It is suitable for correcting
+// clock drift between devices. When the ratio is below 1, the kernel is
+// widened to avoid aliasing.
+type Resampler struct {
+	k       *resamplerKernel
+	cutoff  float64 // passband edge as a fraction of Nyquist
+	in, out float64 // rates; the ratio is out/in
+
+	buf []float64 // input history
+	t0  float64   // time of output 0 since the last rate change, as an index into buf
+	n   int       // number of outputs since the last rate change
+	end int       // len(buf) excluding flush padding, or -1
+}
+
+// NewResampler returns a Resampler whose output rate is ratio times its input
+// rate. It uses the ResamplerMedium kernel with the passband extended to the
+// Nyquist frequency, so a ratio of 1 is the identity.
+func NewResampler(ratio float64) *Resampler {
+	r := &Resampler{k: ResamplerMedium.kernel(), cutoff: 1, end: -1}
+	r.SetRatio(ratio)
+	return r
+}
+
+// NewResamplerRates returns a Resampler from inRate to outRate samples per
+// second with the quality q. Output times are computed from the rates, so
+// integer rates give exactly outRate samples for each inRate input samples.
+func NewResamplerRates(inRate, outRate float64, q ResamplerQuality) *Resampler {
+	if !(inRate > 0) || !(outRate > 0) || math.IsInf(inRate, 0) || math.IsInf(outRate, 0) {
+		panic("rates must be positive")
+	}
+	k := q.kernel()
+	r := &Resampler{k: k, cutoff: k.cutoff, end: -1}
+	r.setRates(inRate, outRate)
+	return r
+}
+
+// SetRatio changes the ratio of output rate to input rate, taking effect
+// at the next output sample.
+func (r *Resampler) SetRatio(ratio float64) {
+	if ratio <= 0 || math.IsInf(ratio, 0) || math.IsNaN(ratio) {
+		panic("ratio must be positive")
+	}
+	r.setRates(1, ratio)
+}
+
+// setRates changes the input and output rates, taking effect at the next
+// output sample.
+func (r *Resampler) setRates(in, out float64) {
+	if r.n > 0 {
+		r.t0 = r.time()
+		r.n = 0
+	}
+	r.in, r.out = in, out
+}
+
+// Ratio returns the current ratio of output rate to input rate.
+func (r *Resampler) Ratio() float64 {
+	return r.out / r.in
+}
+
+// Delay returns the number of input samples the Resampler must buffer beyond
+// an output sample's time before computing it.
+func (r *Resampler) Delay() int {
+	return int(r.width())
+}
+
+// Process resamples the next chunk x of the input and returns the output
+// samples that can be computed so far. Output sample k is at input time
+// k/Ratio(), and is returned once the input is known Delay() samples past
+// it; call Flush at the end of the stream to get the remaining output.
+func (r *Resampler) Process(x []float64) []float64 {
+	r.buf = append(r.buf, x...)
+	return r.run()
+}
+
+// Flush returns the remaining output for all input passed to Process,
+// treating the input after it as zero, and resets the Resampler for a new
+// stream.
+func (r *Resampler) Flush() []float64 {
+	r.end = len(r.buf)
+	r.buf = append(r.buf, make([]float64, int(r.width())+2)...)
+	y := r.run()
+	r.Reset()
+	return y
+}
+
+// Reset clears the Resampler's history, as at creation.
+func (r *Resampler) Reset() {
+	r.buf = r.buf[:0]
+	r.t0 = 0
+	r.n = 0
+	r.end = -1
+}
+
+// scale returns the kernel frequency scale: the cutoff, reduced by the ratio
+// when downsampling.
+func (r *Resampler) scale() float64 {
+	return r.cutoff * math.Min(1, r.Ratio())
+}
+
+// width returns the kernel half-width in input samples.
+func (r *Resampler) width() float64 {
+	return float64(r.k.zeros) / r.scale()
+}
+
+func (r *Resampler) run() []float64 {
+	var y []float64
+	scale, width := r.scale(), r.width()
+	t := r.time()
+	for ; r.end < 0 || t < float64(r.end); t = r.time() {
+		hi := int(math.Floor(t + width))
+		if hi >= len(r.buf) {
+			break
+		}
+		lo := int(math.Ceil(t - width))
+		if lo < 0 {
+			lo = 0
+		}
+
+		var sum float64
+		for i := lo; i <= hi; i++ {
+			sum += r.buf[i] * r.k.at((t-float64(i))*scale)
+		}
+		y = append(y, sum*scale)
+		r.n++
+	}
+
+	// Discard input no longer needed by any future output.
+	if drop := int(t-width) - 1; drop > 0 && r.end < 0 {
+		if drop > len(r.buf) {
+			drop = len(r.buf)
+		}
+		r.buf = append(r.buf[:0], r.buf[drop:]...)
+		r.t0 -= float64(drop)
+	}
+
+	return y
+}
+
+// time returns the time of the next output sample as an index into buf. It
+// is computed from the output count, rather than accumulated, so it does not
+// drift, and is exact for integer rates.
+func (r *Resampler) time() float64 {
+	return r.t0 + float64(r.n)*r.in/r.out
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestResampler(t *testing.T) {
+	const f = 0.02
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * f * float64(i))
+	}
+
+	// A ratio of 1 is the identity.
+	r := NewResampler(1)
+	y := append(r.Process(x), r.Flush()...)
+	if !dsputils.PrettyClose(y, x) {
+		t.Error("Resampler identity error\noutput:", y)
+	}
+
+	for _, ratio := range []float64{1.5, math.Sqrt2, 0.7, 1 / math.Pi} {
+		r := NewResampler(ratio)
+		y := append(r.Process(x), r.Flush()...)
+		if n := int(math.Ceil(float64(len(x)) * ratio)); len(y) < n-1 || len(y) > n+1 {
+			t.Error("Resampler length error:", ratio, len(y), n)
+		}
+		for i := 100; i < len(y)-100; i++ {
+			e := math.Sin(2 * math.Pi * f / ratio * float64(i))
+			if math.Abs(y[i]-e) > 1e-3 {
+				t.Fatal("Resampler error at", ratio, i, ":", y[i], e)
+			}
+		}
+
+		// Processing in chunks gives the same result.
+		r = NewResampler(ratio)
+		var yc []float64
+		for i := 0; i < len(x); i += 37 {
+			end := i + 37
+			if end > len(x) {
+				end = len(x)
+			}
+			yc = append(yc, r.Process(x[i:end])...)
+		}
+		yc = append(yc, r.Flush()...)
+		if !dsputils.PrettyClose(yc, y) {
+			t.Error("Resampler chunk error:", ratio, len(yc), len(y))
+		}
+	}
+}
+
+func TestResamplerSetRatio(t *testing.T) {
+	// A slowly drifting ratio still reproduces a low frequency sinusoid.
+	const f = 0.01
+	r := NewResampler(1)
+	var in, t0 float64
+	var out int
+	for i := 0; i < 50; i++ {
+		x := make([]float64, 100)
+		for j := range x {
+			x[j] = math.Sin(2 * math.Pi * f * (in + float64(j)))
+		}
+		in += 100
+
+		for _, v := range r.Process(x) {
+			e := math.Sin(2 * math.Pi * f * t0)
+			if out > 200 && math.Abs(v-e) > 1e-3 {
+				t.Fatal("Resampler drift error at", out, ":", v, e)
+			}
+			t0 += 1 / r.Ratio()
+			out++
+		}
+		r.SetRatio(1 + 0.001*float64(i))
+	}
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"io"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// Reader is a dsputils.SampleReader that filters the samples of another
+// with a Processor.
+type Reader struct {
+	src  dsputils.SampleReader
+	p    Processor
+	in   []float64
+	out  []float64
+	done bool
+}
+
+// NewReader returns a Reader filtering src with p. If p has a Flush method,
+// such as a Resampler, its output is appended at the end of src.
+func NewReader(src dsputils.SampleReader, p Processor) *Reader {
+	return &Reader{src: src, p: p, in: make([]float64, 4096)}
+}
+
+// ReadFloat64s implements dsputils.SampleReader.
+func (r *Reader) ReadFloat64s(p []float64) (int, error) {
+	for len(r.out) == 0 {
+		if r.done {
+			return 0, io.EOF
+		}
+		n, err := r.src.ReadFloat64s(r.in)
+		if n > 0 {
+			r.out = append(r.out, r.p.Process(r.in[:n])...)
+		}
+		if err == io.EOF {
+			if f, ok := r.p.(flusher); ok {
+				r.out = append(r.out, f.Flush()...)
+			}
+			r.done = true
+		} else if err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+type flusher interface {
+	Flush() []float64
+}
+
+// Writer is a dsputils.SampleWriter that filters samples with a Processor
+// before writing them to another.
+type Writer struct {
+	dst dsputils.SampleWriter
+	p   Processor
+}
+
+// NewWriter returns a Writer filtering samples with p and writing them to
+// dst.
+func NewWriter(dst dsputils.SampleWriter, p Processor) *Writer {
+	return &Writer{dst: dst, p: p}
+}
+
+// WriteFloat64s implements dsputils.SampleWriter. The filtered samples need
+// not be the same number as p, such as when resampling; n counts the samples
+// of p consumed.
+func (w *Writer) WriteFloat64s(p []float64) (int, error) {
+	y := w.p.Process(p)
+	if _, err := w.dst.WriteFloat64s(y); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close writes any output remaining in a Processor with a Flush method.
+func (w *Writer) Close() error {
+	f, ok := w.p.(flusher)
+	if !ok {
+		return nil
+	}
+	_, err := w.dst.WriteFloat64s(f.Flush())
+	return err
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestReader(t *testing.T) {
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = float64(i%37) - 18
+	}
+	b := []float64{0.2, 0.3, 0.5}
+	a := []float64{1, -0.4}
+	e := Lfilter(b, a, x)
+	o, err := dsputils.ReadAll(NewReader(dsputils.NewSliceReader(x), NewStream(b, a)))
+	if err != nil || !dsputils.PrettyClose(o, e) {
+		t.Error("Reader error:", err)
+	}
+
+	// Flushed processors give their remaining output.
+	r := NewResampler(1.5)
+	e = append(r.Process(x), r.Flush()...)
+	o, err = dsputils.ReadAll(NewReader(dsputils.NewSliceReader(x), NewResampler(1.5)))
+	if err != nil || !closeTo(o, e, 1e-6) {
+		t.Error("Reader flush error:", err)
+	}
+}
+
+// closeTo reports whether a and b have the same length and differ by at
+// most tol. The Resampler's timing is rounded differently in chunks, so
+// its outputs are not equal to PrettyClose's precision.
+func closeTo(a, b []float64, tol float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if math.Abs(a[i]-b[i]) > tol {
+			return false
+		}
+	}
+	return true
+}
+
+func TestWriter(t *testing.T) {
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = float64(i%37) - 18
+	}
+	r := NewResampler(0.5)
+	e := append(r.Process(x), r.Flush()...)
+
+	var sw dsputils.SliceWriter
+	w := NewWriter(&sw, NewResampler(0.5))
+	if _, err := dsputils.Copy(w, dsputils.NewSliceReader(x)); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !closeTo(sw.Samples, e, 1e-6) {
+		t.Error("Writer error")
+	}
+}
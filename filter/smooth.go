@@ -0,0 +1,163 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+// MovingAverage returns the causal moving average of x over n samples:
+// y[i] is the mean of x[i-n+1] through x[i], with samples before the start of
+// x taken as zero. It is equivalent to Lfilter with n coefficients of 1/n,
+// but uses a cumulative sum so its cost does not depend on n.
+func MovingAverage(x []float64, n int) []float64 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+
+	y := make([]float64, len(x))
+	var sum float64
+	for i, v := range x {
+		sum += v
+		if i >= n {
+			sum -= x[i-n]
+		}
+		y[i] = sum / float64(n)
+	}
+	return y
+}
+
+// Averager is a streaming moving average filter over a fixed number of
+// samples. Its state starts at zero, so its output matches MovingAverage.
+type Averager struct {
+	buf []float64
+	pos int
+	sum float64
+}
+
+// NewAverager returns an Averager over n samples.
+func NewAverager(n int) *Averager {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	return &Averager{buf: make([]float64, n)}
+}
+
+// Next filters the next input sample v and returns the output sample.
+func (m *Averager) Next(v float64) float64 {
+	m.sum += v - m.buf[m.pos]
+	m.buf[m.pos] = v
+	m.pos++
+	if m.pos == len(m.buf) {
+		// Recompute the sum once per window to bound rounding drift.
+		m.pos = 0
+		m.sum = 0
+		for _, b := range m.buf {
+			m.sum += b
+		}
+	}
+	return m.sum / float64(len(m.buf))
+}
+
+// Process filters the next chunk x of the signal and returns the output.
+func (m *Averager) Process(x []float64) []float64 {
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = m.Next(v)
+	}
+	return y
+}
+
+// Reset clears the Averager's history, as at creation.
+func (m *Averager) Reset() {
+	for i := range m.buf {
+		m.buf[i] = 0
+	}
+	m.pos = 0
+	m.sum = 0
+}
+
+// ExpSmoother is a streaming exponential smoothing filter. Single
+// exponential smoothing tracks the level of a signal; double (Holt)
+// exponential smoothing also tracks its trend, so it lags less behind
+// ramps. The state is initialized from the first input sample.
+// Reference: https://en.wikipedia.org/wiki/Exponential_smoothing
+type ExpSmoother struct {
+	alpha, beta  float64
+	double       bool
+	level, trend float64
+	started      bool
+}
+
+// NewExpSmoother returns a single exponential smoother with smoothing factor
+// alpha in (0, 1]. Each output is alpha times the input plus 1-alpha times
+// the previous output.
+func NewExpSmoother(alpha float64) *ExpSmoother {
+	if alpha <= 0 || alpha > 1 {
+		panic("alpha must be in (0, 1]")
+	}
+	return &ExpSmoother{alpha: alpha}
+}
+
+// NewHoltSmoother returns a double exponential smoother with level
+// smoothing factor alpha and trend smoothing factor beta, both in (0, 1].
+func NewHoltSmoother(alpha, beta float64) *ExpSmoother {
+	if beta <= 0 || beta > 1 {
+		panic("beta must be in (0, 1]")
+	}
+	s := NewExpSmoother(alpha)
+	s.beta = beta
+	s.double = true
+	return s
+}
+
+// Next filters the next input sample v and returns the smoothed level.
+func (s *ExpSmoother) Next(v float64) float64 {
+	if !s.started {
+		s.level = v
+		s.trend = 0
+		s.started = true
+		return v
+	}
+
+	prev := s.level
+	if s.double {
+		s.level = s.alpha*v + (1-s.alpha)*(prev+s.trend)
+		s.trend = s.beta*(s.level-prev) + (1-s.beta)*s.trend
+	} else {
+		s.level = s.alpha*v + (1-s.alpha)*prev
+	}
+	return s.level
+}
+
+// Process filters the next chunk x of the signal and returns the output.
+func (s *ExpSmoother) Process(x []float64) []float64 {
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = s.Next(v)
+	}
+	return y
+}
+
+// Trend returns the current trend estimate, in units per sample, of a
+// double exponential smoother. It is always zero for a single smoother.
+func (s *ExpSmoother) Trend() float64 {
+	return s.trend
+}
+
+// Reset clears the smoother's state, as at creation.
+func (s *ExpSmoother) Reset() {
+	s.level = 0
+	s.trend = 0
+	s.started = false
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestMovingAverage(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6}
+	e := []float64{1.0 / 3, 1, 2, 3, 4, 5}
+	o := MovingAverage(x, 3)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("MovingAverage error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+
+	x = make([]float64, 500)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	for _, n := range []int{1, 4, 17} {
+		h := make([]float64, n)
+		for i := range h {
+			h[i] = 1 / float64(n)
+		}
+		e := Lfilter(h, []float64{1}, x)
+		if o := MovingAverage(x, n); !dsputils.PrettyClose(o, e) {
+			t.Error("MovingAverage error\nn:", n, "\noutput:", o, "\nexpected:", e)
+		}
+
+		m := NewAverager(n)
+		o := append(m.Process(x[:123]), m.Process(x[123:])...)
+		if !dsputils.PrettyClose(o, e) {
+			t.Error("Averager error\nn:", n, "\noutput:", o, "\nexpected:", e)
+		}
+		m.Reset()
+		if o := m.Process(x); !dsputils.PrettyClose(o, e) {
+			t.Error("Averager reset error\nn:", n, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+}
+
+func TestExpSmoother(t *testing.T) {
+	x := []float64{4, 0, 8, 8}
+	e := []float64{4, 2, 5, 6.5}
+	s := NewExpSmoother(0.5)
+	if o := s.Process(x); !dsputils.PrettyClose(o, e) {
+		t.Error("ExpSmoother error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+	if s.Trend() != 0 {
+		t.Error("ExpSmoother trend error:", s.Trend())
+	}
+	s.Reset()
+	if o := s.Process(x); !dsputils.PrettyClose(o, e) {
+		t.Error("ExpSmoother reset error\noutput:", o, "\nexpected:", e)
+	}
+
+	// Holt smoothing tracks a ramp without lag in steady state.
+	ramp := make([]float64, 200)
+	for i := range ramp {
+		ramp[i] = 3 + 0.5*float64(i)
+	}
+	h := NewHoltSmoother(0.3, 0.2)
+	o := h.Process(ramp)
+	if last := len(o) - 1; math.Abs(o[last]-ramp[last]) > 1e-6 || math.Abs(h.Trend()-0.5) > 1e-6 {
+		t.Error("HoltSmoother error\noutput:", o[last], h.Trend(), "\nexpected:", ramp[last], 0.5)
+	}
+}
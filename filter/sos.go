@@ -0,0 +1,278 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+)
+
+// Second-order sections (SOS) represent a filter as a cascade of biquads.
+// Each section is {b0, b1, b2, a0, a1, a2}, the numerator and denominator
+// coefficients of one second-order transfer function. High order IIR filters
+// should be implemented in SOS form, since the transfer function form is
+// numerically unstable.
+
+// ButterSos is like Butter, but returns second-order sections.
+func ButterSos(n int, wn []float64, btype BandType, fs float64) [][6]float64 {
+	z, p, k := Buttap(n)
+	return Zpk2Sos(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// Cheby1Sos is like Cheby1, but returns second-order sections.
+func Cheby1Sos(n int, rp float64, wn []float64, btype BandType, fs float64) [][6]float64 {
+	z, p, k := Cheb1ap(n, rp)
+	return Zpk2Sos(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// Cheby2Sos is like Cheby2, but returns second-order sections.
+func Cheby2Sos(n int, rs float64, wn []float64, btype BandType, fs float64) [][6]float64 {
+	z, p, k := Cheb2ap(n, rs)
+	return Zpk2Sos(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// EllipSos is like Ellip, but returns second-order sections.
+func EllipSos(n int, rp, rs float64, wn []float64, btype BandType, fs float64) [][6]float64 {
+	z, p, k := Ellipap(n, rp, rs)
+	return Zpk2Sos(iirDesign(z, p, k, wn, btype, fs))
+}
+
+// SosFilt filters x with the cascade of second-order sections sos, using the
+// direct form II transposed structure for each section, with zero initial
+// state.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.sosfilt.html
+func SosFilt(sos [][6]float64, x []float64) []float64 {
+	y, _ := SosFiltState(sos, x, nil)
+	return y
+}
+
+// SosFiltState is like SosFilt, but starts from the section delay values zi
+// and also returns the final delay values zf. A nil zi is all zeros. Passing
+// zf as zi to the next call filters a signal in consecutive chunks.
+func SosFiltState(sos [][6]float64, x []float64, zi [][2]float64) (y []float64, zf [][2]float64) {
+	zf = make([][2]float64, len(sos))
+	if zi != nil {
+		if len(zi) != len(sos) {
+			panic("incorrect zi length")
+		}
+		copy(zf, zi)
+	}
+
+	y = make([]float64, len(x))
+	copy(y, x)
+
+	for s, c := range sos {
+		if c[3] == 0 {
+			panic("a0 must be nonzero")
+		}
+		b0, b1, b2 := c[0]/c[3], c[1]/c[3], c[2]/c[3]
+		a1, a2 := c[4]/c[3], c[5]/c[3]
+		z0, z1 := zf[s][0], zf[s][1]
+
+		for i, xi := range y {
+			yi := b0*xi + z0
+			z0 = b1*xi + z1 - a1*yi
+			z1 = b2*xi - a2*yi
+			y[i] = yi
+		}
+
+		zf[s][0], zf[s][1] = z0, z1
+	}
+
+	return y, zf
+}
+
+// SosFiltZi returns the section delay values for SosFiltState corresponding
+// to the steady state of the step response. Scaling it by the first input
+// sample gives initial conditions that avoid a startup transient.
+func SosFiltZi(sos [][6]float64) [][2]float64 {
+	zi := make([][2]float64, len(sos))
+	scale := 1.0
+	for s, c := range sos {
+		b := []float64{c[0] / c[3], c[1] / c[3], c[2] / c[3]}
+		a := []float64{1, c[4] / c[3], c[5] / c[3]}
+
+		// The steady state output of each section is its DC gain g times
+		// its input; the states follow from the difference equations.
+		g := (b[0] + b[1] + b[2]) / (a[0] + a[1] + a[2])
+		zi[s][1] = scale * (b[2] - a[2]*g)
+		zi[s][0] = scale * (b[1] + b[2] - (a[1]+a[2])*g)
+		scale *= g
+	}
+	return zi
+}
+
+// SosFiltFilt applies the second-order sections sos to x forward and
+// backward, giving a zero-phase result with squared magnitude response. The
+// edges of x are extended by odd reflection and the filter states are
+// initialized to their steady state to reduce transients.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.sosfiltfilt.html
+func SosFiltFilt(sos [][6]float64, x []float64) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	ntaps := 2*len(sos) + 1
+	var zb, za int
+	for _, c := range sos {
+		if c[2] == 0 {
+			zb++
+		}
+		if c[5] == 0 {
+			za++
+		}
+	}
+	if zb < za {
+		ntaps -= zb
+	} else {
+		ntaps -= za
+	}
+	padlen := 3 * ntaps
+	if padlen > len(x)-1 {
+		padlen = len(x) - 1
+	}
+
+	ext := oddExtend(x, padlen)
+	zi := SosFiltZi(sos)
+
+	scaled := func(v float64) [][2]float64 {
+		r := make([][2]float64, len(zi))
+		for i, z := range zi {
+			r[i] = [2]float64{z[0] * v, z[1] * v}
+		}
+		return r
+	}
+
+	y, _ := SosFiltState(sos, ext, scaled(ext[0]))
+	reverse(y)
+	y, _ = SosFiltState(sos, y, scaled(y[0]))
+	reverse(y)
+
+	return y[padlen : len(y)-padlen]
+}
+
+// oddExtend returns x extended by n points at each end with an odd (point
+// symmetric) reflection about its end points.
+func oddExtend(x []float64, n int) []float64 {
+	r := make([]float64, len(x)+2*n)
+	copy(r[n:], x)
+	last := len(x) - 1
+	for i := 1; i <= n; i++ {
+		r[n-i] = 2*x[0] - x[i]
+		r[n+last+i] = 2*x[last] - x[last-i]
+	}
+	return r
+}
+
+// reverse reverses x in place.
+func reverse(x []float64) {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+}
+
+// rootGroups splits the roots x into groups of at most two: complex
+// conjugate pairs and pairs of real roots. Each group is represented by the
+// root with non-negative imaginary part. Groups are ordered so those closest
+// to the unit circle are first.
+func rootGroups(x []complex128) [][]complex128 {
+	const tol = 1e-10
+
+	var reals []float64
+	var groups [][]complex128
+	for _, v := range x {
+		if math.Abs(imag(v)) <= tol*math.Max(1, cmplx.Abs(v)) {
+			reals = append(reals, real(v))
+		} else if imag(v) > 0 {
+			groups = append(groups, []complex128{v, complex(real(v), -imag(v))})
+		}
+	}
+
+	// Pair real roots with similar distance to the unit circle.
+	sort.Slice(reals, func(i, j int) bool {
+		return math.Abs(1-math.Abs(reals[i])) < math.Abs(1-math.Abs(reals[j]))
+	})
+	for i := 0; i < len(reals); i += 2 {
+		g := []complex128{complex(reals[i], 0)}
+		if i+1 < len(reals) {
+			g = append(g, complex(reals[i+1], 0))
+		}
+		groups = append(groups, g)
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		return math.Abs(1-cmplx.Abs(groups[i][0])) < math.Abs(1-cmplx.Abs(groups[j][0]))
+	})
+	return groups
+}
+
+// Zpk2Sos returns the second-order sections of the filter with zeros z,
+// poles p, and gain k. Poles closest to the unit circle are placed in the
+// last sections, each paired with the nearest remaining zeros.
+func Zpk2Sos(z, p []complex128, k float64) [][6]float64 {
+	pg := rootGroups(p)
+	zg := rootGroups(z)
+
+	n := len(pg)
+	if len(zg) > n {
+		n = len(zg)
+	}
+	if n == 0 {
+		return [][6]float64{{k, 0, 0, 1, 0, 0}}
+	}
+
+	sos := make([][6]float64, n)
+	for i := 0; i < n; i++ {
+		var poles, zeros []complex128
+		if i < len(pg) {
+			poles = pg[i]
+		}
+
+		// Choose the zero group nearest the poles.
+		if len(zg) > 0 {
+			best := 0
+			if len(poles) > 0 {
+				for j := range zg {
+					if cmplx.Abs(zg[j][0]-poles[0]) < cmplx.Abs(zg[best][0]-poles[0]) {
+						best = j
+					}
+				}
+			}
+			zeros = zg[best]
+			zg = append(zg[:best], zg[best+1:]...)
+		}
+
+		b := poly(zeros)
+		a := poly(poles)
+		s := n - 1 - i
+		for j := 0; j < 3; j++ {
+			if j < len(b) {
+				sos[s][j] = real(b[j])
+			}
+			if j < len(a) {
+				sos[s][3+j] = real(a[j])
+			}
+		}
+	}
+
+	for j := 0; j < 3; j++ {
+		sos[0][j] *= k
+	}
+
+	return sos
+}
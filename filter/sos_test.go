@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSosFilt(t *testing.T) {
+	const fs = 1000
+	x := make([]float64, 200)
+	for i := range x {
+		x[i] = math.Sin(float64(i)*0.3) + math.Cos(float64(i*i)*0.01)
+	}
+
+	b, a := Cheby1(6, 1, []float64{100, 250}, Bandpass, fs)
+	sos := Cheby1Sos(6, 1, []float64{100, 250}, Bandpass, fs)
+	if len(sos) != 6 {
+		t.Fatal("section count error:", len(sos))
+	}
+
+	// For moderate orders the transfer function and SOS forms agree.
+	y := Lfilter(b, a, x)
+	ys := SosFilt(sos, x)
+	for i := range y {
+		if math.Abs(y[i]-ys[i]) > 1e-8 {
+			t.Fatal("SosFilt error at", i, ":", ys[i], y[i])
+		}
+	}
+
+	b, a = Cheby2(5, 40, []float64{100}, Highpass, fs)
+	sos = Cheby2Sos(5, 40, []float64{100}, Highpass, fs)
+	if len(sos) != 3 {
+		t.Fatal("section count error:", len(sos))
+	}
+	y = Lfilter(b, a, x)
+	ys = SosFilt(sos, x)
+	for i := range y {
+		if math.Abs(y[i]-ys[i]) > 1e-8 {
+			t.Fatal("SosFilt error at", i, ":", ys[i], y[i])
+		}
+	}
+}
+
+func TestSosFiltHighOrder(t *testing.T) {
+	// A narrow, high order bandpass is unstable in transfer function form
+	// but fine in SOS form.
+	sos := Cheby1Sos(12, 0.5, []float64{0.10, 0.11}, Bandpass, 1)
+	for _, v := range []struct{ f, min, max float64 }{
+		{0.105, 0.94, 1.001},
+		{0.2, 0, 1e-6},
+	} {
+		x := make([]float64, 20000)
+		for i := range x {
+			x[i] = math.Sin(2 * math.Pi * v.f * float64(i))
+		}
+		y := SosFilt(sos, x)
+		var peak float64
+		for _, yi := range y[len(y)-1000:] {
+			peak = math.Max(peak, math.Abs(yi))
+		}
+		if math.IsNaN(peak) || peak < v.min || peak > v.max {
+			t.Error("SosFilt high order error at", v.f, ":", peak)
+		}
+	}
+}
+
+func TestSosFiltFilt(t *testing.T) {
+	sos := Cheby2Sos(4, 40, []float64{0.1}, Lowpass, 1)
+
+	// A constant input is passed unchanged.
+	x := make([]float64, 50)
+	for i := range x {
+		x[i] = 3
+	}
+	y := SosFiltFilt(sos, x)
+	for i := range y {
+		if math.Abs(y[i]-3) > 1e-6 {
+			t.Fatal("SosFiltFilt constant error at", i, ":", y[i])
+		}
+	}
+
+	// A sinusoid in the passband is passed without phase shift.
+	x = make([]float64, 400)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 0.01 * float64(i))
+	}
+	y = SosFiltFilt(sos, x)
+	for i := 50; i < 350; i++ {
+		if math.Abs(y[i]-x[i]) > 0.01 {
+			t.Fatal("SosFiltFilt phase error at", i, ":", y[i], x[i])
+		}
+	}
+}
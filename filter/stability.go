@@ -0,0 +1,106 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// IsStable reports whether the filter with denominator coefficients a is
+// stable, that is, whether all of its poles lie strictly inside the unit
+// circle. It uses the Schur-Cohn step-down recursion, which is more reliable
+// than finding the poles for filters with poles close to the unit circle.
+// Reference: https://en.wikipedia.org/wiki/Jury_stability_criterion
+func IsStable(a []float64) bool {
+	for len(a) > 1 && a[len(a)-1] == 0 {
+		a = a[:len(a)-1]
+	}
+	if len(a) == 0 || a[0] == 0 {
+		panic("a[0] must be nonzero")
+	}
+
+	c := make([]float64, len(a))
+	for i, v := range a {
+		c[i] = v / a[0]
+	}
+
+	// Each step computes a reflection coefficient and the next lower order
+	// polynomial; the filter is stable if all have magnitude less than 1.
+	for m := len(c) - 1; m > 0; m-- {
+		k := c[m]
+		if math.Abs(k) >= 1 || math.IsNaN(k) {
+			return false
+		}
+		d := 1 - k*k
+		n := make([]float64, m)
+		for i := range n {
+			n[i] = (c[i] - k*c[m-i]) / d
+		}
+		c = n
+	}
+	return true
+}
+
+// IsStableSos reports whether all of the second-order sections sos are
+// stable.
+func IsStableSos(sos [][6]float64) bool {
+	for _, s := range sos {
+		if !IsStable(s[3:]) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsMinimumPhase reports whether the filter with transfer function
+// coefficients b and a is stable and has all of its zeros inside the unit
+// circle, so that it has a stable inverse.
+func IsMinimumPhase(b, a []float64) bool {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+	return len(b) > 0 && IsStable(a) && IsStable(b)
+}
+
+// Poles returns the poles of the filter with denominator coefficients a:
+// the roots of a[0]*z^n + a[1]*z^(n-1) + ... + a[n].
+func Poles(a []float64) []complex128 {
+	return dsputils.Roots(a)
+}
+
+// Zeros returns the zeros of the filter with numerator coefficients b, as
+// Poles does for the denominator. Use Tf2Zpk to also get the gain and the
+// poles or zeros at the origin from unequal lengths of b and a.
+func Zeros(b []float64) []complex128 {
+	return dsputils.Roots(b)
+}
+
+// MaxPoleRadius returns the largest pole magnitude of the filter with
+// denominator coefficients a. The filter is stable if it is less than 1, and
+// its impulse response decays by about this factor each sample.
+func MaxPoleRadius(a []float64) float64 {
+	var r float64
+	for _, p := range Poles(a) {
+		if m := cmplx.Abs(p); m > r {
+			r = m
+		}
+	}
+	return r
+}
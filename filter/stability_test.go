@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+type stabilityTest struct {
+	a      []float64
+	stable bool
+}
+
+var stabilityTests = []stabilityTest{
+	{[]float64{1}, true},
+	{[]float64{1, -0.5}, true},
+	{[]float64{1, -1}, false},
+	{[]float64{2, -3}, false},
+	{[]float64{1, -1.8, 0.81}, true},         // double pole at 0.9
+	{[]float64{1, -2.1, 1.1}, false},         // poles at 1 and 1.1
+	{[]float64{1, 0, 0.99}, true},            // poles at ±0.995i
+	{[]float64{1, 0, 1.01}, false},           // poles outside the unit circle
+	{[]float64{1, -0.5, 0, 0, 0}, true},      // trailing zeros
+	{[]float64{1, 0.7, 0.25, -0.425}, true},  // poles at 0.5 and -0.6±0.7i
+	{[]float64{1, 0.9, 0.43, -0.565}, false}, // poles at 0.5 and -0.7±0.8i
+}
+
+func TestIsStable(t *testing.T) {
+	for _, st := range stabilityTests {
+		if o := IsStable(st.a); o != st.stable {
+			t.Error("IsStable error\ninput:", st.a, "\noutput:", o, "\nexpected:", st.stable)
+		}
+		if o := MaxPoleRadius(st.a) < 1-1e-9; o != st.stable {
+			t.Error("MaxPoleRadius error\ninput:", st.a, "\noutput:", MaxPoleRadius(st.a))
+		}
+	}
+
+	sos := Cheby1Sos(10, 1, []float64{0.1}, Lowpass, 1)
+	if !IsStableSos(sos) {
+		t.Error("IsStableSos error: Cheby1Sos is unstable")
+	}
+	sos = append(sos, [6]float64{1, 0, 0, 1, 0, 1.5})
+	if IsStableSos(sos) {
+		t.Error("IsStableSos error: expected unstable")
+	}
+
+	if !IsMinimumPhase([]float64{1, -0.5}, []float64{1, 0.3}) {
+		t.Error("IsMinimumPhase error: expected minimum phase")
+	}
+	if IsMinimumPhase([]float64{1, -2}, []float64{1, 0.3}) {
+		t.Error("IsMinimumPhase error: expected non-minimum phase")
+	}
+}
+
+func TestPoles(t *testing.T) {
+	p := Poles([]float64{1, -1.5, 0.56})
+	p = sortRoots(p)
+	e := []complex128{0.7, 0.8}
+	for i := range e {
+		if cmplx.Abs(p[i]-e[i]) > 1e-9 {
+			t.Error("Poles error\noutput:", p, "\nexpected:", e)
+			break
+		}
+	}
+
+	z := Zeros([]float64{1, 0, 4})
+	for _, v := range z {
+		if math.Abs(cmplx.Abs(v)-2) > 1e-9 || math.Abs(real(v)) > 1e-9 {
+			t.Error("Zeros error\noutput:", z)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+// Stream filters a signal in consecutive chunks, carrying the filter's delay
+// values from one chunk to the next, so the output is the same as filtering
+// the whole signal at once. It is suitable for real-time, block-by-block
+// processing.
+type Stream struct {
+	b, a []float64
+	z    []float64
+
+	sos  [][6]float64
+	zsos [][2]float64
+}
+
+// NewStream returns a Stream for the filter with transfer function
+// coefficients b and a. An FIR filter has a = []float64{1}.
+func NewStream(b, a []float64) *Stream {
+	b, a = normalize(b, a)
+	return &Stream{
+		b: b,
+		a: a,
+		z: make([]float64, len(a)-1),
+	}
+}
+
+// NewSosStream returns a Stream for the second-order sections sos.
+func NewSosStream(sos [][6]float64) *Stream {
+	s := make([][6]float64, len(sos))
+	copy(s, sos)
+	return &Stream{
+		sos:  s,
+		zsos: make([][2]float64, len(sos)),
+	}
+}
+
+// Process filters the next chunk x of the signal and returns the output.
+func (s *Stream) Process(x []float64) []float64 {
+	y := make([]float64, len(x))
+	copy(y, x)
+	s.ProcessInPlace(y)
+	return y
+}
+
+// ProcessInPlace filters the next chunk x of the signal, replacing it with
+// the output. It does not allocate.
+func (s *Stream) ProcessInPlace(x []float64) {
+	if s.sos != nil {
+		for i, c := range s.sos {
+			if c[3] == 0 {
+				panic("a0 must be nonzero")
+			}
+			b0, b1, b2 := c[0]/c[3], c[1]/c[3], c[2]/c[3]
+			a1, a2 := c[4]/c[3], c[5]/c[3]
+			z0, z1 := s.zsos[i][0], s.zsos[i][1]
+			for j, xi := range x {
+				yi := b0*xi + z0
+				z0 = b1*xi + z1 - a1*yi
+				z1 = b2*xi - a2*yi
+				x[j] = yi
+			}
+			s.zsos[i][0], s.zsos[i][1] = z0, z1
+		}
+		return
+	}
+
+	for i, xi := range x {
+		x[i] = s.next(xi)
+	}
+}
+
+// Next filters the next sample x of the signal and returns the output sample.
+func (s *Stream) Next(x float64) float64 {
+	if s.sos != nil {
+		v := [1]float64{x}
+		s.ProcessInPlace(v[:])
+		return v[0]
+	}
+	return s.next(x)
+}
+
+// next is Next for a transfer function Stream, in direct form II transposed.
+func (s *Stream) next(x float64) float64 {
+	b, a, z := s.b, s.a, s.z
+	n := len(a)
+	y := b[0] * x
+	if n == 1 {
+		return y
+	}
+	y += z[0]
+	for j := 1; j < n-1; j++ {
+		z[j-1] = b[j]*x + z[j] - a[j]*y
+	}
+	z[n-2] = b[n-1]*x - a[n-1]*y
+	return y
+}
+
+// Reset clears the filter's delay values, as at creation.
+func (s *Stream) Reset() {
+	for i := range s.z {
+		s.z[i] = 0
+	}
+	for i := range s.zsos {
+		s.zsos[i] = [2]float64{}
+	}
+}
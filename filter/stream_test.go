@@ -0,0 +1,84 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestStream(t *testing.T) {
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = float64(i%11) - 5
+	}
+
+	b, a := Cheby1(4, 1, []float64{0.2}, Lowpass, 1)
+	sos := Cheby1Sos(4, 1, []float64{0.2}, Lowpass, 1)
+	h := []float64{0.1, 0.2, 0.4, 0.2, 0.1}
+
+	streams := []struct {
+		name string
+		s    *Stream
+		y    []float64
+	}{
+		{"iir", NewStream(b, a), Lfilter(b, a, x)},
+		{"sos", NewSosStream(sos), SosFilt(sos, x)},
+		{"fir", NewStream(h, []float64{1}), Lfilter(h, []float64{1}, x)},
+	}
+
+	for _, v := range streams {
+		for pass := 0; pass < 2; pass++ {
+			var y []float64
+			for _, n := range []int{1, 7, 0, 40, 52} {
+				y = append(y, v.s.Process(x[len(y):len(y)+n])...)
+			}
+			if !dsputils.PrettyClose(y, v.y) {
+				t.Error(v.name, "Stream error\noutput:", y, "\nexpected:", v.y)
+			}
+			v.s.Reset()
+		}
+	}
+}
+
+func TestStreamNext(t *testing.T) {
+	x := make([]float64, 50)
+	for i := range x {
+		x[i] = float64(i%7) - 3
+	}
+	b, a := Butter(3, []float64{0.1}, Lowpass, 1)
+	sos := ButterSos(3, []float64{0.1}, Lowpass, 1)
+
+	for _, s := range []*Stream{NewStream(b, a), NewSosStream(sos), NewStream([]float64{2}, []float64{1})} {
+		e := s.Process(x)
+		s.Reset()
+		y := make([]float64, len(x))
+		for i, v := range x {
+			y[i] = s.Next(v)
+		}
+		if !dsputils.PrettyClose(y, e) {
+			t.Error("Stream Next error\noutput:", y, "\nexpected:", e)
+		}
+
+		s.Reset()
+		y = append(y[:0], x...)
+		if a := testing.AllocsPerRun(5, func() { s.ProcessInPlace(y) }); a != 0 {
+			t.Error("Stream ProcessInPlace allocations:", a)
+		}
+	}
+}
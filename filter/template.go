@@ -0,0 +1,107 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// MatchTemplate returns the normalized cross-correlation of the template
+// tmpl with each placement of it entirely within img, and the row and
+// column of the best match. ncc[u][v], for the template's top left corner at
+// img[u][v], is the correlation coefficient of the template and the image
+// under it, in [-1, 1], so it is unaffected by the brightness and contrast
+// of either; it is 0 where the image is flat. The correlations are computed
+// with FFT2, and the local image statistics with summed-area tables.
+// Reference: Lewis, "Fast Normalized Cross-Correlation", Vision Interface,
+// 1995.
+func MatchTemplate(img, tmpl [][]float64) (ncc [][]float64, row, col int) {
+	R, C := size2(img)
+	r, c := size2(tmpl)
+	if r > R || c > C {
+		panic("template larger than image")
+	}
+	n := float64(r * c)
+
+	// Zero mean template, flipped for convolution.
+	var mean float64
+	for _, t := range tmpl {
+		for _, v := range t {
+			mean += v
+		}
+	}
+	mean /= n
+	var tvar float64
+	t := make([][]float64, r)
+	for i := range t {
+		t[i] = make([]float64, c)
+		for j := range t[i] {
+			v := tmpl[r-1-i][c-1-j] - mean
+			t[i][j] = v
+			tvar += v * v
+		}
+	}
+
+	fr, fc := dsputils.NextPowerOf2(R), dsputils.NextPowerOf2(C)
+	X := fft.FFT2Real(pad2(img, fr, fc))
+	T := fft.FFT2Real(pad2(t, fr, fc))
+	for i := range X {
+		for j := range X[i] {
+			X[i][j] *= T[i][j]
+		}
+	}
+	corr := fft.IFFT2(X)
+
+	// Summed-area tables of img and its square, with a leading zero row and
+	// column.
+	s1 := make([][]float64, R+1)
+	s2 := make([][]float64, R+1)
+	s1[0], s2[0] = make([]float64, C+1), make([]float64, C+1)
+	for i := 0; i < R; i++ {
+		s1[i+1], s2[i+1] = make([]float64, C+1), make([]float64, C+1)
+		for j := 0; j < C; j++ {
+			v := img[i][j]
+			s1[i+1][j+1] = v + s1[i][j+1] + s1[i+1][j] - s1[i][j]
+			s2[i+1][j+1] = v*v + s2[i][j+1] + s2[i+1][j] - s2[i][j]
+		}
+	}
+	box := func(s [][]float64, u, v int) float64 {
+		return s[u+r][v+c] - s[u][v+c] - s[u+r][v] + s[u][v]
+	}
+
+	best := math.Inf(-1)
+	ncc = make([][]float64, R-r+1)
+	for u := range ncc {
+		ncc[u] = make([]float64, C-c+1)
+		for v := range ncc[u] {
+			sum := box(s1, u, v)
+			ivar := box(s2, u, v) - sum*sum/n
+			d := math.Sqrt(ivar * tvar)
+			// Flat regions, whose variance is rounding error, don't match.
+			if ivar > 1e-12*n && d > 0 {
+				ncc[u][v] = real(corr[u+r-1][v+c-1]) / d
+			}
+			if ncc[u][v] > best {
+				best, row, col = ncc[u][v], u, v
+			}
+		}
+	}
+	return ncc, row, col
+}
@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package filter
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMatchTemplate(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	img := make([][]float64, 50)
+	for i := range img {
+		img[i] = make([]float64, 40)
+		for j := range img[i] {
+			img[i][j] = r.Float64()
+		}
+	}
+	// A flat region, which must not match.
+	for i := 30; i < 45; i++ {
+		for j := 20; j < 35; j++ {
+			img[i][j] = 0.5
+		}
+	}
+
+	// The template is a patch of the image with its brightness and contrast
+	// changed.
+	const row, col = 12, 7
+	tmpl := make([][]float64, 9)
+	for i := range tmpl {
+		tmpl[i] = make([]float64, 6)
+		for j := range tmpl[i] {
+			tmpl[i][j] = 3*img[row+i][col+j] + 10
+		}
+	}
+
+	ncc, u, v := MatchTemplate(img, tmpl)
+	if len(ncc) != 42 || len(ncc[0]) != 35 {
+		t.Fatalf("MatchTemplate: got %vx%v surface, expected 42x35", len(ncc), len(ncc[0]))
+	}
+	if u != row || v != col {
+		t.Errorf("MatchTemplate: got match at %v, %v, expected %v, %v", u, v, row, col)
+	}
+	if p := ncc[row][col]; math.Abs(p-1) > 1e-9 {
+		t.Errorf("MatchTemplate: got peak %v, expected 1", p)
+	}
+	for i := range ncc {
+		for j, p := range ncc[i] {
+			if p < -1-1e-9 || p > 1+1e-9 {
+				t.Fatalf("MatchTemplate: ncc[%v][%v] = %v out of range", i, j, p)
+			}
+			if (i != row || j != col) && p > 0.9 {
+				t.Errorf("MatchTemplate: false match %v at %v, %v", p, i, j)
+			}
+		}
+	}
+	if p := ncc[32][22]; p != 0 {
+		t.Errorf("MatchTemplate: flat region got %v, expected 0", p)
+	}
+}
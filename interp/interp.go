@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package interp provides piecewise cubic interpolation of sampled data,
+// such as resampling unevenly spaced measurements onto the uniform grid
+// needed for FFT-based analysis.
+package interp
+
+import (
+	"sort"
+)
+
+// Cubic is a piecewise cubic Hermite interpolant, defined by its values and
+// first derivatives at increasing breakpoints. Outside the breakpoints it
+// extrapolates with the first or last cubic piece.
+type Cubic struct {
+	x, y, d []float64
+}
+
+// NewCubic returns the Cubic through the points (x, y) with first
+// derivatives d. x must be strictly increasing.
+func NewCubic(x, y, d []float64) *Cubic {
+	checkPoints(x, y)
+	if len(d) != len(x) {
+		panic("x and d must have the same length")
+	}
+	return &Cubic{x, y, d}
+}
+
+func checkPoints(x, y []float64) {
+	if len(x) != len(y) {
+		panic("x and y must have the same length")
+	}
+	if len(x) < 2 {
+		panic("at least two points required")
+	}
+	for i := 1; i < len(x); i++ {
+		if !(x[i] > x[i-1]) {
+			panic("x must be strictly increasing")
+		}
+	}
+}
+
+// At returns the value of c at t.
+func (c *Cubic) At(t float64) float64 {
+	k := sort.SearchFloat64s(c.x, t) - 1
+	if k < 0 {
+		k = 0
+	} else if k > len(c.x)-2 {
+		k = len(c.x) - 2
+	}
+
+	h := c.x[k+1] - c.x[k]
+	s := (t - c.x[k]) / h
+	s2 := s * s
+	s3 := s2 * s
+	return (2*s3-3*s2+1)*c.y[k] + (s3-2*s2+s)*h*c.d[k] +
+		(-2*s3+3*s2)*c.y[k+1] + (s3-s2)*h*c.d[k+1]
+}
+
+// Eval returns the values of c at t.
+func (c *Cubic) Eval(t []float64) []float64 {
+	r := make([]float64, len(t))
+	for i, v := range t {
+		r[i] = c.At(v)
+	}
+	return r
+}
+
+// Sample returns n values of c on the uniform grid start, start+step, ...,
+// as for resampling onto a fixed sampling rate of 1/step.
+func (c *Cubic) Sample(start, step float64, n int) []float64 {
+	r := make([]float64, n)
+	for i := range r {
+		r[i] = c.At(start + float64(i)*step)
+	}
+	return r
+}
+
+// slopes returns the widths and slopes of the intervals of (x, y).
+func slopes(x, y []float64) (h, delta []float64) {
+	h = make([]float64, len(x)-1)
+	delta = make([]float64, len(x)-1)
+	for i := range h {
+		h[i] = x[i+1] - x[i]
+		delta[i] = (y[i+1] - y[i]) / h[i]
+	}
+	return
+}
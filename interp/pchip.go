@@ -0,0 +1,80 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package interp
+
+import (
+	"math"
+)
+
+// NewPCHIP returns the piecewise cubic Hermite interpolating polynomial
+// through the points (x, y), whose derivatives are chosen so that it is
+// monotone wherever the data are, and it does not overshoot. It has a
+// continuous first derivative only. x must be strictly increasing.
+// Reference: http://dx.doi.org/10.1137/0717021
+// See also: https://docs.scipy.org/doc/scipy/reference/generated/scipy.interpolate.PchipInterpolator.html
+func NewPCHIP(x, y []float64) *Cubic {
+	checkPoints(x, y)
+	n := len(x)
+	h, delta := slopes(x, y)
+	d := make([]float64, n)
+	if n == 2 {
+		d[0], d[1] = delta[0], delta[0]
+		return &Cubic{x, y, d}
+	}
+
+	// Interior derivatives are weighted harmonic means of the adjacent
+	// slopes, or zero at local extrema.
+	for k := 1; k < n-1; k++ {
+		if delta[k-1]*delta[k] <= 0 {
+			continue
+		}
+		w1 := 2*h[k] + h[k-1]
+		w2 := h[k] + 2*h[k-1]
+		d[k] = (w1 + w2) / (w1/delta[k-1] + w2/delta[k])
+	}
+	d[0] = pchipEnd(h[0], h[1], delta[0], delta[1])
+	d[n-1] = pchipEnd(h[n-2], h[n-3], delta[n-2], delta[n-3])
+	return &Cubic{x, y, d}
+}
+
+// pchipEnd returns the end derivative from a shape-preserving three-point
+// formula, where h0 and delta0 belong to the end interval.
+func pchipEnd(h0, h1, delta0, delta1 float64) float64 {
+	d := ((2*h0+h1)*delta0 - h0*delta1) / (h0 + h1)
+	if sign(d) != sign(delta0) {
+		return 0
+	}
+	if sign(delta0) != sign(delta1) && math.Abs(d) > 3*math.Abs(delta0) {
+		return 3 * delta0
+	}
+	return d
+}
+
+// PCHIP returns the PCHIP interpolant through (x, y) evaluated at xi.
+func PCHIP(x, y, xi []float64) []float64 {
+	return NewPCHIP(x, y).Eval(xi)
+}
+
+func sign(x float64) int {
+	switch {
+	case x > 0:
+		return 1
+	case x < 0:
+		return -1
+	}
+	return 0
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package interp
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestPCHIP(t *testing.T) {
+	tests := []struct {
+		x, y, xi, out []float64
+	}{
+		{
+			[]float64{0, 1, 2},
+			[]float64{0, 1, 0},
+			[]float64{0.5, 1, 1.5},
+			[]float64{0.75, 1, 0.75},
+		},
+		// A straight line is reproduced.
+		{
+			[]float64{0, 1, 3, 4},
+			[]float64{1, 3, 7, 9},
+			[]float64{0.5, 2, 3.5},
+			[]float64{2, 5, 8},
+		},
+	}
+	for _, v := range tests {
+		o := PCHIP(v.x, v.y, v.xi)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error("PCHIP error\ninput:", v.x, v.y, v.xi, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestPCHIPMonotone(t *testing.T) {
+	// A step, which makes a spline overshoot.
+	x := []float64{0, 1, 2, 3, 4, 5, 6}
+	y := []float64{0, 0, 0, 1, 1, 1, 1}
+	p := NewPCHIP(x, y).Sample(0, 0.01, 601)
+	s := NewSpline(x, y, NotAKnot).Sample(0, 0.01, 601)
+	for i := 1; i < len(p); i++ {
+		if p[i] < p[i-1] || p[i] < 0 || p[i] > 1 {
+			t.Fatalf("PCHIP not monotone at %v: %v, %v", i, p[i-1], p[i])
+		}
+	}
+	var over bool
+	for _, v := range s {
+		if v < 0 || v > 1 {
+			over = true
+		}
+	}
+	if !over {
+		t.Error("spline did not overshoot")
+	}
+}
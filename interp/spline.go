@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package interp
+
+// Boundary is the end condition of a cubic spline.
+type Boundary int
+
+const (
+	// NotAKnot makes the third derivative continuous at the second and
+	// second to last points, as MATLAB's spline and scipy's CubicSpline do
+	// by default.
+	NotAKnot Boundary = iota
+
+	// Natural makes the second derivative zero at the end points.
+	Natural
+)
+
+// NewSpline returns the cubic spline through the points (x, y), which has
+// continuous first and second derivatives, with the end condition bc. x
+// must be strictly increasing. Two points give a line; three points and
+// NotAKnot give a parabola.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.interpolate.CubicSpline.html
+func NewSpline(x, y []float64, bc Boundary) *Cubic {
+	checkPoints(x, y)
+	n := len(x)
+	h, delta := slopes(x, y)
+	d := make([]float64, n)
+
+	switch {
+	case n == 2:
+		d[0], d[1] = delta[0], delta[0]
+		return &Cubic{x, y, d}
+	case n == 3 && bc == NotAKnot:
+		// The parabola through the three points.
+		c := (delta[1] - delta[0]) / (x[2] - x[0])
+		d[0] = delta[0] - c*h[0]
+		d[1] = delta[0] + c*h[0]
+		d[2] = delta[1] + c*h[1]
+		return &Cubic{x, y, d}
+	}
+
+	// Solve the tridiagonal system for the derivatives, with sub-diagonal
+	// a, diagonal b and super-diagonal c.
+	a := make([]float64, n)
+	b := make([]float64, n)
+	c := make([]float64, n)
+	for i := 1; i < n-1; i++ {
+		a[i] = h[i]
+		b[i] = 2 * (h[i-1] + h[i])
+		c[i] = h[i-1]
+		d[i] = 3 * (h[i]*delta[i-1] + h[i-1]*delta[i])
+	}
+	switch bc {
+	case NotAKnot:
+		w := x[2] - x[0]
+		b[0], c[0] = h[1], w
+		d[0] = ((h[0]+2*w)*h[1]*delta[0] + h[0]*h[0]*delta[1]) / w
+		w = x[n-1] - x[n-3]
+		a[n-1], b[n-1] = w, h[n-3]
+		d[n-1] = (h[n-2]*h[n-2]*delta[n-3] + (2*w+h[n-2])*h[n-3]*delta[n-2]) / w
+	case Natural:
+		b[0], c[0] = 2, 1
+		d[0] = 3 * delta[0]
+		a[n-1], b[n-1] = 1, 2
+		d[n-1] = 3 * delta[n-2]
+	default:
+		panic("unknown boundary condition")
+	}
+
+	for i := 1; i < n; i++ {
+		m := a[i] / b[i-1]
+		b[i] -= m * c[i-1]
+		d[i] -= m * d[i-1]
+	}
+	d[n-1] /= b[n-1]
+	for i := n - 2; i >= 0; i-- {
+		d[i] = (d[i] - c[i]*d[i+1]) / b[i]
+	}
+	return &Cubic{x, y, d}
+}
+
+// Spline returns the NotAKnot cubic spline through (x, y) evaluated at xi.
+func Spline(x, y, xi []float64) []float64 {
+	return NewSpline(x, y, NotAKnot).Eval(xi)
+}
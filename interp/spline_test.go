@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package interp
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestSpline(t *testing.T) {
+	cubic := func(x float64) float64 { return x*x*x - 2*x*x + 3 }
+	x := []float64{-1, 0, 0.5, 2, 3, 4.5}
+	y := make([]float64, len(x))
+	for i, v := range x {
+		y[i] = cubic(v)
+	}
+	xi := []float64{-2, -0.5, 0.25, 1, 2.5, 4, 5}
+	e := make([]float64, len(xi))
+	for i, v := range xi {
+		e[i] = cubic(v)
+	}
+
+	tests := []struct {
+		x, y, xi, out []float64
+		bc            Boundary
+	}{
+		// A not-a-knot spline reproduces a cubic, even when extrapolating.
+		{x, y, xi, e, NotAKnot},
+		// A natural spline reproduces a straight line.
+		{
+			[]float64{-2, 1, 3, 7},
+			[]float64{-3, 3, 7, 15},
+			[]float64{0, 1, 2, 3, 4, 5},
+			[]float64{1, 3, 5, 7, 9, 11},
+			Natural,
+		},
+		{
+			[]float64{0, 1, 2},
+			[]float64{0, 1, 0},
+			[]float64{0.5, 1, 1.5},
+			[]float64{0.6875, 1, 0.6875},
+			Natural,
+		},
+		// Three points give a parabola.
+		{
+			[]float64{0, 1, 3},
+			[]float64{1, 2, 10},
+			[]float64{2, -1},
+			[]float64{5, 2},
+			NotAKnot,
+		},
+		// Two points give a line.
+		{
+			[]float64{1, 3},
+			[]float64{2, 6},
+			[]float64{0, 2},
+			[]float64{0, 4},
+			NotAKnot,
+		},
+	}
+	for _, v := range tests {
+		o := NewSpline(v.x, v.y, v.bc).Eval(v.xi)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error("NewSpline error\ninput:", v.x, v.y, v.xi, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+
+	if o := Spline(x, y, xi); !dsputils.PrettyClose(o, e) {
+		t.Error("Spline error\ninput:", x, y, xi, "\noutput:", o, "\nexpected:", e)
+	}
+}
+
+func TestSample(t *testing.T) {
+	c := NewSpline([]float64{0, 0.3, 1.1, 2}, []float64{0, 0.6, 2.2, 4}, Natural)
+	o := c.Sample(0, 0.5, 5)
+	if e := []float64{0, 1, 2, 3, 4}; !dsputils.PrettyClose(o, e) {
+		t.Error("Sample error\noutput:", o, "\nexpected:", e)
+	}
+}
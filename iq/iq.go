@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package iq provides support for raw interleaved IQ capture files, as
+// written by rtl_sdr, hackrf_transfer and GNU Radio file sinks.
+package iq
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Format is the sample format of an IQ file. Each complex sample is an I
+// value followed by a Q value.
+type Format int
+
+const (
+	// Uint8 is unsigned 8-bit samples offset by 127.5, as written by
+	// rtl_sdr.
+	Uint8 Format = iota
+
+	// Int8 is signed 8-bit samples, as written by hackrf_transfer.
+	Int8
+
+	// Int16 is signed little-endian 16-bit samples.
+	Int16
+
+	// Float32 is little-endian IEEE float samples, as written by GNU
+	// Radio's complex file sink.
+	Float32
+)
+
+var formatNames = map[string]Format{
+	"cu8":  Uint8,
+	"cs8":  Int8,
+	"cs16": Int16,
+	"cf32": Float32,
+}
+
+// ParseFormat returns the Format with the conventional file extension name:
+// cu8, cs8, cs16 or cf32.
+func ParseFormat(name string) (Format, error) {
+	f, ok := formatNames[name]
+	if !ok {
+		return 0, fmt.Errorf("iq: unknown format: %q", name)
+	}
+	return f, nil
+}
+
+// Size returns the number of bytes of one complex sample.
+func (f Format) Size() int {
+	switch f {
+	case Uint8, Int8:
+		return 2
+	case Int16:
+		return 4
+	case Float32:
+		return 8
+	}
+	panic("unknown format")
+}
+
+// Reader reads IQ files.
+type Reader struct {
+	Format Format
+
+	r   io.Reader
+	buf []byte
+}
+
+// NewReader returns a Reader reading samples of format f from r.
+func NewReader(r io.Reader, f Format) *Reader {
+	if f < Uint8 || f > Float32 {
+		panic("unknown format")
+	}
+	return &Reader{Format: f, r: r}
+}
+
+// Read reads len(p) samples into p, scaled to the range -1 to 1, or fewer
+// at the end of the file. It returns the number of samples read and any
+// error: io.EOF if no samples remain, or io.ErrUnexpectedEOF if the file
+// ends in the middle of a sample.
+func (r *Reader) Read(p []complex128) (int, error) {
+	size := r.Format.Size()
+	if n := len(p) * size; cap(r.buf) < n {
+		r.buf = make([]byte, n)
+	}
+	buf := r.buf[:len(p)*size]
+	n, err := io.ReadFull(r.r, buf)
+	if err == io.ErrUnexpectedEOF && n%size == 0 {
+		err = nil
+	}
+	for i := range p[:n/size] {
+		p[i] = r.decode(buf[i*size:])
+	}
+	return n / size, err
+}
+
+// ReadSamples returns the next n samples, or fewer at the end of the file.
+func (r *Reader) ReadSamples(n int) ([]complex128, error) {
+	p := make([]complex128, n)
+	m, err := r.Read(p)
+	if err != nil {
+		return nil, err
+	}
+	return p[:m], nil
+}
+
+func (r *Reader) decode(b []byte) complex128 {
+	switch r.Format {
+	case Uint8:
+		return complex((float64(b[0])-127.5)/127.5, (float64(b[1])-127.5)/127.5)
+	case Int8:
+		return complex(float64(int8(b[0]))/128, float64(int8(b[1]))/128)
+	case Int16:
+		i := int16(binary.LittleEndian.Uint16(b))
+		q := int16(binary.LittleEndian.Uint16(b[2:]))
+		return complex(float64(i)/32768, float64(q)/32768)
+	default:
+		i := math.Float32frombits(binary.LittleEndian.Uint32(b))
+		q := math.Float32frombits(binary.LittleEndian.Uint32(b[4:]))
+		return complex(float64(i), float64(q))
+	}
+}
+
+// ReadComplex128s implements dsputils.ComplexReader. It is the same as Read.
+func (r *Reader) ReadComplex128s(p []complex128) (int, error) {
+	return r.Read(p)
+}
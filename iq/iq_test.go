@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package iq
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"testing"
+)
+
+func TestReader(t *testing.T) {
+	f32 := new(bytes.Buffer)
+	binary.Write(f32, binary.LittleEndian, []float32{0.5, -0.25, 1, 0})
+
+	tests := []struct {
+		f   Format
+		in  []byte
+		out []complex128
+	}{
+		{Uint8, []byte{255, 0, 127, 128}, []complex128{complex(1, -1), complex(-0.5/127.5, 0.5/127.5)}},
+		{Int8, []byte{0x80, 0x7f, 0, 0x40}, []complex128{complex(-1, 127.0/128), complex(0, 0.5)}},
+		{Int16, []byte{0x00, 0x80, 0xff, 0x7f, 0x00, 0x40, 0x00, 0xc0}, []complex128{complex(-1, 32767.0/32768), complex(0.5, -0.5)}},
+		{Float32, f32.Bytes(), []complex128{complex(0.5, -0.25), 1}},
+	}
+	for _, v := range tests {
+		r := NewReader(bytes.NewReader(v.in), v.f)
+		o, err := r.ReadSamples(10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(o) != len(v.out) {
+			t.Fatal("Reader length error\ninput:", v.f, "\noutput:", o, "\nexpected:", v.out)
+		}
+		for i := range o {
+			if o[i] != v.out[i] {
+				t.Error("Reader error\ninput:", v.f, "\noutput:", o, "\nexpected:", v.out)
+				break
+			}
+		}
+		if _, err := r.ReadSamples(1); err != io.EOF {
+			t.Error("Reader EOF error:", err)
+		}
+	}
+}
+
+func TestReaderBlocks(t *testing.T) {
+	b := make([]byte, 1000)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	r := NewReader(bytes.NewReader(b), Int8)
+	p := make([]complex128, 64)
+	var total int
+	for {
+		n, err := r.Read(p)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		for i, v := range p[:n] {
+			j := 2 * (total + i)
+			if e := complex(float64(int8(j))/128, float64(int8(j+1))/128); v != e {
+				t.Fatal("Reader block error at", total+i, "\noutput:", v, "\nexpected:", e)
+			}
+		}
+		total += n
+	}
+	if total != 500 {
+		t.Error("Reader block count error:", total)
+	}
+
+	// A truncated final sample.
+	r = NewReader(bytes.NewReader(b[:7]), Int16)
+	if n, err := r.Read(p); n != 1 || err != io.ErrUnexpectedEOF {
+		t.Error("Reader truncated error:", n, err)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for name, e := range map[string]Format{"cu8": Uint8, "cs8": Int8, "cs16": Int16, "cf32": Float32} {
+		if f, err := ParseFormat(name); err != nil || f != e {
+			t.Error("ParseFormat error\ninput:", name, "\noutput:", f, err)
+		}
+	}
+	if _, err := ParseFormat("wav"); err == nil {
+		t.Error("ParseFormat expected error")
+	}
+}
@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+)
+
+// KWeighting returns the K-weighting filter of ITU-R BS.1770 for the
+// sampling frequency fs, as second-order sections: a high shelf of about
+// +4 dB above 1.5 kHz modelling the acoustic effect of the head, followed by
+// a highpass at about 38 Hz (the RLB weighting curve). The filters are
+// derived from their analog prototypes, so they match the 48 kHz
+// coefficients of the standard and hold at other sampling frequencies.
+// Reference: https://www.itu.int/rec/R-REC-BS.1770
+// See also: https://github.com/csteinmetz1/pyloudnorm
+func KWeighting(fs float64) [][6]float64 {
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+
+	// Stage 1: high shelf.
+	const (
+		shelfGain = 3.999843853973347
+		shelfQ    = 0.7071752369554196
+		shelfFc   = 1681.974450955533
+	)
+	K := math.Tan(math.Pi * shelfFc / fs)
+	Vh := math.Pow(10, shelfGain/20)
+	Vb := math.Pow(Vh, 0.4996667741545416)
+	a0 := 1 + K/shelfQ + K*K
+	shelf := [6]float64{
+		(Vh + Vb*K/shelfQ + K*K) / a0,
+		2 * (K*K - Vh) / a0,
+		(Vh - Vb*K/shelfQ + K*K) / a0,
+		1,
+		2 * (K*K - 1) / a0,
+		(1 - K/shelfQ + K*K) / a0,
+	}
+
+	// Stage 2: highpass.
+	const (
+		hpQ  = 0.5003270373238773
+		hpFc = 38.13547087602444
+	)
+	K = math.Tan(math.Pi * hpFc / fs)
+	a0 = 1 + K/hpQ + K*K
+	hp := [6]float64{
+		1, -2, 1,
+		1,
+		2 * (K*K - 1) / a0,
+		(1 - K/hpQ + K*K) / a0,
+	}
+
+	return [][6]float64{shelf, hp}
+}
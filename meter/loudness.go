@@ -0,0 +1,214 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/filter"
+)
+
+// Gates of the integrated loudness measurement, in LUFS and LU.
+const (
+	AbsoluteGate = -70
+	RelativeGate = -10
+)
+
+// Meter measures the loudness of multichannel audio per EBU R128 and
+// ITU-R BS.1770: momentary (400 ms), short-term (3 s) and gated integrated
+// loudness in LUFS, and true peak.
+// Reference: https://tech.ebu.ch/publications/r128
+type Meter struct {
+	fs      float64
+	weights []float64
+	k       []*filter.Stream
+	peak    []*truePeak
+
+	step   int       // samples per 100 ms sub-block
+	pos    int       // samples in the current sub-block
+	acc    float64   // weighted energy of the current sub-block
+	sub    []float64 // weighted energies of the last 30 sub-blocks
+	nsub   int       // sub-blocks completed
+	blocks []float64 // mean squares of all 400 ms gating blocks
+}
+
+// NewMeter returns a Meter for audio with the given number of channels at
+// sampling frequency fs. weights are the channel weights of BS.1770: 1 for
+// left, right and center, 1.41 for surround channels and 0 for LFE. nil
+// weights all channels by 1.
+func NewMeter(fs float64, channels int, weights []float64) *Meter {
+	if channels < 1 {
+		panic("channels must be positive")
+	}
+	if weights == nil {
+		weights = make([]float64, channels)
+		for i := range weights {
+			weights[i] = 1
+		}
+	} else if len(weights) != channels {
+		panic("weights must have one value per channel")
+	}
+	step := int(fs/10 + 0.5)
+	if step < 1 {
+		panic("fs too low")
+	}
+
+	m := &Meter{
+		fs:      fs,
+		weights: append([]float64(nil), weights...),
+		step:    step,
+	}
+	kw := KWeighting(fs)
+	for i := 0; i < channels; i++ {
+		m.k = append(m.k, filter.NewSosStream(kw))
+		m.peak = append(m.peak, newTruePeak())
+	}
+	return m
+}
+
+// Process measures the next chunk of audio, with one slice per channel,
+// all of the same length.
+func (m *Meter) Process(x [][]float64) {
+	if len(x) != len(m.k) {
+		panic("wrong number of channels")
+	}
+	y := make([][]float64, len(x))
+	for c := range x {
+		if len(x[c]) != len(x[0]) {
+			panic("channels must have the same length")
+		}
+		y[c] = m.k[c].Process(x[c])
+		m.peak[c].process(x[c])
+	}
+
+	for i := range x[0] {
+		for c, w := range m.weights {
+			m.acc += w * y[c][i] * y[c][i]
+		}
+		m.pos++
+		if m.pos == m.step {
+			m.endSubBlock()
+		}
+	}
+}
+
+// endSubBlock records the completed 100 ms sub-block, and the 400 ms gating
+// block, overlapping the previous one by 75%, that it completes.
+func (m *Meter) endSubBlock() {
+	m.sub = append(m.sub, m.acc)
+	if len(m.sub) > 30 {
+		m.sub = m.sub[1:]
+	}
+	m.nsub++
+	m.acc, m.pos = 0, 0
+	if m.nsub >= 4 {
+		m.blocks = append(m.blocks, m.meanSquare(4))
+	}
+}
+
+// meanSquare returns the weighted mean square of the last n sub-blocks.
+func (m *Meter) meanSquare(n int) float64 {
+	var s float64
+	for _, v := range m.sub[len(m.sub)-n:] {
+		s += v
+	}
+	return s / float64(n*m.step)
+}
+
+// lufs returns the loudness of the weighted mean square z.
+func lufs(z float64) float64 {
+	return -0.691 + 10*math.Log10(z)
+}
+
+// Momentary returns the loudness of the last 400 ms, or -Inf if less has
+// been measured.
+func (m *Meter) Momentary() float64 {
+	if m.nsub < 4 {
+		return math.Inf(-1)
+	}
+	return lufs(m.meanSquare(4))
+}
+
+// ShortTerm returns the loudness of the last 3 s, or -Inf if less has been
+// measured.
+func (m *Meter) ShortTerm() float64 {
+	if m.nsub < 30 {
+		return math.Inf(-1)
+	}
+	return lufs(m.meanSquare(30))
+}
+
+// Integrated returns the gated loudness of all audio measured: the loudness
+// of the 400 ms blocks above AbsoluteGate and above RelativeGate below the
+// loudness of those. It returns -Inf if no blocks pass the gates.
+func (m *Meter) Integrated() float64 {
+	var sum float64
+	var n int
+	for _, z := range m.blocks {
+		if lufs(z) > AbsoluteGate {
+			sum += z
+			n++
+		}
+	}
+	if n == 0 {
+		return math.Inf(-1)
+	}
+	gate := lufs(sum/float64(n)) + RelativeGate
+
+	sum, n = 0, 0
+	for _, z := range m.blocks {
+		if l := lufs(z); l > AbsoluteGate && l > gate {
+			sum += z
+			n++
+		}
+	}
+	if n == 0 {
+		return math.Inf(-1)
+	}
+	return lufs(sum / float64(n))
+}
+
+// TruePeak returns the largest true peak of all channels measured, as a
+// linear level.
+func (m *Meter) TruePeak() float64 {
+	var p float64
+	for _, t := range m.peak {
+		if t.max > p {
+			p = t.max
+		}
+	}
+	return p
+}
+
+// Reset clears all measurements, as at creation.
+func (m *Meter) Reset() {
+	for c := range m.k {
+		m.k[c].Reset()
+		m.peak[c].reset()
+	}
+	m.pos, m.acc, m.nsub = 0, 0, 0
+	m.sub = m.sub[:0]
+	m.blocks = m.blocks[:0]
+}
+
+// Integrated returns the integrated loudness of x, with one slice per
+// channel, all weighted by 1, in LUFS.
+func Integrated(x [][]float64, fs float64) float64 {
+	m := NewMeter(fs, len(x), nil)
+	m.Process(x)
+	return m.Integrated()
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+	"testing"
+)
+
+func sine(f, amp, fs float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = amp * math.Sin(2*math.Pi*f*float64(i)/fs)
+	}
+	return x
+}
+
+func TestIntegrated(t *testing.T) {
+	// A 1 kHz sine at -23 dBFS in both channels of a stereo signal measures
+	// -23 LUFS, as in EBU Tech 3341.
+	for _, fs := range []float64{44100, 48000} {
+		x := sine(1000, math.Pow(10, -23.0/20), fs, int(20*fs))
+		if l := Integrated([][]float64{x, x}, fs); math.Abs(l+23) > 0.1 {
+			t.Errorf("Integrated at %v Hz: got %v, expected -23", fs, l)
+		}
+		if l := Integrated([][]float64{x}, fs); math.Abs(l+26) > 0.1 {
+			t.Errorf("Integrated mono at %v Hz: got %v, expected -26", fs, l)
+		}
+	}
+}
+
+func TestMeter(t *testing.T) {
+	const fs = 48000
+	m := NewMeter(fs, 2, nil)
+	if l := m.Integrated(); !math.IsInf(l, -1) {
+		t.Error("Integrated of nothing: got", l, "expected -Inf")
+	}
+
+	// 10 s at -20 dBFS, then 10 s at -40 dBFS, which the relative gate
+	// excludes, and 10 s of silence, which the absolute gate excludes.
+	loud := sine(1000, math.Pow(10, -20.0/20), fs, 10*fs)
+	quiet := sine(1000, math.Pow(10, -40.0/20), fs, 10*fs)
+	silence := make([]float64, 10*fs)
+	for k, x := range [][]float64{loud, quiet, silence} {
+		// Process in odd sized chunks, to cross sub-block boundaries.
+		for i := 0; i < len(x); i += 1234 {
+			j := i + 1234
+			if j > len(x) {
+				j = len(x)
+			}
+			m.Process([][]float64{x[i:j], x[i:j]})
+		}
+		if k == 1 {
+			if l := m.ShortTerm(); math.Abs(l+40) > 0.1 {
+				t.Errorf("ShortTerm: got %v, expected -40", l)
+			}
+			if l := m.Momentary(); math.Abs(l+40) > 0.1 {
+				t.Errorf("Momentary: got %v, expected -40", l)
+			}
+		}
+	}
+	if l := m.Integrated(); math.Abs(l+20) > 0.1 {
+		t.Errorf("Integrated: got %v, expected -20", l)
+	}
+	if p := DB(m.TruePeak()); math.Abs(p+20) > 0.1 {
+		t.Errorf("TruePeak: got %v dB, expected -20", p)
+	}
+
+	m.Reset()
+	if l := m.Momentary(); !math.IsInf(l, -1) {
+		t.Error("Momentary after Reset: got", l, "expected -Inf")
+	}
+	if p := m.TruePeak(); p != 0 {
+		t.Error("TruePeak after Reset: got", p, "expected 0")
+	}
+
+	// An LFE channel weighted by 0 does not count.
+	m = NewMeter(fs, 2, []float64{1, 0})
+	m.Process([][]float64{loud, loud})
+	if l := m.Integrated(); math.Abs(l+23) > 0.1 {
+		t.Errorf("Integrated with weights: got %v, expected -23", l)
+	}
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package meter provides level and loudness metering of audio signals, such
+// as RMS, true peak, and EBU R128 loudness in LUFS.
+package meter
+
+import (
+	"math"
+)
+
+// RMS returns the root mean square level of x.
+func RMS(x []float64) float64 {
+	if len(x) == 0 {
+		return 0
+	}
+	var s float64
+	for _, v := range x {
+		s += v * v
+	}
+	return math.Sqrt(s / float64(len(x)))
+}
+
+// Peak returns the largest absolute sample value of x.
+func Peak(x []float64) float64 {
+	var p float64
+	for _, v := range x {
+		if v := math.Abs(v); v > p {
+			p = v
+		}
+	}
+	return p
+}
+
+// DB returns the linear level v in decibels relative to full scale, 1.0,
+// such as dBFS for RMS and dBTP for TruePeak.
+func DB(v float64) float64 {
+	return 20 * math.Log10(v)
+}
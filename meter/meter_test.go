@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRMS(t *testing.T) {
+	x := []float64{1, -1, 1, -1}
+	if r := RMS(x); r != 1 {
+		t.Error("RMS error\ninput:", x, "\noutput:", r, "\nexpected:", 1)
+	}
+	if r := RMS(nil); r != 0 {
+		t.Error("RMS error\ninput: nil\noutput:", r, "\nexpected:", 0)
+	}
+	x = []float64{0.5, -2, 1}
+	if p := Peak(x); p != 2 {
+		t.Error("Peak error\ninput:", x, "\noutput:", p, "\nexpected:", 2)
+	}
+	if d := DB(0.1); math.Abs(d+20) > 1e-12 {
+		t.Error("DB error\ninput:", 0.1, "\noutput:", d, "\nexpected:", -20)
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// Oversampling of the true peak measurement.
+const (
+	tpFactor = 4
+	tpTaps   = 16 // per phase
+)
+
+// tpPhases are the polyphase components of the true peak interpolation
+// filter, a windowed sinc lowpass at the original Nyquist frequency. It is
+// centered on a tap, so the first phase passes the samples unchanged; the
+// tap that would make it symmetric is a zero of the sinc, and is dropped.
+var tpPhases = func() [][]float64 {
+	n := tpFactor * tpTaps
+	h := window.Kaiser(n+1, 6)[:n]
+	M := float64(n / 2)
+	for i := range h {
+		t := (float64(i) - M) / tpFactor
+		if t != 0 {
+			h[i] *= math.Sin(math.Pi*t) / (math.Pi * t)
+		}
+	}
+	p := make([][]float64, tpFactor)
+	for i := range p {
+		p[i] = make([]float64, tpTaps)
+		for j := range p[i] {
+			p[i][j] = h[j*tpFactor+i]
+		}
+	}
+	return p
+}()
+
+// truePeak measures the true peak of a signal, the peak of its continuous
+// waveform between samples, by 4x oversampling as recommended by ITU-R
+// BS.1770 Annex 2.
+type truePeak struct {
+	hist []float64 // last tpTaps-1 inputs
+	max  float64
+}
+
+func newTruePeak() *truePeak {
+	return &truePeak{hist: make([]float64, tpTaps-1)}
+}
+
+func (t *truePeak) process(x []float64) {
+	n := len(t.hist)
+	buf := append(t.hist, x...)
+	for i := n; i < len(buf); i++ {
+		for _, h := range tpPhases {
+			var s float64
+			for j, v := range h {
+				s += v * buf[i-j]
+			}
+			if s = math.Abs(s); s > t.max {
+				t.max = s
+			}
+		}
+	}
+	t.hist = append(t.hist[:0], buf[len(buf)-n:]...)
+}
+
+func (t *truePeak) reset() {
+	for i := range t.hist {
+		t.hist[i] = 0
+	}
+	t.max = 0
+}
+
+// TruePeak returns the true peak of x, as a linear level.
+func TruePeak(x []float64) float64 {
+	t := newTruePeak()
+	t.process(x)
+	// Flush the interpolator, so peaks at the end of x are measured.
+	t.process(make([]float64, tpTaps))
+	return t.max
+}
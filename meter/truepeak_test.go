@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package meter
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTruePeak(t *testing.T) {
+	// A sine at a quarter of the sampling frequency, sampled 45 degrees
+	// from its peaks, has a sample peak 3 dB below its true peak.
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = math.Sin(math.Pi/2*float64(i) + math.Pi/4)
+	}
+	if p := Peak(x); math.Abs(p-math.Sqrt2/2) > 1e-9 {
+		t.Errorf("Peak: got %v, expected %v", p, math.Sqrt2/2)
+	}
+	if p := TruePeak(x); math.Abs(p-1) > 0.02 {
+		t.Errorf("TruePeak: got %v, expected 1", p)
+	}
+
+	// The samples themselves are measured, even at the end of x.
+	if p := TruePeak([]float64{0, 0, -1}); p != 1 {
+		t.Errorf("TruePeak of impulse: got %v, expected 1", p)
+	}
+}
@@ -0,0 +1,65 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package pipeline
+
+import (
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/filter"
+)
+
+// Window returns a node multiplying each block's samples by the window from
+// wf, such as window.Hann.
+func Window(wf func(int) []float64) Node {
+	var w []float64
+	return Func(func(b *Block) error {
+		if len(w) != len(b.Samples) {
+			w = wf(len(b.Samples))
+		}
+		for i := range b.Samples {
+			b.Samples[i] *= w[i]
+		}
+		return nil
+	})
+}
+
+// FFT returns a node setting each block's Spectrum to the FFT of its
+// samples.
+func FFT() Node {
+	return Func(func(b *Block) error {
+		b.Spectrum = fft.FFTReal(b.Samples)
+		return nil
+	})
+}
+
+// Filter returns a node replacing each block's samples with the output of
+// the streaming filter p. The pipeline's blocks must not overlap.
+func Filter(p filter.Processor) Node {
+	return Func(func(b *Block) error {
+		b.Samples = p.Process(b.Samples)
+		return nil
+	})
+}
+
+// Write returns a node writing each block's samples to w. The pipeline's
+// blocks must not overlap.
+func Write(w dsputils.SampleWriter) Node {
+	return Func(func(b *Block) error {
+		_, err := w.WriteFloat64s(b.Samples)
+		return err
+	})
+}
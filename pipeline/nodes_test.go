@@ -0,0 +1,83 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/filter"
+	"github.com/mjibson/go-dsp/window"
+)
+
+func TestSpectrum(t *testing.T) {
+	// Find the strongest bin of each block of a tone at bin 32.
+	x := make([]float64, 4096)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * 32 * float64(i) / 256)
+	}
+	var peaks []int
+	err := New(dsputils.NewSliceReader(x), 256, 128).Add(
+		Window(window.Hann),
+		FFT(),
+		Func(func(b *Block) error {
+			best := 0
+			for i, v := range b.Spectrum[:len(b.Spectrum)/2] {
+				if cmplx.Abs(v) > cmplx.Abs(b.Spectrum[best]) {
+					best = i
+				}
+			}
+			peaks = append(peaks, best)
+			return nil
+		}),
+	).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(peaks) != 31 {
+		t.Error("block count error:", len(peaks))
+	}
+	for _, p := range peaks {
+		if p != 32 {
+			t.Error("peak error:", peaks)
+			break
+		}
+	}
+}
+
+func TestFilter(t *testing.T) {
+	x := make([]float64, 10000)
+	for i := range x {
+		x[i] = float64(i%37) - 18
+	}
+	b := []float64{0.2, 0.3, 0.5}
+	a := []float64{1, -0.4}
+	var w dsputils.SliceWriter
+	err := New(dsputils.NewSliceReader(x), 512, 512).Add(
+		Filter(filter.NewStream(b, a)),
+		Write(&w),
+	).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := filter.Lfilter(b, a, x); !dsputils.PrettyClose(w.Samples, e) {
+		t.Error("Filter error")
+	}
+}
@@ -0,0 +1,197 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package pipeline runs block-based signal processing graphs: a source
+// splits a sample stream into fixed-size blocks, which pass through a chain
+// of nodes (such as window, FFT and filter stages) to a sink. Each node runs
+// in its own goroutine, and bounded channels between them provide
+// backpressure, so a slow sink throttles the source.
+package pipeline
+
+import (
+	"context"
+	"io"
+	"sync"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// Block is a unit of data passed between nodes. Each block is owned by one
+// node at a time, so nodes may modify it in place.
+type Block struct {
+	// Seq is the index of the block in the stream, starting at 0.
+	Seq int
+
+	// Offset is the index in the source stream of the first sample of the
+	// block.
+	Offset int
+
+	// Samples holds the block's real samples.
+	Samples []float64
+
+	// Spectrum holds complex data, such as set by an FFT node.
+	Spectrum []complex128
+}
+
+// Node is a processing stage. Process is called with each block in order,
+// from a single goroutine, so nodes may keep state between blocks. A
+// non-nil error stops the pipeline.
+type Node interface {
+	Process(b *Block) error
+}
+
+// Func is an adapter to allow the use of ordinary functions as nodes.
+type Func func(b *Block) error
+
+// Process calls f(b).
+func (f Func) Process(b *Block) error {
+	return f(b)
+}
+
+// Pipeline reads blocks from a source and passes them through its nodes.
+type Pipeline struct {
+	src       dsputils.SampleReader
+	size, hop int
+	nodes     []Node
+
+	// Buffer is the number of blocks that may be queued between each pair
+	// of nodes. The default value is 0, which uses 1.
+	Buffer int
+}
+
+// New returns a Pipeline reading blocks of size samples from src, each
+// starting hop samples after the previous one. A hop smaller than size
+// gives overlapping blocks, as for spectral analysis; streaming filters
+// need hop equal to size. The final block is shortened if the source ends
+// within it.
+func New(src dsputils.SampleReader, size, hop int) *Pipeline {
+	if size < 1 || hop < 1 || hop > size {
+		panic("size and hop must be positive, with hop at most size")
+	}
+	return &Pipeline{src: src, size: size, hop: hop}
+}
+
+// Add appends nodes to the pipeline and returns it.
+func (p *Pipeline) Add(nodes ...Node) *Pipeline {
+	p.nodes = append(p.nodes, nodes...)
+	return p
+}
+
+// Run processes the source until it ends, a node returns an error, or ctx
+// is done, and returns the first error. Reaching the end of the source is
+// not an error.
+func (p *Pipeline) Run(parent context.Context) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+
+	buffer := p.Buffer
+	if buffer < 1 {
+		buffer = 1
+	}
+
+	var (
+		once     sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	ch := make(chan *Block, buffer)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(ch)
+		if err := p.read(ctx, ch); err != nil {
+			fail(err)
+		}
+	}()
+
+	in := ch
+	for _, n := range p.nodes {
+		out := make(chan *Block, buffer)
+		wg.Add(1)
+		go func(n Node, in <-chan *Block, out chan<- *Block) {
+			defer wg.Done()
+			defer close(out)
+			for b := range in {
+				if err := n.Process(b); err != nil {
+					fail(err)
+					break
+				}
+				select {
+				case out <- b:
+				case <-ctx.Done():
+					return
+				}
+			}
+			// Drain so upstream nodes are not blocked.
+			for range in {
+			}
+		}(n, in, out)
+		in = out
+	}
+	for range in {
+	}
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = parent.Err()
+	}
+	return firstErr
+}
+
+// read sends the blocks of the source to ch.
+func (p *Pipeline) read(ctx context.Context, ch chan<- *Block) error {
+	buf := make([]float64, 0, p.size)
+	eof := false
+	for seq, offset := 0, 0; ; seq, offset = seq+1, offset+p.hop {
+		for len(buf) < p.size && !eof {
+			n, err := p.src.ReadFloat64s(buf[len(buf):p.size])
+			buf = buf[:len(buf)+n]
+			if err == io.EOF {
+				eof = true
+			} else if err != nil {
+				return err
+			}
+		}
+		// Stop when there are no samples not already sent.
+		if len(buf) == 0 || (seq > 0 && len(buf) <= p.size-p.hop) {
+			return nil
+		}
+
+		b := &Block{
+			Seq:     seq,
+			Offset:  offset,
+			Samples: append([]float64(nil), buf...),
+		}
+		select {
+		case ch <- b:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if p.hop >= len(buf) {
+			buf = buf[:0]
+		} else {
+			buf = append(buf[:0], buf[p.hop:]...)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func ramp(n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = float64(i)
+	}
+	return x
+}
+
+func TestBlocks(t *testing.T) {
+	tests := []struct {
+		n, size, hop int
+		offsets      []int
+		lens         []int
+	}{
+		{10, 4, 4, []int{0, 4, 8}, []int{4, 4, 2}},
+		{8, 4, 4, []int{0, 4}, []int{4, 4}},
+		{10, 4, 2, []int{0, 2, 4, 6}, []int{4, 4, 4, 4}},
+		{11, 4, 2, []int{0, 2, 4, 6, 8}, []int{4, 4, 4, 4, 3}},
+		{8, 4, 2, []int{0, 2, 4}, []int{4, 4, 4}},
+		{3, 4, 2, []int{0}, []int{3}},
+		{0, 4, 2, nil, nil},
+	}
+	for _, v := range tests {
+		var offsets, lens []int
+		x := ramp(v.n)
+		err := New(dsputils.NewSliceReader(x), v.size, v.hop).Add(Func(func(b *Block) error {
+			if b.Seq != len(offsets) {
+				t.Error("Seq error:", b.Seq)
+			}
+			for i, s := range b.Samples {
+				if s != x[b.Offset+i] {
+					t.Error("sample error at", b.Offset+i)
+				}
+			}
+			offsets = append(offsets, b.Offset)
+			lens = append(lens, len(b.Samples))
+			return nil
+		})).Run(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(offsets, v.offsets) || !reflect.DeepEqual(lens, v.lens) {
+			t.Error("blocks error\ninput:", v.n, v.size, v.hop, "\noutput:", offsets, lens, "\nexpected:", v.offsets, v.lens)
+		}
+	}
+}
+
+func TestError(t *testing.T) {
+	// An error stops the pipeline, even with an endless source.
+	e := errors.New("stop")
+	var seen int
+	err := New(endless{}, 16, 16).Add(
+		Func(func(b *Block) error {
+			if b.Seq == 10 {
+				return e
+			}
+			return nil
+		}),
+		Func(func(b *Block) error {
+			seen++
+			return nil
+		}),
+	).Run(context.Background())
+	if err != e {
+		t.Error("Run error:", err)
+	}
+	if seen != 10 {
+		t.Error("blocks after error:", seen)
+	}
+}
+
+func TestCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	err := New(endless{}, 16, 16).Add(Func(func(b *Block) error {
+		if b.Seq == 5 {
+			cancel()
+		}
+		return nil
+	})).Run(ctx)
+	if err != context.Canceled {
+		t.Error("Run cancel error:", err)
+	}
+}
+
+type endless struct{}
+
+func (endless) ReadFloat64s(p []float64) (int, error) {
+	return len(p), nil
+}
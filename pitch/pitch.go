@@ -0,0 +1,267 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package pitch provides fundamental frequency estimators for monophonic
+// signals, such as speech and single musical notes.
+package pitch
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/wav"
+)
+
+// Estimate is the pitch estimate of one frame.
+type Estimate struct {
+	// Time is the time of the start of the frame in seconds.
+	Time float64
+
+	// Freq is the estimated fundamental frequency in Hz. It is set even for
+	// unvoiced frames, but is then unreliable.
+	Freq float64
+
+	// Confidence is the periodicity of the frame, from 0 (noise) to 1
+	// (perfectly periodic).
+	Confidence float64
+
+	// Voiced reports whether the frame is periodic enough to have a pitch.
+	Voiced bool
+}
+
+// Detector estimates the pitch of a frame of samples.
+type Detector interface {
+	Detect(frame []float64) Estimate
+}
+
+// Track returns the pitch estimates of consecutive frames of x sampled at
+// fs, each frameSize samples long and starting hop samples after the
+// previous one. Only whole frames are used.
+func Track(x []float64, fs float64, d Detector, frameSize, hop int) []Estimate {
+	if frameSize < 1 || hop < 1 {
+		panic("frameSize and hop must be positive")
+	}
+	var r []Estimate
+	for i := 0; i+frameSize <= len(x); i += hop {
+		e := d.Detect(x[i : i+frameSize])
+		e.Time = float64(i) / fs
+		r = append(r, e)
+	}
+	return r
+}
+
+// TrackWav reads the remaining samples of w, mixes its channels, and returns
+// their pitch estimates as Track. The detector must be configured for
+// w.SampleRate.
+func TrackWav(w *wav.Wav, d Detector, frameSize, hop int) ([]Estimate, error) {
+	f, err := dsputils.ReadAll(w)
+	if err != nil {
+		return nil, err
+	}
+	ch := int(w.NumChannels)
+	if ch < 1 {
+		ch = 1
+	}
+	x := make([]float64, len(f)/ch)
+	for i := range x {
+		for c := 0; c < ch; c++ {
+			x[i] += f[i*ch+c]
+		}
+		x[i] /= float64(ch)
+	}
+	return Track(x, float64(w.SampleRate), d, frameSize, hop), nil
+}
+
+// lagRange returns the lags corresponding to fmax and fmin at fs.
+func lagRange(fs, fmin, fmax float64) (lo, hi int) {
+	if fmin <= 0 || fmax <= fmin || fmax > fs/2 {
+		panic("frequencies must satisfy 0 < fmin < fmax <= fs/2")
+	}
+	lo = int(math.Floor(fs / fmax))
+	if lo < 1 {
+		lo = 1
+	}
+	return lo, int(math.Ceil(fs / fmin))
+}
+
+// parabolic returns the offset, between -1/2 and 1/2, of the vertex of the
+// parabola through (-1, a), (0, b) and (1, c).
+func parabolic(a, b, c float64) float64 {
+	d := a - 2*b + c
+	if d == 0 {
+		return 0
+	}
+	p := (a - c) / (2 * d)
+	if p < -0.5 || p > 0.5 {
+		return 0
+	}
+	return p
+}
+
+// YIN is a pitch detector using the YIN algorithm: the first dip of the
+// cumulative mean normalized difference function below a threshold.
+type YIN struct {
+	fs        float64
+	lo, hi    int
+	threshold float64
+}
+
+// NewYIN returns a YIN detector for signals sampled at fs, searching for
+// pitches between fmin and fmax. Frames must be at least MinFrame samples
+// long. The threshold, typically 0.1 to 0.2, is the largest normalized
+// difference accepted as voiced.
+// Reference: http://audition.ens.fr/adc/pdf/2002_JASA_YIN.pdf
+func NewYIN(fs, fmin, fmax, threshold float64) *YIN {
+	lo, hi := lagRange(fs, fmin, fmax)
+	return &YIN{fs: fs, lo: lo, hi: hi, threshold: threshold}
+}
+
+// MinFrame returns the shortest frame Detect accepts, 2*ceil(fs/fmin)+1
+// samples.
+func (y *YIN) MinFrame() int {
+	return 2*y.hi + 1
+}
+
+// Detect returns the pitch estimate of frame. It panics if frame is shorter
+// than MinFrame.
+func (y *YIN) Detect(frame []float64) Estimate {
+	if len(frame) < y.MinFrame() {
+		panic("frame too short")
+	}
+	w := len(frame) - y.hi - 1
+
+	// Cumulative mean normalized difference function.
+	d := make([]float64, y.hi+2)
+	d[0] = 1
+	var sum float64
+	for tau := 1; tau < len(d); tau++ {
+		var s float64
+		for j := 0; j < w; j++ {
+			v := frame[j] - frame[j+tau]
+			s += v * v
+		}
+		sum += s
+		if sum == 0 {
+			d[tau] = 1
+		} else {
+			d[tau] = s * float64(tau) / sum
+		}
+	}
+
+	// The first dip below the threshold, or the global minimum.
+	best := -1
+	for tau := y.lo; tau <= y.hi; tau++ {
+		if d[tau] < y.threshold {
+			for tau+1 <= y.hi && d[tau+1] < d[tau] {
+				tau++
+			}
+			best = tau
+			break
+		}
+	}
+	voiced := best >= 0
+	if !voiced {
+		best = y.lo
+		for tau := y.lo; tau <= y.hi; tau++ {
+			if d[tau] < d[best] {
+				best = tau
+			}
+		}
+	}
+
+	lag := float64(best) + parabolic(d[best-1], d[best], d[best+1])
+	conf := 1 - d[best]
+	if conf < 0 {
+		conf = 0
+	}
+	return Estimate{Freq: y.fs / lag, Confidence: conf, Voiced: voiced}
+}
+
+// Autocorrelation is a pitch detector using the peak of the normalized
+// autocorrelation of the frame.
+type Autocorrelation struct {
+	fs        float64
+	lo, hi    int
+	threshold float64
+}
+
+// NewAutocorrelation returns an autocorrelation detector for signals sampled
+// at fs, searching for pitches between fmin and fmax. Frames must be at least
+// MinFrame samples long. The threshold, typically 0.3 to 0.5, is the
+// smallest normalized autocorrelation peak accepted as voiced.
+func NewAutocorrelation(fs, fmin, fmax, threshold float64) *Autocorrelation {
+	lo, hi := lagRange(fs, fmin, fmax)
+	return &Autocorrelation{fs: fs, lo: lo, hi: hi, threshold: threshold}
+}
+
+// MinFrame returns the shortest frame Detect accepts, 2*ceil(fs/fmin)+2
+// samples.
+func (a *Autocorrelation) MinFrame() int {
+	return 2*a.hi + 2
+}
+
+// Detect returns the pitch estimate of frame. It panics if frame is shorter
+// than MinFrame.
+func (a *Autocorrelation) Detect(frame []float64) Estimate {
+	if len(frame) < a.MinFrame() {
+		panic("frame too short")
+	}
+	var mean float64
+	for _, v := range frame {
+		mean += v
+	}
+	mean /= float64(len(frame))
+	x := make([]float64, len(frame))
+	for i, v := range frame {
+		x[i] = v - mean
+	}
+
+	// Normalized autocorrelation over the overlapping part of the frame.
+	r := make([]float64, a.hi+2)
+	for tau := a.lo - 1; tau < len(r); tau++ {
+		var s, e0, e1 float64
+		for j := 0; j+tau < len(x); j++ {
+			s += x[j] * x[j+tau]
+			e0 += x[j] * x[j]
+			e1 += x[j+tau] * x[j+tau]
+		}
+		if e0 > 0 && e1 > 0 {
+			r[tau] = s / math.Sqrt(e0*e1)
+		}
+	}
+
+	best := a.lo
+	for tau := a.lo; tau <= a.hi; tau++ {
+		if r[tau] > r[best] {
+			best = tau
+		}
+	}
+	// Multiples of the period correlate nearly as well; prefer the first
+	// local maximum close to the best.
+	for tau := a.lo; tau < best; tau++ {
+		if r[tau] >= r[tau-1] && r[tau] >= r[tau+1] && r[tau] > 0.9*r[best] {
+			best = tau
+			break
+		}
+	}
+
+	lag := float64(best) + parabolic(-r[best-1], -r[best], -r[best+1])
+	conf := r[best]
+	if conf < 0 {
+		conf = 0
+	}
+	return Estimate{Freq: a.fs / lag, Confidence: conf, Voiced: conf >= a.threshold}
+}
@@ -0,0 +1,189 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package pitch
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/wav"
+)
+
+const fs = 16000.0
+
+// harmonic returns n samples of a tone at f with decaying harmonics.
+func harmonic(f float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		t := float64(i) / fs
+		for k := 1; k <= 5; k++ {
+			x[i] += math.Sin(2*math.Pi*f*float64(k)*t) / float64(k)
+		}
+	}
+	return x
+}
+
+func noise(n int) []float64 {
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = r.NormFloat64()
+	}
+	return x
+}
+
+func detectors() map[string]Detector {
+	return map[string]Detector{
+		"YIN":             NewYIN(fs, 60, 1000, 0.15),
+		"Autocorrelation": NewAutocorrelation(fs, 60, 1000, 0.5),
+	}
+}
+
+func TestDetect(t *testing.T) {
+	for name, d := range detectors() {
+		for _, f := range []float64{82.4, 110, 220, 261.6, 440, 880} {
+			e := d.Detect(harmonic(f, 1024))
+			if !e.Voiced || math.Abs(e.Freq-f)/f > 0.005 {
+				t.Error(name, "error\ninput:", f, "\noutput:", e)
+			}
+		}
+		if e := d.Detect(noise(1024)); e.Voiced {
+			t.Error(name, "noise error\noutput:", e)
+		}
+	}
+}
+
+func TestMinFrame(t *testing.T) {
+	// ceil(8000/60) = 134.
+	for _, tt := range []struct {
+		d interface {
+			Detector
+			MinFrame() int
+		}
+		min int
+	}{
+		{NewYIN(8000, 60, 1000, 0.15), 269},
+		{NewAutocorrelation(8000, 60, 1000, 0.5), 270},
+	} {
+		if m := tt.d.MinFrame(); m != tt.min {
+			t.Errorf("%T MinFrame: %v, expected %v", tt.d, m, tt.min)
+		}
+		tt.d.Detect(make([]float64, tt.min))
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%T did not panic", tt.d)
+				}
+			}()
+			tt.d.Detect(make([]float64, tt.min-1))
+		}()
+	}
+}
+
+// wavBytes returns a mono 16-bit WAV file of x.
+func wavBytes(x []float64) []byte {
+	return wavBytesSize(x, uint32(2*len(x)))
+}
+
+// wavBytesSize is like wavBytes, but with data chunk size sz.
+func wavBytesSize(x []float64, sz uint32) []byte {
+	var b bytes.Buffer
+	w := func(v interface{}) { binary.Write(&b, binary.LittleEndian, v) }
+	b.WriteString("RIFF")
+	w(uint32(36 + 2*len(x)))
+	b.WriteString("WAVEfmt ")
+	w(uint32(16))
+	w(wav.Header{
+		AudioFormat:   1,
+		NumChannels:   1,
+		SampleRate:    uint32(fs),
+		ByteRate:      2 * uint32(fs),
+		BlockAlign:    2,
+		BitsPerSample: 16,
+	})
+	b.WriteString("data")
+	w(sz)
+	for _, v := range x {
+		w(int16(v * 10000))
+	}
+	return b.Bytes()
+}
+
+func TestTrackWav(t *testing.T) {
+	// A 220 Hz note, silence, then a 330 Hz note.
+	x := harmonic(220, 4096)
+	x = append(x, make([]float64, 4096)...)
+	x = append(x, harmonic(330, 4096)...)
+
+	for name, d := range detectors() {
+		w, err := wav.New(bytes.NewReader(wavBytes(x)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		es, err := TrackWav(w, d, 1024, 512)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(es) != 23 {
+			t.Fatal(name, "frames error:", len(es))
+		}
+		for _, e := range es {
+			var f float64
+			switch {
+			case e.Time+1024/fs <= 4096/fs:
+				f = 220
+			case e.Time >= 4096/fs && e.Time+1024/fs <= 8192/fs:
+				f = 0
+			case e.Time >= 8192/fs:
+				f = 330
+			default:
+				continue
+			}
+			if e.Voiced != (f != 0) || (f != 0 && math.Abs(e.Freq-f)/f > 0.005) {
+				t.Error(name, "track error at", e.Time, "\noutput:", e, "\nexpected:", f)
+			}
+		}
+	}
+}
+
+func TestTrackWavRemaining(t *testing.T) {
+	// Silence, read before tracking, then a 220 Hz note. The data size is
+	// unknown, as written by a wav.Writer that cannot seek.
+	x := append(make([]float64, 1000), harmonic(220, 5000)...)
+	w, err := wav.New(bytes.NewReader(wavBytesSize(x, 0xFFFFFFFF)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ReadFloats(1000); err != nil {
+		t.Fatal(err)
+	}
+	es, err := TrackWav(w, NewYIN(fs, 60, 1000, 0.15), 1024, 512)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(es) != 8 {
+		t.Fatal("frames error:", len(es))
+	}
+	for _, e := range es {
+		if !e.Voiced || math.Abs(e.Freq-220)/220 > 0.005 {
+			t.Error("track error at", e.Time, "\noutput:", e)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+//go:build plot
+
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package plot
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"gonum.org/v1/plot"
+	"gonum.org/v1/plot/palette/moreland"
+	"gonum.org/v1/plot/plotter"
+)
+
+// dB returns 10*log10(v), limited below to -300 dB so zeros can be plotted.
+func dB(v float64) float64 {
+	return 10 * math.Log10(math.Max(v, 1e-30))
+}
+
+// line returns a plot of y against x with the given labels.
+func line(x, y []float64, title, xlabel, ylabel string) (*plot.Plot, error) {
+	if len(x) != len(y) {
+		panic("x and y must be the same length")
+	}
+	xy := make(plotter.XYs, len(x))
+	for i := range xy {
+		xy[i].X = x[i]
+		xy[i].Y = y[i]
+	}
+	l, err := plotter.NewLine(xy)
+	if err != nil {
+		return nil, err
+	}
+	p := plot.New()
+	p.Title.Text = title
+	p.X.Label.Text = xlabel
+	p.Y.Label.Text = ylabel
+	p.Add(l, plotter.NewGrid())
+	return p, nil
+}
+
+// PSD returns a plot of the power spectral density Pxx at freqs, such as
+// returned by spectral.Pwelch, in dB.
+func PSD(Pxx, freqs []float64) (*plot.Plot, error) {
+	y := make([]float64, len(Pxx))
+	for i, v := range Pxx {
+		y[i] = dB(v)
+	}
+	return line(freqs, y, "Power spectral density", "Frequency", "Power (dB)")
+}
+
+// Response returns a plot of the magnitude in dB of the frequency response
+// h at freqs, such as returned by filter.Freqz.
+func Response(h []complex128, freqs []float64) (*plot.Plot, error) {
+	y := make([]float64, len(h))
+	for i, v := range h {
+		a := cmplx.Abs(v)
+		y[i] = dB(a * a)
+	}
+	return line(freqs, y, "Frequency response", "Frequency", "Magnitude (dB)")
+}
+
+// Phase returns a plot of the unwrapped phase in degrees of the frequency
+// response h at freqs.
+func Phase(h []complex128, freqs []float64) (*plot.Plot, error) {
+	ph := make([]float64, len(h))
+	for i, v := range h {
+		ph[i] = cmplx.Phase(v)
+	}
+	ph = dsputils.Unwrap(ph)
+	for i := range ph {
+		ph[i] *= 180 / math.Pi
+	}
+	return line(freqs, ph, "Phase response", "Frequency", "Phase (degrees)")
+}
+
+// grid adapts a spectrogram to plotter.GridXYZ.
+type grid struct {
+	s            [][]float64
+	times, freqs []float64
+}
+
+func (g grid) Dims() (c, r int)   { return len(g.times), len(g.freqs) }
+func (g grid) Z(c, r int) float64 { return dB(g.s[c][r]) }
+func (g grid) X(c int) float64    { return g.times[c] }
+func (g grid) Y(r int) float64    { return g.freqs[r] }
+
+// Spectrogram returns a heat map plot in dB of the power spectrogram s,
+// indexed by time and then frequency, at the given times and freqs.
+func Spectrogram(s [][]float64, times, freqs []float64) *plot.Plot {
+	if len(s) != len(times) {
+		panic("s and times must be the same length")
+	}
+	for _, row := range s {
+		if len(row) != len(freqs) {
+			panic("rows of s and freqs must be the same length")
+		}
+	}
+	h := plotter.NewHeatMap(grid{s, times, freqs}, moreland.SmoothBlueRed().Palette(255))
+	p := plot.New()
+	p.Title.Text = "Spectrogram"
+	p.X.Label.Text = "Time"
+	p.Y.Label.Text = "Frequency"
+	p.Add(h)
+	return p
+}
@@ -0,0 +1,62 @@
+//go:build plot
+
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package plot
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/mjibson/go-dsp/filter"
+	"github.com/mjibson/go-dsp/spectral"
+	"gonum.org/v1/plot/vg"
+)
+
+func TestPlots(t *testing.T) {
+	x := make([]float64, 4096)
+	for i := range x {
+		x[i] = float64(i%17) - 8
+	}
+	Pxx, freqs := spectral.Pwelch(x, 1000, &spectral.PwelchOptions{NFFT: 256})
+	p, err := PSD(Pxx, freqs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.WriterTo(4*vg.Inch, 3*vg.Inch, "png"); err != nil {
+		t.Fatal(err)
+	}
+
+	h, hf := filter.Freqz([]float64{0.25, 0.5, 0.25}, []float64{1}, 512, 1000)
+	if _, err := Response(h, hf); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Phase(h, hf); err != nil {
+		t.Fatal(err)
+	}
+
+	s := [][]float64{{1, 2, 3}, {4, 5, 6}}
+	p = Spectrogram(s, []float64{0, 1}, []float64{0, 10, 20})
+	w, err := p.WriterTo(4*vg.Inch, 3*vg.Inch, "png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if _, err := w.WriteTo(&b); err != nil || b.Len() == 0 {
+		t.Error("Spectrogram write error:", err)
+	}
+}
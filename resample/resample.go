@@ -0,0 +1,124 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package resample provides high quality streaming sample rate conversion.
+// It is built on filter.Resampler, selecting its kernel by quality and its
+// ratio by a pair of rates, and on filter.Reader for sample streams.
+package resample
+
+import (
+	"fmt"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/filter"
+	"github.com/mjibson/go-dsp/wav"
+)
+
+// Quality selects the tradeoff between conversion accuracy and speed.
+type Quality = filter.ResamplerQuality
+
+// The qualities, described at the filter.Resampler constants.
+const (
+	Fast   = filter.ResamplerFast
+	Medium = filter.ResamplerMedium
+	High   = filter.ResamplerHigh
+	Best   = filter.ResamplerBest
+)
+
+// Converter is a streaming sample rate converter using bandlimited (Kaiser
+// windowed sinc) interpolation with a polyphase kernel table. The ratio of
+// rates may be any positive number. When downsampling, the kernel is
+// widened to remove frequencies above the output Nyquist frequency.
+type Converter = filter.Resampler
+
+// New returns a Converter from inRate to outRate samples per second.
+func New(inRate, outRate float64, q Quality) *Converter {
+	return filter.NewResamplerRates(inRate, outRate, q)
+}
+
+// NewReader returns a dsputils.SampleReader converting src from inRate to
+// outRate. It returns io.EOF after the source's last sample has been
+// converted and read.
+func NewReader(src dsputils.SampleReader, inRate, outRate float64, q Quality) *filter.Reader {
+	return filter.NewReader(src, New(inRate, outRate, q))
+}
+
+// NewWavReader returns a dsputils.SampleReader of the remaining samples of w
+// converted to outRate, interleaved with w.NumChannels channels as w's are.
+// Each channel is converted separately.
+func NewWavReader(w *wav.Wav, outRate float64, q Quality) (*filter.Reader, error) {
+	if w.NumChannels < 1 {
+		return nil, fmt.Errorf("resample: invalid number of channels: %v", w.NumChannels)
+	}
+	c := make([]*Converter, w.NumChannels)
+	for i := range c {
+		c[i] = New(float64(w.SampleRate), outRate, q)
+	}
+	return filter.NewReader(w, &interleaved{c: c}), nil
+}
+
+// interleaved is a filter.Processor converting each channel of an
+// interleaved signal with its own Converter.
+type interleaved struct {
+	c    []*Converter
+	part []float64 // samples of an incomplete frame
+}
+
+// Process converts the whole frames of x, after any incomplete frame from
+// the previous call.
+func (p *interleaved) Process(x []float64) []float64 {
+	x = append(p.part, x...)
+	n := len(p.c)
+	frames := len(x) / n
+	y := make([][]float64, n)
+	in := make([]float64, frames)
+	for i, c := range p.c {
+		for j := range in {
+			in[j] = x[j*n+i]
+		}
+		y[i] = c.Process(in)
+	}
+	p.part = append(p.part[:0], x[frames*n:]...)
+	return interleave(y)
+}
+
+// Flush returns the remaining output of each channel, discarding any
+// incomplete frame.
+func (p *interleaved) Flush() []float64 {
+	y := make([][]float64, len(p.c))
+	for i, c := range p.c {
+		y[i] = c.Flush()
+	}
+	p.part = p.part[:0]
+	return interleave(y)
+}
+
+// interleave returns the frames common to all channels of y, interleaved.
+func interleave(y [][]float64) []float64 {
+	frames := len(y[0])
+	for _, v := range y {
+		if len(v) < frames {
+			frames = len(v)
+		}
+	}
+	r := make([]float64, frames*len(y))
+	for i, v := range y {
+		for j := 0; j < frames; j++ {
+			r[j*len(y)+i] = v[j]
+		}
+	}
+	return r
+}
@@ -0,0 +1,171 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package resample
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/wav"
+)
+
+func tone(f, fs float64, n int) []float64 {
+	x := make([]float64, n)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * f * float64(i) / fs)
+	}
+	return x
+}
+
+// maxError returns the largest error of y against a tone at f and fs,
+// ignoring the edges.
+func maxError(y []float64, f, fs float64) float64 {
+	var e float64
+	for i := len(y) / 4; i < 3*len(y)/4; i++ {
+		e = math.Max(e, math.Abs(y[i]-math.Sin(2*math.Pi*f*float64(i)/fs)))
+	}
+	return e
+}
+
+func TestConverter(t *testing.T) {
+	tests := []struct {
+		q   Quality
+		tol float64
+	}{
+		{Fast, 1e-2},
+		{Medium, 1e-4},
+		{High, 1e-5},
+		{Best, 1e-6},
+	}
+	x := tone(1000, 44100, 44100)
+	for _, v := range tests {
+		c := New(44100, 48000, v.q)
+		y := append(c.Process(x), c.Flush()...)
+		if len(y) != 48000 {
+			t.Error("Converter length error\ninput:", v.q, "\noutput:", len(y))
+		}
+		if e := maxError(y, 1000, 48000); e > v.tol {
+			t.Error("Converter error\ninput:", v.q, "\noutput:", e, "\nexpected: <", v.tol)
+		}
+	}
+}
+
+func TestConverterDownsample(t *testing.T) {
+	// A tone above the output Nyquist frequency is removed.
+	c := New(48000, 8000, High)
+	y := append(c.Process(tone(5000, 48000, 48000)), c.Flush()...)
+	if e := maxError(y, 0, 8000); e > 1e-4 {
+		t.Error("Converter alias error:", e)
+	}
+
+	c = New(48000, 8000, High)
+	y = append(c.Process(tone(1000, 48000, 48000)), c.Flush()...)
+	if e := maxError(y, 1000, 8000); e > 1e-4 {
+		t.Error("Converter downsample error:", e)
+	}
+}
+
+func TestConverterChunks(t *testing.T) {
+	x := tone(440, 22050, 5000)
+	c := New(22050, 16000, Medium)
+	e := append(c.Process(x), c.Flush()...)
+
+	var o []float64
+	for i := 0; i < len(x); i += 97 {
+		end := i + 97
+		if end > len(x) {
+			end = len(x)
+		}
+		o = append(o, c.Process(x[i:end])...)
+	}
+	o = append(o, c.Flush()...)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Converter chunk error")
+	}
+}
+
+func TestReader(t *testing.T) {
+	x := tone(440, 22050, 10000)
+	c := New(22050, 16000, Medium)
+	e := append(c.Process(x), c.Flush()...)
+
+	o, err := dsputils.ReadAll(NewReader(dsputils.NewSliceReader(x), 22050, 16000, Medium))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Reader error")
+	}
+}
+
+func TestWavReader(t *testing.T) {
+	// A stereo file with a different tone in each channel.
+	l, r := tone(440, 22050, 3000), tone(1000, 22050, 3000)
+	var b bytes.Buffer
+	ww, err := wav.NewWriter(&b, wav.Header{AudioFormat: 3, NumChannels: 2, SampleRate: 22050, BitsPerSample: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	x := make([]float64, 2*len(l))
+	for i := range l {
+		x[2*i], x[2*i+1] = l[i], r[i]
+	}
+	if _, err := ww.WriteFloat64s(x); err != nil {
+		t.Fatal(err)
+	}
+	if err := ww.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	w, err := wav.New(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	ch, err := w.ReadChannels(len(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var e []float64
+	for i, v := range ch {
+		c := New(22050, 16000, Medium)
+		y := append(c.Process(v), c.Flush()...)
+		if i == 0 {
+			e = make([]float64, 2*len(y))
+		}
+		for j, s := range y {
+			e[2*j+i] = s
+		}
+	}
+
+	w, err = wav.New(bytes.NewReader(b.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wr, err := NewWavReader(w, 16000, Medium)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := dsputils.ReadAll(wr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("WavReader error\noutput:", len(o), "\nexpected:", len(e))
+	}
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// AGC is a streaming automatic gain control. It tracks the envelope of its
+// input with separate attack and release time constants, and scales the
+// input so the envelope is at the target level. A short attack keeps strong
+// signals from overshooting; a longer release avoids pumping on the gaps.
+type AGC struct {
+	target          float64
+	attack, release float64
+	maxGain         float64
+	env             float64
+}
+
+// NewAGC returns an AGC for samples at fs with the target output envelope
+// level, attack and release time constants in seconds, and maximum gain,
+// which limits the amplification of silence and noise. The gain starts at
+// 1.
+func NewAGC(target, attack, release, maxGain, fs float64) *AGC {
+	if target <= 0 || maxGain <= 0 || attack < 0 || release < 0 {
+		panic("target and maxGain must be positive, attack and release non-negative")
+	}
+	coef := func(t float64) float64 {
+		if t == 0 {
+			return 0
+		}
+		return math.Exp(-1 / (t * fs))
+	}
+	return &AGC{
+		target:  target,
+		attack:  coef(attack),
+		release: coef(release),
+		maxGain: maxGain,
+		env:     target,
+	}
+}
+
+// next updates the envelope with the magnitude m and returns the gain.
+func (a *AGC) next(m float64) float64 {
+	g := a.release
+	if m > a.env {
+		g = a.attack
+	}
+	a.env = g*a.env + (1-g)*m
+	return a.Gain()
+}
+
+// Gain returns the current gain.
+func (a *AGC) Gain() float64 {
+	if a.env*a.maxGain <= a.target {
+		return a.maxGain
+	}
+	return a.target / a.env
+}
+
+// Process returns the next chunk x of a real signal with gain applied.
+func (a *AGC) Process(x []float64) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = v * a.next(math.Abs(v))
+	}
+	return r
+}
+
+// ProcessComplex returns the next chunk x of a complex signal with gain
+// applied.
+func (a *AGC) ProcessComplex(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		r[i] = v * complex(a.next(cmplx.Abs(v)), 0)
+	}
+	return r
+}
+
+// Reset returns the gain to 1, as at creation.
+func (a *AGC) Reset() {
+	a.env = a.target
+}
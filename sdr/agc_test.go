@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestAGC(t *testing.T) {
+	const fs = 48000.0
+	a := NewAGC(1, 0.001, 0.05, 100, fs)
+
+	// A complex tone whose level jumps from 0.1 to 5.
+	x := make([]complex128, 48000)
+	for i := range x {
+		amp := 0.1
+		if i >= 24000 {
+			amp = 5
+		}
+		x[i] = cmplx.Rect(amp, 2*math.Pi*1000*float64(i)/fs)
+	}
+	o := a.ProcessComplex(x)
+	for _, i := range []int{23999, 47999} {
+		if m := cmplx.Abs(o[i]); math.Abs(m-1) > 0.01 {
+			t.Error("AGC level error at", i, ":", m)
+		}
+	}
+	// The attack settles within a few time constants.
+	if m := cmplx.Abs(o[24000+500]); math.Abs(m-1) > 0.05 {
+		t.Error("AGC attack error:", m)
+	}
+
+	// A real tone settles to its envelope rather than its RMS.
+	a = NewAGC(0.5, 0.001, 0.5, 100, fs)
+	y := make([]float64, 96000)
+	for i := range y {
+		y[i] = 3 * math.Sin(2*math.Pi*100*float64(i)/fs)
+	}
+	o2 := a.Process(y)
+	var peak float64
+	for _, v := range o2[len(o2)-480:] {
+		peak = math.Max(peak, math.Abs(v))
+	}
+	if math.Abs(peak-0.5) > 0.05 {
+		t.Error("AGC real error:", peak)
+	}
+
+	// Silence is limited to the maximum gain.
+	a.Reset()
+	if g := a.Gain(); g != 1 {
+		t.Error("AGC reset error:", g)
+	}
+	a.Process(make([]float64, 5*48000))
+	if g := a.Gain(); g != 100 {
+		t.Error("AGC max gain error:", g)
+	}
+}
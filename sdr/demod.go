@@ -0,0 +1,94 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package sdr provides building blocks for software defined radio on complex
+// baseband (IQ) streams, such as demodulators, automatic gain control and
+// carrier recovery loops.
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Common FM de-emphasis time constants, in seconds.
+const (
+	DeemphasisUS = 75e-6 // Americas and South Korea
+	DeemphasisEU = 50e-6 // Europe and elsewhere
+)
+
+// FMDemodulator is a streaming quadrature FM discriminator: the output is
+// the phase difference between consecutive IQ samples, scaled so that a
+// frequency deviation of deviation Hz gives an output of 1. An optional
+// single pole de-emphasis filter restores the high frequencies boosted by
+// broadcast pre-emphasis.
+type FMDemodulator struct {
+	gain  float64
+	alpha float64 // de-emphasis coefficient, or 0
+	prev  complex128
+	y     float64
+}
+
+// NewFMDemodulator returns an FMDemodulator for IQ samples at fs with peak
+// deviation in Hz, such as 75e3 for broadcast FM or 5e3 for narrowband FM.
+// tau is the de-emphasis time constant, such as DeemphasisUS, or 0 for none.
+// Reference: https://en.wikipedia.org/wiki/Frequency_modulation#Demodulation
+func NewFMDemodulator(deviation, tau, fs float64) *FMDemodulator {
+	if deviation <= 0 || fs <= 0 || tau < 0 {
+		panic("deviation and fs must be positive and tau non-negative")
+	}
+	d := &FMDemodulator{gain: fs / (2 * math.Pi * deviation)}
+	if tau > 0 {
+		d.alpha = 1 - math.Exp(-1/(tau*fs))
+	}
+	return d
+}
+
+// Process demodulates the next chunk x of IQ samples. The first output
+// after creation or Reset is zero.
+func (d *FMDemodulator) Process(x []complex128) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		var y float64
+		if d.prev != 0 {
+			y = cmplx.Phase(v*cmplx.Conj(d.prev)) * d.gain
+		}
+		d.prev = v
+		if d.alpha != 0 {
+			d.y += d.alpha * (y - d.y)
+			y = d.y
+		}
+		r[i] = y
+	}
+	return r
+}
+
+// Reset clears the demodulator's state, as at creation.
+func (d *FMDemodulator) Reset() {
+	d.prev = 0
+	d.y = 0
+}
+
+// AMDemod returns the envelope of the IQ samples x, their magnitudes. The
+// carrier level remains as a DC offset, which may be removed with a highpass
+// filter.
+func AMDemod(x []complex128) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		r[i] = cmplx.Abs(v)
+	}
+	return r
+}
@@ -0,0 +1,93 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// fmModulate returns the FM modulation of m at fs with the given deviation.
+func fmModulate(m []float64, deviation, fs float64) []complex128 {
+	r := make([]complex128, len(m))
+	var phase float64
+	for i, v := range m {
+		phase += 2 * math.Pi * deviation * v / fs
+		r[i] = cmplx.Rect(0.7, phase)
+	}
+	return r
+}
+
+func TestFMDemodulator(t *testing.T) {
+	const fs, dev = 240e3, 75e3
+	m := make([]float64, 2400)
+	for i := range m {
+		m[i] = math.Sin(2 * math.Pi * 1000 * float64(i) / fs)
+	}
+	x := fmModulate(m, dev, fs)
+	d := NewFMDemodulator(dev, 0, fs)
+	o := d.Process(x)
+	if o[0] != 0 || !dsputils.PrettyClose(o[1:], m[1:]) {
+		t.Error("FMDemodulator error")
+	}
+
+	// Chunks give the same result.
+	d.Reset()
+	var s []float64
+	for i := 0; i < len(x); i += 100 {
+		s = append(s, d.Process(x[i:i+100])...)
+	}
+	if !dsputils.PrettyClose(s, o) {
+		t.Error("FMDemodulator chunk error")
+	}
+}
+
+func TestFMDeemphasis(t *testing.T) {
+	// De-emphasis is a lowpass with corner 1/(2*pi*tau): DC passes, and
+	// the corner frequency is attenuated by 3 dB.
+	const fs, dev = 240e3, 75e3
+	gain := func(f float64) float64 {
+		m := make([]float64, 24000)
+		for i := range m {
+			m[i] = math.Sin(2 * math.Pi * f * float64(i) / fs)
+		}
+		o := NewFMDemodulator(dev, DeemphasisUS, fs).Process(fmModulate(m, dev, fs))
+		var peak float64
+		for _, v := range o[len(o)/2:] {
+			peak = math.Max(peak, math.Abs(v))
+		}
+		return peak
+	}
+	if g := gain(50); math.Abs(g-1) > 0.01 {
+		t.Error("de-emphasis low frequency error:", g)
+	}
+	if g := gain(1 / (2 * math.Pi * DeemphasisUS)); math.Abs(g-math.Sqrt2/2) > 0.01 {
+		t.Error("de-emphasis corner error:", g)
+	}
+}
+
+func TestAMDemod(t *testing.T) {
+	x := []complex128{1, 1i, complex(3, 4), 0}
+	o := AMDemod(x)
+	e := []float64{1, 1, 5, 0}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("AMDemod error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,146 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// loop is a second order carrier tracking loop: a proportional-integral loop
+// filter driving a numerically controlled oscillator.
+type loop struct {
+	alpha, beta float64 // phase and frequency gains
+	fmin, fmax  float64 // frequency limits, radians per sample
+	fs          float64
+	phase, freq float64
+}
+
+// newLoop returns a loop with noise bandwidth bw Hz, damping factor 1/sqrt(2),
+// and frequency range fmin to fmax Hz, for samples at fs.
+// Reference: http://www.trondeau.com/blog/2011/8/13/control-loop-gain-values.html
+func newLoop(bw, fmin, fmax, fs float64) loop {
+	if bw <= 0 || fs <= 0 || fmin > fmax {
+		panic("bw and fs must be positive and fmin at most fmax")
+	}
+	const damping = math.Sqrt2 / 2
+	w := 2 * math.Pi * bw / fs
+	d := 1 + 2*damping*w + w*w
+	return loop{
+		alpha: 4 * damping * w / d,
+		beta:  4 * w * w / d,
+		fmin:  2 * math.Pi * fmin / fs,
+		fmax:  2 * math.Pi * fmax / fs,
+		fs:    fs,
+	}
+}
+
+// advance updates the loop with the phase error e.
+func (l *loop) advance(e float64) {
+	l.freq += l.beta * e
+	if l.freq > l.fmax {
+		l.freq = l.fmax
+	} else if l.freq < l.fmin {
+		l.freq = l.fmin
+	}
+	l.phase = math.Remainder(l.phase+l.freq+l.alpha*e, 2*math.Pi)
+}
+
+// Freq returns the tracked carrier frequency in Hz.
+func (l *loop) Freq() float64 {
+	return l.freq * l.fs / (2 * math.Pi)
+}
+
+// Phase returns the tracked carrier phase in radians.
+func (l *loop) Phase() float64 {
+	return l.phase
+}
+
+// Reset sets the tracked phase and frequency to zero.
+func (l *loop) Reset() {
+	l.phase, l.freq = 0, 0
+}
+
+// PLL is a phase-locked loop tracking an unmodulated carrier in a complex
+// baseband stream.
+type PLL struct {
+	loop
+}
+
+// NewPLL returns a PLL for samples at fs with loop noise bandwidth bw Hz,
+// tracking carriers between fmin and fmax Hz. A narrow bandwidth rejects
+// more noise but locks more slowly; a few percent of the frequency range is
+// typical.
+func NewPLL(bw, fmin, fmax, fs float64) *PLL {
+	return &PLL{newLoop(bw, fmin, fmax, fs)}
+}
+
+// Process returns the next chunk x mixed down by the tracked carrier: once
+// locked, the carrier is at 0 Hz with zero phase.
+func (p *PLL) Process(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		r[i] = v * cmplx.Rect(1, -p.phase)
+		p.advance(cmplx.Phase(r[i]))
+	}
+	return r
+}
+
+// Costas is a Costas loop recovering the carrier of a BPSK or QPSK signal,
+// whose modulation removes the carrier component a PLL would track.
+type Costas struct {
+	loop
+	order int
+}
+
+// NewCostas returns a Costas loop for a signal with order 2 (BPSK) or 4
+// (QPSK) constellation points, at one or more samples per symbol. Other
+// arguments are as NewPLL. The recovered carrier has a phase ambiguity of a
+// multiple of 2*pi/order.
+// Reference: https://en.wikipedia.org/wiki/Costas_loop
+func NewCostas(order int, bw, fmin, fmax, fs float64) *Costas {
+	if order != 2 && order != 4 {
+		panic("order must be 2 or 4")
+	}
+	return &Costas{newLoop(bw, fmin, fmax, fs), order}
+}
+
+// Process returns the next chunk x mixed down by the tracked carrier: once
+// locked, BPSK symbols lie on the real axis and QPSK symbols on the
+// diagonals.
+func (c *Costas) Process(x []complex128) []complex128 {
+	r := make([]complex128, len(x))
+	for i, v := range x {
+		r[i] = v * cmplx.Rect(1, -c.phase)
+		re, im := real(r[i]), imag(r[i])
+		var e float64
+		if c.order == 2 {
+			e = re * im
+		} else {
+			e = sign(re)*im - sign(im)*re
+		}
+		c.advance(e)
+	}
+	return r
+}
+
+func sign(v float64) float64 {
+	if v < 0 {
+		return -1
+	}
+	return 1
+}
@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package sdr
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+func TestPLL(t *testing.T) {
+	const fs, f = 48000.0, 1234.0
+	x := make([]complex128, 48000)
+	for i := range x {
+		x[i] = cmplx.Rect(0.5, 2*math.Pi*f*float64(i)/fs+1)
+	}
+	p := NewPLL(100, -5000, 5000, fs)
+	o := p.Process(x)
+	if math.Abs(p.Freq()-f) > 0.01 {
+		t.Error("PLL frequency error\noutput:", p.Freq(), "\nexpected:", f)
+	}
+	for _, v := range o[len(o)-100:] {
+		if math.Abs(cmplx.Phase(v)) > 1e-3 || math.Abs(cmplx.Abs(v)-0.5) > 1e-9 {
+			t.Fatal("PLL lock error:", v)
+		}
+	}
+
+	// The frequency is limited to the range.
+	p = NewPLL(100, -500, 500, fs)
+	p.Process(x)
+	if math.Abs(p.Freq()-500) > 1e-9 {
+		t.Error("PLL limit error:", p.Freq())
+	}
+	p.Reset()
+	if p.Freq() != 0 || p.Phase() != 0 {
+		t.Error("PLL reset error")
+	}
+}
+
+func TestCostas(t *testing.T) {
+	const fs, f = 48000.0, 150.0
+	r := rand.New(rand.NewSource(1))
+	for _, order := range []int{2, 4} {
+		x := make([]complex128, 48000)
+		for i := range x {
+			// One sample per symbol.
+			sym := cmplx.Rect(1, 2*math.Pi*float64(r.Intn(order))/float64(order))
+			if order == 4 {
+				sym *= cmplx.Rect(1, math.Pi/4)
+			}
+			x[i] = sym * cmplx.Rect(1, 2*math.Pi*f*float64(i)/fs+0.3)
+		}
+		c := NewCostas(order, 50, -1000, 1000, fs)
+		o := c.Process(x)
+		if math.Abs(c.Freq()-f) > 0.1 {
+			t.Error("Costas frequency error\ninput:", order, "\noutput:", c.Freq(), "\nexpected:", f)
+		}
+		// The points lie on the axes (BPSK) or diagonals (QPSK).
+		for _, v := range o[len(o)-100:] {
+			ph := cmplx.Phase(v)
+			if order == 4 {
+				ph -= math.Pi / 4
+			}
+			step := 2 * math.Pi / float64(order)
+			if d := math.Remainder(ph, step); math.Abs(d) > 0.01 {
+				t.Fatal("Costas lock error\ninput:", order, "\noutput:", v)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+)
+
+// ChirpMethod is the frequency sweep of a chirp.
+type ChirpMethod int
+
+const (
+	// Linear sweeps as f(t) = f0 + (f1-f0)*t/t1.
+	Linear ChirpMethod = iota
+
+	// Logarithmic (exponential) sweeps as f(t) = f0*(f1/f0)^(t/t1), spending
+	// equal time in each octave. f0 and f1 must have the same sign.
+	Logarithmic
+
+	// Hyperbolic sweeps as f(t) = f0*f1*t1/((f0-f1)*t + f1*t1), so the period
+	// changes linearly. f0 and f1 must be nonzero.
+	Hyperbolic
+)
+
+// Chirp returns a swept-frequency cosine evaluated at the times t:
+// cos(2*pi*integral of f(t) + phi), where the instantaneous frequency f(t)
+// goes from f0 at time 0 to f1 at time t1 as given by method, and phi is in
+// degrees.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.chirp.html
+func Chirp(t []float64, f0, t1, f1 float64, method ChirpMethod, phi float64) []float64 {
+	if t1 <= 0 {
+		panic("t1 must be positive")
+	}
+
+	var phase func(t float64) float64
+	switch {
+	case f0 == f1 && method != Linear:
+		phase = func(t float64) float64 {
+			return 2 * math.Pi * f0 * t
+		}
+	case method == Linear:
+		beta := (f1 - f0) / t1
+		phase = func(t float64) float64 {
+			return 2 * math.Pi * (f0*t + beta*t*t/2)
+		}
+	case method == Logarithmic:
+		if f0*f1 <= 0 {
+			panic("f0 and f1 must be nonzero and have the same sign")
+		}
+		beta := t1 / math.Log(f1/f0)
+		phase = func(t float64) float64 {
+			return 2 * math.Pi * beta * f0 * (math.Pow(f1/f0, t/t1) - 1)
+		}
+	case method == Hyperbolic:
+		if f0 == 0 || f1 == 0 {
+			panic("f0 and f1 must be nonzero")
+		}
+		sing := -f1 * t1 / (f0 - f1)
+		phase = func(t float64) float64 {
+			return 2 * math.Pi * -sing * f0 * math.Log(math.Abs(1-t/sing))
+		}
+	default:
+		panic("unknown chirp method")
+	}
+
+	phi *= math.Pi / 180
+	r := make([]float64, len(t))
+	for i, v := range t {
+		r[i] = math.Cos(phase(v) + phi)
+	}
+	return r
+}
+
+// ChirpN returns n samples of a chirp sampled at fs, sweeping from f0 at the
+// first sample to f1 at the last, as computed by Chirp with zero phase.
+func ChirpN(f0, f1 float64, method ChirpMethod, fs float64, n int) []float64 {
+	if n < 2 {
+		panic("n must be at least 2")
+	}
+	t := make([]float64, n)
+	for i := range t {
+		t[i] = float64(i) / fs
+	}
+	return Chirp(t, f0, t[n-1], f1, method, 0)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestChirp(t *testing.T) {
+	o := Chirp([]float64{0, 0.5, 1}, 1, 1, 3, Linear, 0)
+	e := []float64{1, 0, 1}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Chirp error\noutput:", o, "\nexpected:", e)
+	}
+	o = Chirp([]float64{0}, 1, 1, 3, Linear, 90)
+	if math.Abs(o[0]) > 1e-12 {
+		t.Error("Chirp phase error\noutput:", o)
+	}
+
+	// Compare with the integral of the instantaneous frequency.
+	const f0, t1, f1 = 10.0, 2.0, 50.0
+	freqs := map[ChirpMethod]func(t float64) float64{
+		Linear:      func(t float64) float64 { return f0 + (f1-f0)*t/t1 },
+		Logarithmic: func(t float64) float64 { return f0 * math.Pow(f1/f0, t/t1) },
+		Hyperbolic:  func(t float64) float64 { return f0 * f1 * t1 / ((f0-f1)*t + f1*t1) },
+	}
+	for method, f := range freqs {
+		const steps = 20000
+		dt := t1 / steps
+		var phase float64
+		ts := []float64{0}
+		es := []float64{1}
+		for i := 1; i <= steps; i++ {
+			tt := float64(i) * dt
+			phase += (f(tt-dt) + f(tt)) / 2 * dt
+			if i%1000 == 0 {
+				ts = append(ts, tt)
+				es = append(es, math.Cos(2*math.Pi*phase))
+			}
+		}
+		o := Chirp(ts, f0, t1, f1, method, 0)
+		for i := range o {
+			if math.Abs(o[i]-es[i]) > 1e-4 {
+				t.Error("Chirp error\nmethod:", method, "\noutput:", o, "\nexpected:", es)
+				break
+			}
+		}
+	}
+}
+
+func TestChirpN(t *testing.T) {
+	o := ChirpN(1, 3, Linear, 2, 3)
+	e := []float64{1, 0, 1}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("ChirpN error\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// mlsTaps holds the exponents of a primitive feedback polynomial for each
+// MLS order.
+var mlsTaps = [][]uint{
+	2:  {2, 1},
+	3:  {3, 2},
+	4:  {4, 3},
+	5:  {5, 3},
+	6:  {6, 5},
+	7:  {7, 6},
+	8:  {8, 6, 5, 4},
+	9:  {9, 5},
+	10: {10, 7},
+	11: {11, 9},
+	12: {12, 11, 10, 4},
+	13: {13, 12, 11, 8},
+	14: {14, 13, 12, 2},
+	15: {15, 14},
+	16: {16, 15, 13, 4},
+	17: {17, 14},
+	18: {18, 11},
+	19: {19, 18, 17, 14},
+	20: {20, 17},
+	21: {21, 19},
+	22: {22, 21},
+	23: {23, 18},
+	24: {24, 23, 22, 17},
+}
+
+// MLS returns a maximum length sequence (MLS) of the given order, between 2
+// and 24: a pseudorandom binary sequence of 2^order-1 values of ±1,
+// generated by a linear feedback shift register. Its circular
+// autocorrelation is 2^order-1 at lag zero and -1 at all other lags, so its
+// spectrum is flat.
+// Reference: https://en.wikipedia.org/wiki/Maximum_length_sequence
+func MLS(order int) []float64 {
+	if order < 2 || order >= len(mlsTaps) {
+		panic("order must be between 2 and 24")
+	}
+
+	var mask uint32
+	for _, t := range mlsTaps[order] {
+		mask |= 1 << (t - 1)
+	}
+
+	r := make([]float64, 1<<uint(order)-1)
+	state := uint32(1)
+	for i := range r {
+		if state&1 == 1 {
+			r[i] = 1
+			state = state>>1 ^ mask
+		} else {
+			r[i] = -1
+			state >>= 1
+		}
+	}
+	return r
+}
+
+// MLSImpulseResponse returns the impulse response of a linear system
+// measured with the sequence mls from MLS: y is the system's response to
+// repetitions of mls, starting at the beginning of a repetition and
+// excluding the first, which contains the startup transient. y may hold one
+// or more periods, whose average is used to reduce noise. The result has
+// len(mls) samples; responses longer than that wrap around.
+func MLSImpulseResponse(mls, y []float64) []float64 {
+	L := len(mls)
+	if L == 0 || len(y) == 0 || len(y)%L != 0 {
+		panic("y must hold a whole number of periods of mls")
+	}
+
+	avg := make([]complex128, L)
+	for i, v := range y {
+		avg[i%L] += complex(v, 0)
+	}
+	periods := complex(float64(len(y)/L), 0)
+	for i := range avg {
+		avg[i] /= periods
+	}
+
+	// Circular cross-correlation of y and mls by FFT.
+	Y := fft.FFT(avg)
+	S := fft.FFTReal(mls)
+	for i := range Y {
+		Y[i] *= complex(real(S[i]), -imag(S[i]))
+	}
+	rsy := fft.IFFT(Y)
+
+	// The autocorrelation of the MLS is (L+1)*delta - 1, so the
+	// cross-correlation is (L+1)*h - sum(h), and sum(rsy) is sum(h).
+	var sum float64
+	for _, v := range rsy {
+		sum += real(v)
+	}
+	h := make([]float64, L)
+	for i, v := range rsy {
+		h[i] = (real(v) + sum) / float64(L+1)
+	}
+	return h
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMLS(t *testing.T) {
+	for order := 2; order <= 24; order++ {
+		m := MLS(order)
+		L := 1<<uint(order) - 1
+		if len(m) != L {
+			t.Fatal("MLS length error:", order, len(m))
+		}
+		var sum float64
+		for _, v := range m {
+			sum += v
+		}
+		if sum != 1 {
+			t.Error("MLS balance error:", order, sum)
+		}
+
+		// Circular autocorrelation.
+		for _, lag := range []int{0, 1, L / 3, L - 1} {
+			var r float64
+			for i, v := range m {
+				r += v * m[(i+lag)%L]
+			}
+			e := -1.0
+			if lag == 0 {
+				e = float64(L)
+			}
+			if r != e {
+				t.Error("MLS autocorrelation error:", order, lag, r, e)
+			}
+		}
+	}
+}
+
+func TestMLSImpulseResponse(t *testing.T) {
+	h := []float64{0.5, 1, -0.3, 0.2, 0.1}
+	m := MLS(8)
+
+	// Excite the system with three periods, discarding the first.
+	x := append(append(append([]float64{}, m...), m...), m...)
+	y := make([]float64, len(x))
+	for i := range y {
+		for j, v := range h {
+			if i-j >= 0 {
+				y[i] += v * x[i-j]
+			}
+		}
+	}
+
+	o := MLSImpulseResponse(m, y[len(m):])
+	e := make([]float64, len(m))
+	copy(e, h)
+	for i := range o {
+		if math.Abs(o[i]-e[i]) > 1e-9 {
+			t.Fatal("MLSImpulseResponse error\noutput:", o[:10], "\nexpected:", e[:10])
+		}
+	}
+}
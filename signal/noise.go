@@ -0,0 +1,152 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Noise is a streaming noise generator. Each generator has its own random
+// source, so sequences are reproducible from the seed.
+type Noise struct {
+	rng  *rand.Rand
+	amp  float64
+	next func() float64
+}
+
+// NewWhiteUniform returns a generator of white noise uniformly distributed
+// in [-amp, amp).
+func NewWhiteUniform(amp float64, seed int64) *Noise {
+	n := &Noise{rng: rand.New(rand.NewSource(seed)), amp: amp}
+	n.next = func() float64 {
+		return n.amp * (2*n.rng.Float64() - 1)
+	}
+	return n
+}
+
+// NewWhiteGaussian returns a generator of white Gaussian noise with
+// standard deviation amp.
+func NewWhiteGaussian(amp float64, seed int64) *Noise {
+	n := &Noise{rng: rand.New(rand.NewSource(seed)), amp: amp}
+	n.next = func() float64 {
+		return n.amp * n.rng.NormFloat64()
+	}
+	return n
+}
+
+// pinkRows is the number of Voss-McCartney generator rows, giving a 1/f
+// spectrum over about pinkRows octaves.
+const pinkRows = 16
+
+// NewPink returns a generator of pink (1/f) noise, whose power falls by 3 dB
+// per octave, scaled to have standard deviation about amp. It uses the
+// Voss-McCartney algorithm: the sum of pinkRows white noise sources, where
+// row k is updated every 2^k samples.
+// Reference: https://www.firstpr.com.au/dsp/pink-noise/
+func NewPink(amp float64, seed int64) *Noise {
+	n := &Noise{rng: rand.New(rand.NewSource(seed)), amp: amp}
+	var rows [pinkRows]float64
+	var sum float64
+	for i := range rows {
+		rows[i] = n.rng.NormFloat64()
+		sum += rows[i]
+	}
+	scale := math.Sqrt(pinkRows + 1)
+	var counter uint32
+	n.next = func() float64 {
+		// Update the row given by the number of trailing zeros of the
+		// counter, so row k changes every 2^k samples.
+		counter++
+		k := 0
+		for c := counter; c&1 == 0 && k < pinkRows-1; c >>= 1 {
+			k++
+		}
+		sum -= rows[k]
+		rows[k] = n.rng.NormFloat64()
+		sum += rows[k]
+
+		// A fresh white sample adds the highest octave.
+		v := sum + n.rng.NormFloat64()
+		return n.amp * v / scale
+	}
+	return n
+}
+
+// NewBrown returns a generator of brown (Brownian, 1/f^2) noise, whose power
+// falls by 6 dB per octave, with standard deviation about amp. It integrates
+// white noise with a slight leak, so it does not drift without bound.
+func NewBrown(amp float64, seed int64) *Noise {
+	n := &Noise{rng: rand.New(rand.NewSource(seed)), amp: amp}
+	const leak = 0.998
+	// The steady state variance of y is 1/(1-leak^2).
+	scale := math.Sqrt(1 - leak*leak)
+	var y float64
+	n.next = func() float64 {
+		y = leak*y + n.rng.NormFloat64()
+		return n.amp * y * scale
+	}
+	return n
+}
+
+// Next returns the next sample.
+func (n *Noise) Next() float64 {
+	return n.next()
+}
+
+// Read fills x with the next len(x) samples.
+func (n *Noise) Read(x []float64) {
+	for i := range x {
+		x[i] = n.next()
+	}
+}
+
+// ReadFloat64s implements dsputils.SampleReader. It fills p and never
+// returns an error, since the noise is endless.
+func (n *Noise) ReadFloat64s(p []float64) (int, error) {
+	n.Read(p)
+	return len(p), nil
+}
+
+// Generate returns the next n samples.
+func (n *Noise) Generate(count int) []float64 {
+	x := make([]float64, count)
+	n.Read(x)
+	return x
+}
+
+// WhiteUniform returns n samples of uniform white noise, as generated by
+// NewWhiteUniform.
+func WhiteUniform(amp float64, seed int64, n int) []float64 {
+	return NewWhiteUniform(amp, seed).Generate(n)
+}
+
+// WhiteGaussian returns n samples of Gaussian white noise, as generated by
+// NewWhiteGaussian.
+func WhiteGaussian(amp float64, seed int64, n int) []float64 {
+	return NewWhiteGaussian(amp, seed).Generate(n)
+}
+
+// Pink returns n samples of pink noise, as generated by NewPink.
+func Pink(amp float64, seed int64, n int) []float64 {
+	return NewPink(amp, seed).Generate(n)
+}
+
+// Brown returns n samples of brown noise, as generated by NewBrown.
+func Brown(amp float64, seed int64, n int) []float64 {
+	return NewBrown(amp, seed).Generate(n)
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/spectral"
+)
+
+func stats(x []float64) (mean, std float64) {
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+	for _, v := range x {
+		std += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(std / float64(len(x)))
+}
+
+func TestNoise(t *testing.T) {
+	const n = 1 << 17
+
+	u := WhiteUniform(2, 1, n)
+	for _, v := range u {
+		if v < -2 || v >= 2 {
+			t.Fatal("WhiteUniform range error:", v)
+		}
+	}
+	if m, s := stats(u); math.Abs(m) > 0.02 || math.Abs(s-2/math.Sqrt(3)) > 0.02 {
+		t.Error("WhiteUniform stats error:", m, s)
+	}
+
+	for _, v := range []struct {
+		name  string
+		x     []float64
+		slope float64 // dB per octave
+	}{
+		{"WhiteGaussian", WhiteGaussian(3, 1, n), 0},
+		{"Pink", Pink(3, 1, n), -3},
+		{"Brown", Brown(3, 1, n), -6},
+	} {
+		if m, s := stats(v.x); math.Abs(m) > 0.3 || math.Abs(s-3) > 0.3 {
+			t.Error(v.name, "stats error:", m, s)
+		}
+
+		// Compare the mean power over two bands two octaves apart.
+		p, _ := spectral.Pwelch(v.x, 1, &spectral.PwelchOptions{NFFT: 4096, Noverlap: 2048})
+		band := func(lo int) float64 {
+			var sum float64
+			for _, v := range p[lo : 2*lo] {
+				sum += v
+			}
+			return sum / float64(lo)
+		}
+		slope := 10 * math.Log10(band(200)/band(50)) / 2
+		if math.Abs(slope-v.slope) > 1 {
+			t.Error(v.name, "spectral slope error:", slope, v.slope)
+		}
+	}
+
+	// Generators are reproducible from the seed, and streaming matches.
+	g := NewPink(1, 7)
+	o := append(g.Generate(100), g.Generate(100)...)
+	if e := Pink(1, 7, 200); !dsputils.PrettyClose(o, e) {
+		t.Error("Pink seed error")
+	}
+	if e := Pink(1, 8, 200); dsputils.PrettyClose(o, e) {
+		t.Error("Pink seeds give equal sequences")
+	}
+}
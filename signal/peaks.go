@@ -0,0 +1,240 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"sort"
+)
+
+// PeakOptions holds the criteria a peak must meet to be returned by
+// FindPeaks.
+type PeakOptions struct {
+	// Height is the minimum height of a peak.
+	//
+	// The default value is 0, which disables the height criterion. Use
+	// math.SmallestNonzeroFloat64 to require positive peaks.
+	Height float64
+
+	// Distance is the minimum number of samples between neighboring peaks.
+	// Smaller peaks are removed first until all peaks are at least Distance
+	// apart.
+	//
+	// The default value is 0, which disables the distance criterion.
+	Distance int
+
+	// Prominence is the minimum prominence of a peak: how far it stands
+	// out from the surrounding baseline.
+	//
+	// The default value is 0, which disables the prominence criterion.
+	Prominence float64
+
+	// Width is the minimum width of a peak in samples, measured at
+	// RelHeight.
+	//
+	// The default value is 0, which disables the width criterion.
+	Width float64
+
+	// RelHeight is the height at which peak widths are measured, as a
+	// fraction of the prominence below the peak.
+	//
+	// The default value is 0, which uses 0.5: the full width at half
+	// prominence.
+	RelHeight float64
+
+	// Wlen is the window length in samples, centered on the peak, to which
+	// the search for the bases of a peak is limited.
+	//
+	// The default value is 0, which searches the whole signal.
+	Wlen int
+}
+
+// Peak describes a peak found by FindPeaks.
+type Peak struct {
+	// Index is the sample index of the peak. For flat peaks it is the middle
+	// of the plateau, rounded down.
+	Index int
+
+	// Height is the value of the signal at the peak.
+	Height float64
+
+	// Prominence is the height of the peak above the higher of its two
+	// bases, and LeftBase and RightBase are the indexes of the bases: the
+	// minima between the peak and the nearest higher sample on each side.
+	Prominence          float64
+	LeftBase, RightBase int
+
+	// Width is the width of the peak at its width height, and LeftIP and
+	// RightIP are the interpolated positions where the signal crosses
+	// that height.
+	Width, WidthHeight float64
+	LeftIP, RightIP    float64
+}
+
+// FindPeaks returns the peaks of x, local maxima meeting the criteria of o,
+// in order of increasing index. A nil o returns all local maxima.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.find_peaks.html
+func FindPeaks(x []float64, o *PeakOptions) []Peak {
+	if o == nil {
+		o = &PeakOptions{}
+	}
+	if o.Distance < 0 || o.Wlen < 0 {
+		panic("distance and wlen must be non-negative")
+	}
+	relHeight := o.RelHeight
+	if relHeight == 0 {
+		relHeight = 0.5
+	}
+
+	var peaks []Peak
+	for _, i := range localMaxima(x) {
+		if o.Height != 0 && x[i] < o.Height {
+			continue
+		}
+		peaks = append(peaks, Peak{Index: i, Height: x[i]})
+	}
+	if o.Distance > 1 {
+		peaks = peakDistance(peaks, o.Distance)
+	}
+
+	r := peaks[:0]
+	for _, p := range peaks {
+		peakProminence(x, &p, o.Wlen)
+		if p.Prominence < o.Prominence {
+			continue
+		}
+		peakWidth(x, &p, relHeight)
+		if p.Width < o.Width {
+			continue
+		}
+		r = append(r, p)
+	}
+	return r
+}
+
+// localMaxima returns the indexes of the local maxima of x. Flat maxima
+// are reported at the middle of the plateau.
+func localMaxima(x []float64) []int {
+	var r []int
+	for i := 1; i < len(x)-1; i++ {
+		if x[i-1] >= x[i] {
+			continue
+		}
+		j := i
+		for j < len(x)-1 && x[j+1] == x[i] {
+			j++
+		}
+		if j < len(x)-1 && x[j+1] < x[i] {
+			r = append(r, (i+j)/2)
+		}
+		i = j
+	}
+	return r
+}
+
+// peakDistance removes the lower of any peaks closer than distance.
+func peakDistance(peaks []Peak, distance int) []Peak {
+	order := make([]int, len(peaks))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return peaks[order[a]].Height > peaks[order[b]].Height
+	})
+	keep := make([]bool, len(peaks))
+	for i := range keep {
+		keep[i] = true
+	}
+	for _, i := range order {
+		if !keep[i] {
+			continue
+		}
+		for j := i - 1; j >= 0 && peaks[i].Index-peaks[j].Index < distance; j-- {
+			keep[j] = false
+		}
+		for j := i + 1; j < len(peaks) && peaks[j].Index-peaks[i].Index < distance; j++ {
+			keep[j] = false
+		}
+	}
+	r := peaks[:0]
+	for i, p := range peaks {
+		if keep[i] {
+			r = append(r, p)
+		}
+	}
+	return r
+}
+
+// peakProminence sets the prominence and bases of p.
+func peakProminence(x []float64, p *Peak, wlen int) {
+	lo, hi := 0, len(x)-1
+	if wlen > 1 {
+		if l := p.Index - wlen/2; l > lo {
+			lo = l
+		}
+		if h := p.Index + wlen/2; h < hi {
+			hi = h
+		}
+	}
+
+	p.LeftBase = p.Index
+	leftMin := p.Height
+	for i := p.Index; i >= lo && x[i] <= p.Height; i-- {
+		if x[i] < leftMin {
+			leftMin, p.LeftBase = x[i], i
+		}
+	}
+	p.RightBase = p.Index
+	rightMin := p.Height
+	for i := p.Index; i <= hi && x[i] <= p.Height; i++ {
+		if x[i] < rightMin {
+			rightMin, p.RightBase = x[i], i
+		}
+	}
+
+	if leftMin > rightMin {
+		p.Prominence = p.Height - leftMin
+	} else {
+		p.Prominence = p.Height - rightMin
+	}
+}
+
+// peakWidth sets the width of p at relHeight of its prominence, which must
+// already be set.
+func peakWidth(x []float64, p *Peak, relHeight float64) {
+	h := p.Height - p.Prominence*relHeight
+	p.WidthHeight = h
+
+	i := p.Index
+	for i > p.LeftBase && x[i] > h {
+		i--
+	}
+	p.LeftIP = float64(i)
+	if x[i] < h {
+		p.LeftIP += (h - x[i]) / (x[i+1] - x[i])
+	}
+
+	i = p.Index
+	for i < p.RightBase && x[i] > h {
+		i++
+	}
+	p.RightIP = float64(i)
+	if x[i] < h {
+		p.RightIP -= (h - x[i]) / (x[i-1] - x[i])
+	}
+
+	p.Width = p.RightIP - p.LeftIP
+}
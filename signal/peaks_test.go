@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func peakIndexes(peaks []Peak) []int {
+	r := []int{}
+	for _, p := range peaks {
+		r = append(r, p.Index)
+	}
+	return r
+}
+
+func TestFindPeaks(t *testing.T) {
+	x := []float64{0, 1, 0, 2, 0, 3, 0, 2, 0, 1, 0}
+	tests := []struct {
+		in  []float64
+		o   *PeakOptions
+		out []int
+	}{
+		{x, nil, []int{1, 3, 5, 7, 9}},
+		{x, &PeakOptions{Height: 2}, []int{3, 5, 7}},
+		{x, &PeakOptions{Distance: 3}, []int{1, 5, 9}},
+		{x, &PeakOptions{Distance: 5}, []int{5}},
+		{x, &PeakOptions{Prominence: 2.5}, []int{5}},
+		{x, &PeakOptions{Width: 1.5}, []int{}},
+		// Plateaus, including at the edges.
+		{[]float64{1, 1, 0, 2, 2, 2, 0, 1, 3, 3}, nil, []int{4}},
+		{[]float64{0, 1, 1, 0, 5}, nil, []int{1}},
+		{[]float64{}, nil, []int{}},
+	}
+	for _, v := range tests {
+		o := peakIndexes(FindPeaks(v.in, v.o))
+		if !reflect.DeepEqual(o, v.out) {
+			t.Error("FindPeaks error\ninput:", v.in, v.o, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestPeakProperties(t *testing.T) {
+	x := []float64{0, 1, 0, 2, 0, 3, 0, 2, 0, 1, 0}
+	peaks := FindPeaks(x, nil)
+	var prom, width, left []float64
+	var bases []int
+	for _, p := range peaks {
+		prom = append(prom, p.Prominence)
+		width = append(width, p.Width)
+		left = append(left, p.LeftIP)
+		bases = append(bases, p.LeftBase, p.RightBase)
+	}
+	if e := []float64{1, 2, 3, 2, 1}; !dsputils.PrettyClose(prom, e) {
+		t.Error("prominence error\noutput:", prom, "\nexpected:", e)
+	}
+	if e := []float64{1, 1, 1, 1, 1}; !dsputils.PrettyClose(width, e) {
+		t.Error("width error\noutput:", width, "\nexpected:", e)
+	}
+	if e := []float64{0.5, 2.5, 4.5, 6.5, 8.5}; !dsputils.PrettyClose(left, e) {
+		t.Error("left ip error\noutput:", left, "\nexpected:", e)
+	}
+	if e := []int{0, 2, 2, 4, 4, 6, 6, 8, 8, 10}; !reflect.DeepEqual(bases, e) {
+		t.Error("bases error\noutput:", bases, "\nexpected:", e)
+	}
+
+	// A peak on a slope: the prominence is relative to the higher base, and
+	// the width is measured on the prominent part only.
+	x = []float64{0, 4, 2, 5, 3, 10}
+	p := FindPeaks(x, &PeakOptions{RelHeight: 1})
+	if len(p) != 2 {
+		t.Fatal("slope peaks error:", p)
+	}
+	if p[1].Prominence != 2 || p[1].WidthHeight != 3 || math.Abs(p[1].LeftIP-7.0/3) > 1e-12 || p[1].RightIP != 4 {
+		t.Error("slope peak error:", p[1])
+	}
+
+	// Limiting the base search window reduces the prominence.
+	x = []float64{0, 1, 2, 3, 2, 1, 0}
+	p = FindPeaks(x, &PeakOptions{Wlen: 3})
+	if len(p) != 1 || p[0].Prominence != 1 {
+		t.Error("wlen error:", p)
+	}
+}
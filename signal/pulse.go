@@ -0,0 +1,123 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+)
+
+// GaussPulse returns a Gaussian modulated sinusoid evaluated at the times t:
+// exp(-a*t^2)*cos(2*pi*fc*t), with center frequency fc in Hz. The
+// Gaussian envelope is chosen so that the spectrum falls to bwr dB (negative)
+// below its peak at a fractional bandwidth bw, i.e. over the frequencies
+// fc*(1 ± bw/2). Typical values are bw = 0.5 and bwr = -6.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.gausspulse.html
+func GaussPulse(t []float64, fc, bw, bwr float64) []float64 {
+	a := gaussPulseA(fc, bw, bwr)
+	r := make([]float64, len(t))
+	for i, v := range t {
+		r[i] = math.Exp(-a*v*v) * math.Cos(2*math.Pi*fc*v)
+	}
+	return r
+}
+
+// GaussPulseEnvelope is like GaussPulse, but returns the envelope
+// exp(-a*t^2) of the pulse.
+func GaussPulseEnvelope(t []float64, fc, bw, bwr float64) []float64 {
+	a := gaussPulseA(fc, bw, bwr)
+	r := make([]float64, len(t))
+	for i, v := range t {
+		r[i] = math.Exp(-a * v * v)
+	}
+	return r
+}
+
+// GaussPulseCutoff returns the time at which the envelope of the pulse
+// described by fc, bw and bwr falls to tpr dB (negative), such as -60. The
+// pulse may be truncated to [-cutoff, cutoff].
+func GaussPulseCutoff(fc, bw, bwr, tpr float64) float64 {
+	if tpr >= 0 {
+		panic("tpr must be negative")
+	}
+	a := gaussPulseA(fc, bw, bwr)
+	tref := math.Pow(10, tpr/20)
+	return math.Sqrt(-math.Log(tref) / a)
+}
+
+func gaussPulseA(fc, bw, bwr float64) float64 {
+	if fc <= 0 {
+		panic("fc must be positive")
+	}
+	if bw <= 0 {
+		panic("bw must be positive")
+	}
+	if bwr >= 0 {
+		panic("bwr must be negative")
+	}
+	ref := math.Pow(10, bwr/20)
+	return -(math.Pi * fc * bw) * (math.Pi * fc * bw) / (4 * math.Log(ref))
+}
+
+// Impulse returns n samples of a unit impulse: 1 at index idx and 0
+// elsewhere.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.unit_impulse.html
+func Impulse(n, idx int) []float64 {
+	if idx < 0 || idx >= n {
+		panic("idx out of range")
+	}
+	r := make([]float64, n)
+	r[idx] = 1
+	return r
+}
+
+// ImpulseTrain returns n samples of a train of unit impulses every period
+// samples, the first at index offset.
+func ImpulseTrain(n, period, offset int) []float64 {
+	if period < 1 {
+		panic("period must be positive")
+	}
+	if offset < 0 {
+		panic("offset must be non-negative")
+	}
+	r := make([]float64, n)
+	for i := offset; i < n; i += period {
+		r[i] = 1
+	}
+	return r
+}
+
+// PulseTrain returns n samples of copies of pulse starting every period
+// samples, the first at index offset. Overlapping copies are summed. With a
+// GaussPulse as the pulse it simulates a radar or ultrasound transmission.
+func PulseTrain(pulse []float64, n, period, offset int) []float64 {
+	if period < 1 {
+		panic("period must be positive")
+	}
+	if offset < 0 {
+		panic("offset must be non-negative")
+	}
+	r := make([]float64, n)
+	for start := offset; start < n; start += period {
+		for i, v := range pulse {
+			if start+i >= n {
+				break
+			}
+			r[start+i] += v
+		}
+	}
+	return r
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+func TestGaussPulse(t *testing.T) {
+	const fc, bw, bwr, fs = 1000.0, 0.5, -6.0, 64000.0
+
+	cut := GaussPulseCutoff(fc, bw, bwr, -60)
+	env := GaussPulseEnvelope([]float64{0, cut}, fc, bw, bwr)
+	if e := []float64{1, 0.001}; !dsputils.PrettyClose(env, e) {
+		t.Error("GaussPulseCutoff error\noutput:", env, "\nexpected:", e)
+	}
+
+	// The spectrum is bwr dB down at fc*(1 ± bw/2).
+	n := 1 << 14
+	ts := make([]float64, n)
+	for i := range ts {
+		ts[i] = float64(i-n/2) / fs
+	}
+	X := fft.FFTReal(GaussPulse(ts, fc, bw, bwr))
+	mag := func(f float64) float64 {
+		return cmplx.Abs(X[int(math.Round(f*float64(n)/fs))])
+	}
+	for _, f := range []float64{fc * (1 - bw/2), fc * (1 + bw/2)} {
+		if g := 20 * math.Log10(mag(f)/mag(fc)); math.Abs(g-bwr) > 0.2 {
+			t.Error("GaussPulse bandwidth error at", f, ":", g)
+		}
+	}
+}
+
+func TestImpulseTrain(t *testing.T) {
+	if o, e := Impulse(4, 1), []float64{0, 1, 0, 0}; !dsputils.PrettyClose(o, e) {
+		t.Error("Impulse error\noutput:", o, "\nexpected:", e)
+	}
+	if o, e := ImpulseTrain(8, 3, 1), []float64{0, 1, 0, 0, 1, 0, 0, 1}; !dsputils.PrettyClose(o, e) {
+		t.Error("ImpulseTrain error\noutput:", o, "\nexpected:", e)
+	}
+	if o, e := PulseTrain([]float64{1, 2, 3}, 7, 2, 0), []float64{1, 2, 4, 2, 4, 2, 4}; !dsputils.PrettyClose(o, e) {
+		t.Error("PulseTrain error\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,165 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+)
+
+// StreamPercentile estimates a percentile of a stream of values in constant
+// memory with the P² algorithm, which tracks five markers whose heights are
+// adjusted by piecewise parabolic interpolation as values arrive.
+// Reference: Jain and Chlamtac, "The P² algorithm for dynamic calculation of
+// quantiles and histograms without storing observations", Communications of
+// the ACM 28(10), 1985.
+type StreamPercentile struct {
+	p     float64
+	q     [5]float64 // marker heights
+	n     [5]float64 // marker positions
+	np    [5]float64 // desired marker positions
+	dn    [5]float64 // increments of the desired positions
+	count int
+}
+
+// NewStreamPercentile returns a StreamPercentile estimating the pth
+// percentile, for p in [0, 100].
+func NewStreamPercentile(p float64) *StreamPercentile {
+	if p < 0 || p > 100 {
+		panic("percentile out of range")
+	}
+	s := &StreamPercentile{p: p / 100}
+	s.Reset()
+	return s
+}
+
+// Add adds the values x to the stream.
+func (s *StreamPercentile) Add(x ...float64) {
+	for _, v := range x {
+		s.add(v)
+	}
+}
+
+func (s *StreamPercentile) add(x float64) {
+	q, n := &s.q, &s.n
+	if s.count < 5 {
+		// Insertion sort the first five values.
+		i := s.count
+		for ; i > 0 && q[i-1] > x; i-- {
+			q[i] = q[i-1]
+		}
+		q[i] = x
+		s.count++
+		return
+	}
+	s.count++
+
+	var k int
+	switch {
+	case x < q[0]:
+		q[0] = x
+		k = 0
+	case x >= q[4]:
+		q[4] = x
+		k = 3
+	default:
+		for k = 0; k < 3 && x >= q[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		n[i]++
+	}
+	for i := range s.np {
+		s.np[i] += s.dn[i]
+	}
+
+	for i := 1; i < 4; i++ {
+		d := s.np[i] - n[i]
+		if (d >= 1 && n[i+1]-n[i] > 1) || (d <= -1 && n[i-1]-n[i] < -1) {
+			ds := math.Copysign(1, d)
+			h := q[i] + ds/(n[i+1]-n[i-1])*((n[i]-n[i-1]+ds)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+				(n[i+1]-n[i]-ds)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+			if q[i-1] < h && h < q[i+1] {
+				q[i] = h
+			} else {
+				j := i + int(ds)
+				q[i] += ds * (q[j] - q[i]) / (n[j] - n[i])
+			}
+			n[i] += ds
+		}
+	}
+}
+
+// Value returns the current estimate, which is exact for up to five values.
+// It returns NaN if no values have been added.
+func (s *StreamPercentile) Value() float64 {
+	if s.count == 0 {
+		return math.NaN()
+	}
+	if s.count <= 5 {
+		return sortedPercentile(s.q[:s.count], s.p*100)
+	}
+	return s.q[2]
+}
+
+// Reset clears the estimate, as at creation.
+func (s *StreamPercentile) Reset() {
+	p := s.p
+	s.count = 0
+	s.n = [5]float64{0, 1, 2, 3, 4}
+	s.np = [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4}
+	s.dn = [5]float64{0, p / 2, p, (1 + p) / 2, 1}
+}
+
+// StreamMAD estimates the median absolute deviation of a stream of values
+// in constant memory. It tracks the median with a StreamPercentile, and the
+// median of the distances from the running median estimate with another, so
+// it converges to MAD once the median estimate settles.
+type StreamMAD struct {
+	med, dev *StreamPercentile
+}
+
+// NewStreamMAD returns a new StreamMAD.
+func NewStreamMAD() *StreamMAD {
+	return &StreamMAD{
+		med: NewStreamPercentile(50),
+		dev: NewStreamPercentile(50),
+	}
+}
+
+// Add adds the values x to the stream.
+func (m *StreamMAD) Add(x ...float64) {
+	for _, v := range x {
+		m.med.add(v)
+		m.dev.add(math.Abs(v - m.med.Value()))
+	}
+}
+
+// Median returns the current estimate of the median.
+func (m *StreamMAD) Median() float64 {
+	return m.med.Value()
+}
+
+// Value returns the current estimate of the median absolute deviation.
+func (m *StreamMAD) Value() float64 {
+	return m.dev.Value()
+}
+
+// Reset clears the estimates, as at creation.
+func (m *StreamMAD) Reset() {
+	m.med.Reset()
+	m.dev.Reset()
+}
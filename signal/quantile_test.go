@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"testing"
+)
+
+func TestStreamPercentile(t *testing.T) {
+	x := WhiteGaussian(1, 1, 20000)
+	for _, p := range []float64{5, 50, 95} {
+		s := NewStreamPercentile(p)
+		s.Add(x...)
+		if o, e := s.Value(), Percentile(x, p); math.Abs(o-e) > 0.05 {
+			t.Errorf("StreamPercentile(%v): got %v, expected %v", p, o, e)
+		}
+	}
+
+	// Up to five values, the estimate is exact.
+	s := NewStreamPercentile(25)
+	if v := s.Value(); !math.IsNaN(v) {
+		t.Error("empty StreamPercentile: got", v, "expected NaN")
+	}
+	y := []float64{4, 2, 8, 6}
+	s.Add(y...)
+	if o, e := s.Value(), Percentile(y, 25); o != e {
+		t.Errorf("StreamPercentile: got %v, expected %v", o, e)
+	}
+	s.Reset()
+	s.Add(1)
+	if o := s.Value(); o != 1 {
+		t.Errorf("StreamPercentile after Reset: got %v, expected 1", o)
+	}
+}
+
+func TestStreamMAD(t *testing.T) {
+	x := WhiteGaussian(1, 2, 20000)
+	// Add outliers, which MAD should ignore.
+	for i := 0; i < len(x); i += 1000 {
+		x[i] = 100
+	}
+	m := NewStreamMAD()
+	m.Add(x...)
+	if o, e := m.Median(), Median(x); math.Abs(o-e) > 0.05 {
+		t.Errorf("StreamMAD.Median: got %v, expected %v", o, e)
+	}
+	if o, e := m.Value(), MAD(x); math.Abs(o-e) > 0.05 {
+		t.Errorf("StreamMAD: got %v, expected %v", o, e)
+	}
+	if sigma := 1.4826 * MAD(x); math.Abs(sigma-1) > 0.05 {
+		t.Errorf("MAD sigma: got %v, expected 1", sigma)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package signal provides signal generators for testing and measurement,
+// and tools for analyzing signals such as peak finding.
+package signal
+
+import (
+	"math"
+)
+
+// Generator is a streaming periodic waveform generator. It keeps its phase
+// between calls, so a signal may be generated in consecutive chunks, and
+// changes of frequency are phase continuous.
+type Generator struct {
+	freq, amp, fs float64
+	phase         float64 // current phase, in cycles in [0, 1)
+	shape         func(p float64) float64
+}
+
+func newGenerator(freq, amp, phase, fs float64, shape func(float64) float64) *Generator {
+	if fs <= 0 {
+		panic("fs must be positive")
+	}
+	g := &Generator{
+		freq:  freq,
+		amp:   amp,
+		fs:    fs,
+		shape: shape,
+	}
+	g.phase = wrap(phase / (2 * math.Pi))
+	return g
+}
+
+// NewSine returns a generator of a sine wave of frequency freq (in the same
+// units as the sampling frequency fs), amplitude amp and initial phase
+// phase, in radians: amp*sin(2*pi*freq*t + phase).
+func NewSine(freq, amp, phase, fs float64) *Generator {
+	return newGenerator(freq, amp, phase, fs, func(p float64) float64 {
+		return math.Sin(2 * math.Pi * p)
+	})
+}
+
+// NewSquare returns a generator of a square wave that is amp for the
+// fraction duty of each period, and -amp for the remainder. Other parameters
+// are as in NewSine.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.square.html
+func NewSquare(freq, amp, phase, duty, fs float64) *Generator {
+	if duty < 0 || duty > 1 {
+		panic("duty must be between 0 and 1")
+	}
+	return newGenerator(freq, amp, phase, fs, func(p float64) float64 {
+		if p < duty {
+			return 1
+		}
+		return -1
+	})
+}
+
+// NewSawtooth returns a generator of a sawtooth wave that rises from -amp to
+// amp over each period. Other parameters are as in NewSine.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.sawtooth.html
+func NewSawtooth(freq, amp, phase, fs float64) *Generator {
+	return newGenerator(freq, amp, phase, fs, func(p float64) float64 {
+		return 2*p - 1
+	})
+}
+
+// NewTriangle returns a generator of a triangle wave that rises from -amp to
+// amp over the first half of each period and falls back over the second.
+// Other parameters are as in NewSine.
+func NewTriangle(freq, amp, phase, fs float64) *Generator {
+	return newGenerator(freq, amp, phase, fs, func(p float64) float64 {
+		if p < 0.5 {
+			return 4*p - 1
+		}
+		return 3 - 4*p
+	})
+}
+
+// Next returns the next sample.
+func (g *Generator) Next() float64 {
+	v := g.amp * g.shape(g.phase)
+	g.phase = wrap(g.phase + g.freq/g.fs)
+	return v
+}
+
+// Read fills x with the next len(x) samples.
+func (g *Generator) Read(x []float64) {
+	for i := range x {
+		x[i] = g.Next()
+	}
+}
+
+// ReadFloat64s implements dsputils.SampleReader. It fills p and never
+// returns an error, since the signal is endless.
+func (g *Generator) ReadFloat64s(p []float64) (int, error) {
+	g.Read(p)
+	return len(p), nil
+}
+
+// Generate returns the next n samples.
+func (g *Generator) Generate(n int) []float64 {
+	x := make([]float64, n)
+	g.Read(x)
+	return x
+}
+
+// SetFreq changes the frequency, keeping the phase continuous.
+func (g *Generator) SetFreq(freq float64) {
+	g.freq = freq
+}
+
+// SetAmp changes the amplitude.
+func (g *Generator) SetAmp(amp float64) {
+	g.amp = amp
+}
+
+// wrap returns p modulo 1, in [0, 1).
+func wrap(p float64) float64 {
+	p -= math.Floor(p)
+	if p >= 1 {
+		p = 0
+	}
+	return p
+}
+
+// Sine returns n samples of a sine wave, as generated by NewSine.
+func Sine(freq, amp, phase, fs float64, n int) []float64 {
+	return NewSine(freq, amp, phase, fs).Generate(n)
+}
+
+// Square returns n samples of a square wave, as generated by NewSquare.
+func Square(freq, amp, phase, duty, fs float64, n int) []float64 {
+	return NewSquare(freq, amp, phase, duty, fs).Generate(n)
+}
+
+// Sawtooth returns n samples of a sawtooth wave, as generated by
+// NewSawtooth.
+func Sawtooth(freq, amp, phase, fs float64, n int) []float64 {
+	return NewSawtooth(freq, amp, phase, fs).Generate(n)
+}
+
+// Triangle returns n samples of a triangle wave, as generated by
+// NewTriangle.
+func Triangle(freq, amp, phase, fs float64, n int) []float64 {
+	return NewTriangle(freq, amp, phase, fs).Generate(n)
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type waveTest struct {
+	name string
+	out  []float64
+	exp  []float64
+}
+
+var waveTests = []waveTest{
+	{
+		"Sine",
+		Sine(1, 2, 0, 4, 6),
+		[]float64{0, 2, 0, -2, 0, 2},
+	},
+	{
+		"Sine phase",
+		Sine(1, 1, math.Pi/2, 4, 4),
+		[]float64{1, 0, -1, 0},
+	},
+	{
+		"Square",
+		Square(1, 1, 0, 0.5, 4, 8),
+		[]float64{1, 1, -1, -1, 1, 1, -1, -1},
+	},
+	{
+		"Square duty",
+		Square(1, 3, 0, 0.25, 8, 8),
+		[]float64{3, 3, -3, -3, -3, -3, -3, -3},
+	},
+	{
+		"Sawtooth",
+		Sawtooth(1, 1, 0, 4, 5),
+		[]float64{-1, -0.5, 0, 0.5, -1},
+	},
+	{
+		"Sawtooth phase",
+		Sawtooth(1, 1, math.Pi, 4, 4),
+		[]float64{0, 0.5, -1, -0.5},
+	},
+	{
+		"Triangle",
+		Triangle(1, 1, 0, 8, 9),
+		[]float64{-1, -0.5, 0, 0.5, 1, 0.5, 0, -0.5, -1},
+	},
+}
+
+func TestWaveforms(t *testing.T) {
+	for _, wt := range waveTests {
+		if !dsputils.PrettyClose(wt.out, wt.exp) {
+			t.Error(wt.name, "error\noutput:", wt.out, "\nexpected:", wt.exp)
+		}
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	// Chunks continue where the previous one ended.
+	g := NewSine(440, 1, 0.3, 48000)
+	o := append(g.Generate(100), g.Generate(50)...)
+	e := Sine(440, 1, 0.3, 48000, 150)
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Generator chunk error\noutput:", o, "\nexpected:", e)
+	}
+
+	// Frequency changes are phase continuous.
+	g = NewSine(1, 1, 0, 8)
+	x := make([]float64, 2)
+	g.Read(x)
+	g.SetFreq(2)
+	g.SetAmp(2)
+	o = append(x, g.Generate(3)...)
+	e = []float64{0, math.Sqrt2 / 2, 2, 0, -2}
+	if !dsputils.PrettyClose(o, e) {
+		t.Error("Generator SetFreq error\noutput:", o, "\nexpected:", e)
+	}
+
+	// Generators are sample readers.
+	var r dsputils.SampleReader = NewSine(440, 1, 0.3, 48000)
+	x = make([]float64, 150)
+	if n, err := r.ReadFloat64s(x); n != 150 || err != nil {
+		t.Error("Generator ReadFloat64s error:", n, err)
+	}
+	e = Sine(440, 1, 0.3, 48000, 150)
+	if !dsputils.PrettyClose(x, e) {
+		t.Error("Generator ReadFloat64s error\noutput:", x, "\nexpected:", e)
+	}
+}
@@ -0,0 +1,222 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"sort"
+)
+
+// Histogram counts the values of x in bins equal width bins spanning
+// [lo, hi], and returns the counts and the bins+1 bin edges. The last bin
+// includes hi; values outside the range and NaNs are not counted. If
+// lo >= hi, the range of x is used.
+// Reference: https://numpy.org/doc/stable/reference/generated/numpy.histogram.html
+func Histogram(x []float64, bins int, lo, hi float64) (counts []int, edges []float64) {
+	if lo >= hi {
+		lo, hi = valueRange(x)
+	}
+
+	h := NewStreamHistogram(bins, lo, hi)
+	h.Add(x...)
+	return h.Counts(), h.Edges()
+}
+
+// valueRange returns the range of x, widened by 0.5 on either side if it is
+// empty, as numpy does.
+func valueRange(x []float64) (lo, hi float64) {
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range x {
+		if v < lo {
+			lo = v
+		}
+		if v > hi {
+			hi = v
+		}
+	}
+	if lo > hi {
+		return 0, 1
+	}
+	if lo == hi {
+		return lo - 0.5, hi + 0.5
+	}
+	return lo, hi
+}
+
+// Percentile returns the pth percentile of x, for p in [0, 100], linearly
+// interpolating between the closest ranks like numpy's default method. x is
+// not modified.
+// Reference: https://numpy.org/doc/stable/reference/generated/numpy.percentile.html
+func Percentile(x []float64, p float64) float64 {
+	return Percentiles(x, p)[0]
+}
+
+// Percentiles returns the percentiles p of x, as Percentile, sorting x only
+// once.
+func Percentiles(x []float64, p ...float64) []float64 {
+	if len(x) == 0 {
+		panic("x must not be empty")
+	}
+	s := make([]float64, len(x))
+	copy(s, x)
+	sort.Float64s(s)
+
+	r := make([]float64, len(p))
+	for i, v := range p {
+		r[i] = sortedPercentile(s, v)
+	}
+	return r
+}
+
+func sortedPercentile(s []float64, p float64) float64 {
+	if p < 0 || p > 100 {
+		panic("percentile out of range")
+	}
+	pos := p / 100 * float64(len(s)-1)
+	i := int(pos)
+	if i >= len(s)-1 {
+		return s[len(s)-1]
+	}
+	frac := pos - float64(i)
+	return s[i] + frac*(s[i+1]-s[i])
+}
+
+// Median returns the median of x.
+func Median(x []float64) float64 {
+	return Percentile(x, 50)
+}
+
+// MAD returns the median absolute deviation of x, the median of the
+// distances of x from its median. It is a robust measure of spread: for
+// Gaussian noise, 1.4826*MAD estimates the standard deviation, unaffected by
+// a few large outliers such as signal peaks.
+// Reference: https://en.wikipedia.org/wiki/Median_absolute_deviation
+func MAD(x []float64) float64 {
+	m := Median(x)
+	d := make([]float64, len(x))
+	for i, v := range x {
+		d[i] = math.Abs(v - m)
+	}
+	return Median(d)
+}
+
+// StreamHistogram is a Histogram accumulated over a stream of values, with
+// a fixed range.
+type StreamHistogram struct {
+	lo, hi      float64
+	counts      []int
+	under, over int
+}
+
+// NewStreamHistogram returns a StreamHistogram with bins equal width bins
+// spanning [lo, hi].
+func NewStreamHistogram(bins int, lo, hi float64) *StreamHistogram {
+	if bins < 1 {
+		panic("bins must be positive")
+	}
+	if !(lo < hi) {
+		panic("lo must be less than hi")
+	}
+	return &StreamHistogram{
+		lo:     lo,
+		hi:     hi,
+		counts: make([]int, bins),
+	}
+}
+
+// Add counts the values x.
+func (h *StreamHistogram) Add(x ...float64) {
+	nb := len(h.counts)
+	scale := float64(nb) / (h.hi - h.lo)
+	for _, v := range x {
+		switch {
+		case v < h.lo:
+			h.under++
+		case v > h.hi:
+			h.over++
+		case v == h.hi:
+			h.counts[nb-1]++
+		case v >= h.lo:
+			b := int((v - h.lo) * scale)
+			if b >= nb {
+				b = nb - 1
+			}
+			h.counts[b]++
+		}
+	}
+}
+
+// Counts returns the number of values in each bin.
+func (h *StreamHistogram) Counts() []int {
+	r := make([]int, len(h.counts))
+	copy(r, h.counts)
+	return r
+}
+
+// Edges returns the len(Counts())+1 bin edges.
+func (h *StreamHistogram) Edges() []float64 {
+	nb := len(h.counts)
+	e := make([]float64, nb+1)
+	for i := range e {
+		e[i] = h.lo + (h.hi-h.lo)*float64(i)/float64(nb)
+	}
+	e[nb] = h.hi
+	return e
+}
+
+// Outside returns the number of values below and above the range.
+func (h *StreamHistogram) Outside() (under, over int) {
+	return h.under, h.over
+}
+
+// Percentile estimates the pth percentile, for p in [0, 100], of the values
+// added, assuming they are spread uniformly within each bin. Values outside
+// the range count as lo or hi. It returns NaN if no values have been added.
+func (h *StreamHistogram) Percentile(p float64) float64 {
+	if p < 0 || p > 100 {
+		panic("percentile out of range")
+	}
+	total := h.under + h.over
+	for _, c := range h.counts {
+		total += c
+	}
+	if total == 0 {
+		return math.NaN()
+	}
+
+	rank := p / 100 * float64(total)
+	cum := float64(h.under)
+	if rank <= cum {
+		return h.lo
+	}
+	w := (h.hi - h.lo) / float64(len(h.counts))
+	for i, c := range h.counts {
+		if c > 0 && rank <= cum+float64(c) {
+			return h.lo + w*(float64(i)+(rank-cum)/float64(c))
+		}
+		cum += float64(c)
+	}
+	return h.hi
+}
+
+// Reset clears the counts, as at creation.
+func (h *StreamHistogram) Reset() {
+	for i := range h.counts {
+		h.counts[i] = 0
+	}
+	h.under, h.over = 0, 0
+}
@@ -0,0 +1,88 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package signal
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestHistogram(t *testing.T) {
+	tests := []struct {
+		x      []float64
+		bins   int
+		lo, hi float64
+		counts []int
+		edges  []float64
+	}{
+		{[]float64{1, 2, 2, 3, 3, 3, 4}, 3, 0, 0, []int{1, 2, 4}, []float64{1, 2, 3, 4}},
+		{[]float64{-1, 0, 0.5, 1, 2, math.NaN()}, 2, 0, 1, []int{1, 2}, []float64{0, 0.5, 1}},
+		{[]float64{5, 5}, 2, 0, 0, []int{0, 2}, []float64{4.5, 5, 5.5}},
+	}
+	for _, v := range tests {
+		c, e := Histogram(v.x, v.bins, v.lo, v.hi)
+		if !reflect.DeepEqual(c, v.counts) || !dsputils.PrettyClose(e, v.edges) {
+			t.Error("Histogram error\ninput:", v.x, "\noutput:", c, e, "\nexpected:", v.counts, v.edges)
+		}
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	x := []float64{3, 1, 4, 1, 5, 9, 2, 6}
+	p := Percentiles(x, 0, 25, 50, 90, 100)
+	if e := []float64{1, 1.75, 3.5, 6.9, 9}; !dsputils.PrettyClose(p, e) {
+		t.Error("Percentiles error\ninput:", x, "\noutput:", p, "\nexpected:", e)
+	}
+	if m := Median(x); m != 3.5 {
+		t.Error("Median error\ninput:", x, "\noutput:", m, "\nexpected:", 3.5)
+	}
+	if m := MAD(x); m != 2 {
+		t.Error("MAD error\ninput:", x, "\noutput:", m, "\nexpected:", 2)
+	}
+	if x[0] != 3 {
+		t.Error("Percentiles modified its input")
+	}
+}
+
+func TestStreamHistogram(t *testing.T) {
+	x := WhiteUniform(1, 1, 10000)
+	h := NewStreamHistogram(20, -1, 1)
+	for i := 0; i < len(x); i += 100 {
+		h.Add(x[i : i+100]...)
+	}
+	c, _ := Histogram(x, 20, -1, 1)
+	if o := h.Counts(); !reflect.DeepEqual(o, c) {
+		t.Error("StreamHistogram error\noutput:", o, "\nexpected:", c)
+	}
+	for _, p := range []float64{10, 50, 90} {
+		if o, e := h.Percentile(p), Percentile(x, p); math.Abs(o-e) > 0.01 {
+			t.Errorf("StreamHistogram.Percentile(%v): got %v, expected %v", p, o, e)
+		}
+	}
+
+	h.Add(-2, 2, 3)
+	if u, o := h.Outside(); u != 1 || o != 2 {
+		t.Errorf("Outside: got %v, %v, expected 1, 2", u, o)
+	}
+	h.Reset()
+	if p := h.Percentile(50); !math.IsNaN(p) {
+		t.Error("Percentile after Reset: got", p, "expected NaN")
+	}
+}
@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// ARMethod is a method of estimating autoregressive model parameters.
+type ARMethod int
+
+const (
+	// YuleWalker solves the Yule-Walker equations of the biased
+	// autocorrelation, giving a stable model whose resolution is limited by
+	// the implied windowing of the data.
+	YuleWalker ARMethod = iota
+
+	// Burg minimizes the forward and backward prediction errors, which
+	// resolves closely spaced peaks better for short records.
+	Burg
+)
+
+// AR returns the coefficients a of the order p autoregressive model of x,
+// with a[0] = 1, such that x[n] + a[1]x[n-1] + ... + a[p]x[n-p] is white
+// noise of variance sigma2, estimated with method m.
+// Reference: http://www.mathworks.com/help/signal/ref/aryule.html
+// See also: http://www.mathworks.com/help/signal/ref/arburg.html
+func AR(x []float64, p int, m ARMethod) (a []float64, sigma2 float64) {
+	a, errs := ar(x, p, m)
+	return a, errs[p]
+}
+
+// ar returns the order p model of x and the prediction error variances of
+// orders 0 through p.
+func ar(x []float64, p int, m ARMethod) (a, errs []float64) {
+	if p < 0 || p >= len(x) {
+		panic("order must be between 0 and len(x)-1")
+	}
+	switch m {
+	case YuleWalker:
+		return levinson(autocorr(x, p))
+	case Burg:
+		return burg(x, p)
+	}
+	panic("unknown method")
+}
+
+// autocorr returns the biased autocorrelation of x at lags 0 through p.
+func autocorr(x []float64, p int) []float64 {
+	r := make([]float64, p+1)
+	for k := range r {
+		for i := k; i < len(x); i++ {
+			r[k] += x[i] * x[i-k]
+		}
+		r[k] /= float64(len(x))
+	}
+	return r
+}
+
+// levinson solves the Yule-Walker equations for the autocorrelation r with
+// the Levinson-Durbin recursion.
+func levinson(r []float64) (a, errs []float64) {
+	p := len(r) - 1
+	a = make([]float64, 1, p+1)
+	a[0] = 1
+	errs = make([]float64, p+1)
+	e := r[0]
+	errs[0] = e
+	for k := 1; k <= p; k++ {
+		acc := r[k]
+		for j := 1; j < k; j++ {
+			acc += a[j] * r[k-j]
+		}
+		a = append(a, 0)
+		a = reflect(a, -acc/e)
+		e *= 1 - a[k]*a[k]
+		errs[k] = e
+	}
+	return a, errs
+}
+
+// reflect updates the order len(a)-2 model a, extended with a zero, with
+// the reflection coefficient k.
+func reflect(a []float64, k float64) []float64 {
+	n := len(a) - 1
+	b := make([]float64, len(a))
+	for j := range a {
+		b[j] = a[j] + k*a[n-j]
+	}
+	return b
+}
+
+// burg returns the model of x from Burg's method.
+func burg(x []float64, p int) (a, errs []float64) {
+	ef := append([]float64(nil), x...)
+	eb := append([]float64(nil), x...)
+	a = []float64{1}
+	errs = make([]float64, p+1)
+	var e float64
+	for _, v := range x {
+		e += v * v
+	}
+	e /= float64(len(x))
+	errs[0] = e
+
+	for m := 1; m <= p; m++ {
+		efp, ebp := ef[1:], eb[:len(eb)-1]
+		var num, den float64
+		for i := range efp {
+			num += ebp[i] * efp[i]
+			den += efp[i]*efp[i] + ebp[i]*ebp[i]
+		}
+		k := -2 * num / den
+		for i := range efp {
+			efp[i], ebp[i] = efp[i]+k*ebp[i], ebp[i]+k*efp[i]
+		}
+		ef, eb = efp, ebp
+		a = reflect(append(a, 0), k)
+		e *= 1 - k*k
+		errs[m] = e
+	}
+	return a, errs
+}
+
+// AROrder returns the order, up to maxOrder, of the autoregressive model of
+// x estimated with method m that minimizes Akaike's information criterion,
+// N ln(sigma2) + 2p.
+// Reference: http://dx.doi.org/10.1109/TAC.1974.1100705
+func AROrder(x []float64, maxOrder int, m ARMethod) int {
+	_, errs := ar(x, maxOrder, m)
+	best, order := math.Inf(1), 0
+	for p, e := range errs {
+		if aic := float64(len(x))*math.Log(e) + 2*float64(p); aic < best {
+			best, order = aic, p
+		}
+	}
+	return order
+}
+
+// ARPSD returns the one-sided power spectral density of the autoregressive
+// model a with noise variance sigma2, sigma2 / |A(f)|^2, at nfft/2+1
+// frequencies from 0 to Fs/2, scaled like Pwelch.
+func ARPSD(a []float64, sigma2 float64, nfft int, Fs float64) (Pxx, freqs []float64) {
+	if nfft < len(a) {
+		panic("nfft must be at least len(a)")
+	}
+	A := fft.FFTReal(dsputils.ZeroPadF(a, nfft))
+	lp := nfft/2 + 1
+	Pxx = make([]float64, lp)
+	freqs = make([]float64, lp)
+	for i := range Pxx {
+		m := cmplx.Abs(A[i])
+		Pxx[i] = sigma2 / (m * m) / Fs
+		if i > 0 && 2*i != nfft {
+			Pxx[i] *= 2
+		}
+		freqs[i] = float64(i) * Fs / float64(nfft)
+	}
+	return
+}
+
+// Pyulear returns the power spectral density of x, sampled at Fs, from its
+// order p autoregressive model estimated with the Yule-Walker method, at
+// nfft/2+1 frequencies.
+// Reference: http://www.mathworks.com/help/signal/ref/pyulear.html
+func Pyulear(x []float64, p, nfft int, Fs float64) (Pxx, freqs []float64) {
+	a, e := AR(x, p, YuleWalker)
+	return ARPSD(a, e, nfft, Fs)
+}
+
+// Pburg is like Pyulear, but uses Burg's method.
+// Reference: http://www.mathworks.com/help/signal/ref/pburg.html
+func Pburg(x []float64, p, nfft int, Fs float64) (Pxx, freqs []float64) {
+	a, e := AR(x, p, Burg)
+	return ARPSD(a, e, nfft, Fs)
+}
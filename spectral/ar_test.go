@@ -0,0 +1,108 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// ar2 returns n samples of x[n] = 1.5x[n-1] - 0.75x[n-2] + e[n] with unit
+// variance e.
+func ar2(n int, seed int64) []float64 {
+	r := rand.New(rand.NewSource(seed))
+	x := make([]float64, n+100)
+	for i := 2; i < len(x); i++ {
+		x[i] = 1.5*x[i-1] - 0.75*x[i-2] + r.NormFloat64()
+	}
+	return x[100:]
+}
+
+func TestAR(t *testing.T) {
+	x := ar2(10000, 1)
+	e := []float64{1, -1.5, 0.75}
+	for _, m := range []ARMethod{YuleWalker, Burg} {
+		a, sigma2 := AR(x, 2, m)
+		for i := range a {
+			if math.Abs(a[i]-e[i]) > 0.03 {
+				t.Errorf("AR method %v: got %v, expected %v", m, a, e)
+				break
+			}
+		}
+		if math.Abs(sigma2-1) > 0.05 {
+			t.Errorf("AR method %v: got sigma2 %v, expected 1", m, sigma2)
+		}
+		if p := AROrder(x, 10, m); p != 2 {
+			t.Errorf("AROrder method %v: got %v, expected 2", m, p)
+		}
+	}
+
+	// A short record, checked against a direct solution of the Yule-Walker
+	// equations, and the recursion of MATLAB's arburg.
+	y := []float64{1, 2, 3, 4, 5, 4, 3, 2, 1, 0}
+	tests := []struct {
+		m      ARMethod
+		a      []float64
+		sigma2 float64
+	}{
+		{YuleWalker, []float64{1, -1.6484848484848487, 0.7515151515151519}, 0.4224242424242437},
+		{Burg, []float64{1, -1.7200861497078364, 0.8168409956289022}, 0.2932439891549318},
+	}
+	for _, v := range tests {
+		a, sigma2 := AR(y, 2, v.m)
+		if !dsputils.PrettyClose(a, v.a) || !dsputils.Float64Equal(sigma2, v.sigma2) {
+			t.Error("AR error\ninput:", y, v.m, "\noutput:", a, sigma2, "\nexpected:", v.a, v.sigma2)
+		}
+	}
+}
+
+func TestARPSD(t *testing.T) {
+	a := []float64{1, -1.5, 0.75}
+	Pxx, freqs := ARPSD(a, 2, 8, 4)
+	for i, f := range freqs {
+		w := 2 * math.Pi * f / 4
+		re := 1 - 1.5*math.Cos(w) + 0.75*math.Cos(2*w)
+		im := 1.5*math.Sin(w) - 0.75*math.Sin(2*w)
+		e := 2 / (re*re + im*im) / 4
+		if i > 0 && i < 4 {
+			e *= 2
+		}
+		if !dsputils.Float64Equal(Pxx[i], e) {
+			t.Errorf("ARPSD at %v: got %v, expected %v", f, Pxx[i], e)
+		}
+	}
+
+	// The spectrum peaks near the angle of the model's poles,
+	// acos(1.5 / (2 * sqrt(0.75))) / (2 * pi) cycles per sample.
+	x := ar2(4096, 2)
+	peak := math.Acos(1.5/(2*math.Sqrt(0.75))) / (2 * math.Pi)
+	for _, p := range []func([]float64, int, int, float64) ([]float64, []float64){Pyulear, Pburg} {
+		P, f := p(x, 2, 1024, 1)
+		best := 0
+		for i := range P {
+			if P[i] > P[best] {
+				best = i
+			}
+		}
+		if math.Abs(f[best]-peak) > 0.01 {
+			t.Errorf("PSD peak at %v, expected %v", f[best], peak)
+		}
+	}
+}
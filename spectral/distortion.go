@@ -0,0 +1,194 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/window"
+)
+
+// DistortionOptions holds the parameters of MeasureDistortion.
+type DistortionOptions struct {
+	// Window is a function that returns an array of window values the length
+	// of its input parameter. Its sidelobes must be below the noise floor
+	// being measured.
+	//
+	// The default (nil) is window.BlackmanHarrisPeriodic, whose sidelobes are
+	// 92 dB down.
+	Window func(int) []float64
+
+	// Harmonics is the number of tones, counting the fundamental, whose
+	// power is the harmonic distortion.
+	//
+	// The default value is 0, which uses 6 like MATLAB's thd.
+	Harmonics int
+
+	// Bins is the number of bins on each side of a tone's peak attributed
+	// to the tone. It must cover the main lobe of the window.
+	//
+	// The default value is 0, which uses 5, suiting the default window.
+	Bins int
+}
+
+// Distortion holds the results of MeasureDistortion. Ratios are in dB.
+type Distortion struct {
+	// Fundamental is the frequency of the fundamental, and Power its power,
+	// in units of x squared.
+	Fundamental, Power float64
+
+	// Harmonics are the powers of the harmonics, starting with the second.
+	// Harmonics above Fs/2 are measured at their aliased frequencies.
+	Harmonics []float64
+
+	// THD is the total harmonic distortion: the power of the harmonics
+	// relative to the fundamental, in dBc.
+	THD float64
+
+	// THDN is the total harmonic distortion plus noise: the power of
+	// everything but the fundamental and DC, relative to the fundamental.
+	THDN float64
+
+	// SNR is the signal to noise ratio: the power of the fundamental
+	// relative to everything but it, the harmonics and DC.
+	SNR float64
+
+	// SINAD is the signal to noise and distortion ratio, -THDN.
+	SINAD float64
+
+	// ENOB is the effective number of bits of a full scale capture,
+	// (SINAD - 1.76) / 6.02.
+	ENOB float64
+}
+
+// MeasureDistortion measures the distortion and noise of x, a capture of
+// a sine excited system such as an amplifier or ADC, sampled at Fs. The
+// strongest tone of the windowed spectrum is the fundamental; the powers
+// of it and its harmonics are summed over their peaks, and the noise is the
+// power of the remaining bins apart from DC. Bins attributed to tones are
+// not counted as noise. A nil o uses the default options.
+// Reference: http://www.mathworks.com/help/signal/ref/thd.html
+// See also: https://www.analog.com/media/en/training-seminars/tutorials/MT-003.pdf
+func MeasureDistortion(x []float64, Fs float64, o *DistortionOptions) Distortion {
+	var opts DistortionOptions
+	if o != nil {
+		opts = *o
+	}
+	if opts.Window == nil {
+		opts.Window = window.BlackmanHarrisPeriodic
+	}
+	if opts.Harmonics == 0 {
+		opts.Harmonics = 6
+	}
+	if opts.Bins == 0 {
+		opts.Bins = 5
+	}
+	span := opts.Bins
+	n := len(x)
+	if n < 4*span {
+		panic("x is too short")
+	}
+
+	// One-sided power spectrum of the windowed signal, without its mean.
+	var mean float64
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(n)
+	w := opts.Window(n)
+	seg := make([]float64, n)
+	var norm float64
+	for i, v := range x {
+		seg[i] = (v - mean) * w[i]
+		norm += w[i] * w[i]
+	}
+	X := fft.FFTReal(seg)
+	P := make([]float64, n/2+1)
+	for k := range P {
+		P[k] = real(X[k]*cmplx.Conj(X[k])) / (float64(n) * norm)
+		if k > 0 && 2*k != n {
+			P[k] *= 2
+		}
+	}
+
+	// DC, and the bins of each tone, are marked as used.
+	used := make([]bool, len(P))
+	for k := 0; k <= span && k < len(P); k++ {
+		used[k] = true
+	}
+	var total float64
+	for k, v := range P {
+		if !used[k] {
+			total += v
+		}
+	}
+
+	k0 := span + 1
+	for k := k0; k < len(P); k++ {
+		if P[k] > P[k0] {
+			k0 = k
+		}
+	}
+	var d Distortion
+	var moment float64
+	for k := k0 - span; k <= k0+span && k < len(P); k++ {
+		if used[k] {
+			continue
+		}
+		moment += float64(k) * P[k]
+		d.Power += P[k]
+		used[k] = true
+	}
+	d.Fundamental = moment / d.Power * Fs / float64(n)
+
+	var harm float64
+	for h := 2; h <= opts.Harmonics; h++ {
+		// Fold the harmonic into [0, Fs/2].
+		f := math.Mod(float64(h)*d.Fundamental, Fs)
+		if f > Fs/2 {
+			f = Fs - f
+		}
+		kh := int(f/Fs*float64(n) + 0.5)
+
+		// Sum the bins around the peak nearest the expected bin.
+		peak := kh
+		for k := kh - span; k <= kh+span; k++ {
+			if k >= 0 && k < len(P) && P[k] > P[peak] {
+				peak = k
+			}
+		}
+		var p float64
+		for k := peak - span; k <= peak+span; k++ {
+			if k >= 0 && k < len(P) && !used[k] {
+				p += P[k]
+				used[k] = true
+			}
+		}
+		d.Harmonics = append(d.Harmonics, p)
+		harm += p
+	}
+
+	noise := total - d.Power - harm
+	d.THD = 10 * math.Log10(harm/d.Power)
+	d.THDN = 10 * math.Log10((total-d.Power)/d.Power)
+	d.SNR = 10 * math.Log10(d.Power/noise)
+	d.SINAD = -d.THDN
+	d.ENOB = (d.SINAD - 1.76) / 6.02
+	return d
+}
@@ -0,0 +1,79 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestMeasureDistortion(t *testing.T) {
+	const (
+		fs    = 48000
+		f0    = 997
+		sigma = 1e-3
+	)
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, 16384)
+	for i := range x {
+		p := 2 * math.Pi * f0 * float64(i) / fs
+		x[i] = 0.3 + math.Sin(p) + 0.01*math.Sin(2*p) + 0.001*math.Sin(3*p+1) + sigma*r.NormFloat64()
+	}
+	d := MeasureDistortion(x, fs, nil)
+
+	harm := (0.01*0.01 + 0.001*0.001) / 2
+	noise := sigma * sigma
+	tests := []struct {
+		name      string
+		got, want float64
+		tol       float64
+	}{
+		{"Fundamental", d.Fundamental, f0, 0.1},
+		{"Power", d.Power, 0.5, 1e-3},
+		{"THD", d.THD, 10 * math.Log10(harm/0.5), 0.1},
+		{"THDN", d.THDN, 10 * math.Log10((harm+noise)/0.5), 0.2},
+		{"SNR", d.SNR, 10 * math.Log10(0.5/noise), 0.2},
+		{"SINAD", d.SINAD, -10 * math.Log10((harm+noise)/0.5), 0.2},
+	}
+	for _, v := range tests {
+		if math.Abs(v.got-v.want) > v.tol {
+			t.Errorf("MeasureDistortion %s: got %v, expected %v", v.name, v.got, v.want)
+		}
+	}
+	if len(d.Harmonics) != 5 {
+		t.Fatalf("MeasureDistortion: got %v harmonics, expected 5", len(d.Harmonics))
+	}
+	if h := 10 * math.Log10(d.Harmonics[0]/d.Power); math.Abs(h+40) > 0.1 {
+		t.Errorf("MeasureDistortion second harmonic: got %v dBc, expected -40", h)
+	}
+}
+
+func TestENOB(t *testing.T) {
+	// An ideal 16 bit ADC capturing a full scale sine, with a whole number
+	// of cycles, has an ENOB of 16.
+	const n = 8192
+	x := make([]float64, n)
+	for i := range x {
+		v := math.Sin(2 * math.Pi * 1001 * float64(i) / n)
+		x[i] = math.Round(v*32767.5-0.5) / 32768
+	}
+	d := MeasureDistortion(x, n, &DistortionOptions{Harmonics: 2})
+	if math.Abs(d.ENOB-16) > 0.2 {
+		t.Errorf("ENOB: got %v, expected 16", d.ENOB)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+)
+
+// Framewise returns the feature f of each frame of x, segmented as by
+// Segment into frames of length size with noverlap samples of overlap. For
+// example, the zero-crossing rate of 25 ms frames with a 10 ms hop at 16 kHz:
+//
+//	zcr := Framewise(x, 400, 240, ZeroCrossingRate)
+func Framewise(x []float64, size, noverlap int, f func([]float64) float64) []float64 {
+	frames := Segment(x, size, noverlap)
+	r := make([]float64, len(frames))
+	for i, frame := range frames {
+		r[i] = f(frame)
+	}
+	return r
+}
+
+// ZeroCrossingRate returns the fraction of consecutive samples of x that
+// change sign, which is high for noise and unvoiced speech and low for
+// voiced speech and tones. Zeros count as positive.
+func ZeroCrossingRate(x []float64) float64 {
+	if len(x) < 2 {
+		return 0
+	}
+	var n int
+	for i := 1; i < len(x); i++ {
+		if (x[i] < 0) != (x[i-1] < 0) {
+			n++
+		}
+	}
+	return float64(n) / float64(len(x)-1)
+}
+
+// Energy returns the short-time energy of x, the mean of its squares.
+func Energy(x []float64) float64 {
+	if len(x) == 0 {
+		return 0
+	}
+	var e float64
+	for _, v := range x {
+		e += v * v
+	}
+	return e / float64(len(x))
+}
+
+// EnergyEntropy returns the entropy, in bits, of the distribution of the
+// energy of x among n equal sub-frames. It is low for frames with abrupt
+// energy changes, such as onsets, and at most log2(n) for steady ones.
+// Samples past the last whole sub-frame are ignored, and a silent frame
+// has an entropy of 0.
+// Reference: Giannakopoulos, "pyAudioAnalysis: An Open-Source Python
+// Library for Audio Signal Analysis", PLoS ONE 10(12), 2015.
+func EnergyEntropy(x []float64, n int) float64 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+	size := len(x) / n
+	if size == 0 {
+		return 0
+	}
+	sub := make([]float64, n)
+	var total float64
+	for i := range sub {
+		for _, v := range x[i*size : (i+1)*size] {
+			sub[i] += v * v
+		}
+		total += sub[i]
+	}
+	if total == 0 {
+		return 0
+	}
+
+	var h float64
+	for _, e := range sub {
+		if p := e / total; p > 0 {
+			h -= p * math.Log2(p)
+		}
+	}
+	return h
+}
@@ -0,0 +1,86 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestZeroCrossingRate(t *testing.T) {
+	tests := []struct {
+		x   []float64
+		out float64
+	}{
+		{nil, 0},
+		{[]float64{1}, 0},
+		{[]float64{1, -1, 1, -1, 1}, 1},
+		{[]float64{1, 2, 0, -1, -2}, 0.25},
+		{[]float64{-1, 0, 1, 2, 3}, 0.25},
+	}
+	for _, v := range tests {
+		if o := ZeroCrossingRate(v.x); o != v.out {
+			t.Error("ZeroCrossingRate error\ninput:", v.x, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestEnergy(t *testing.T) {
+	x := []float64{1, -2, 3, -4}
+	if o := Energy(x); o != 7.5 {
+		t.Error("Energy error\ninput:", x, "\noutput:", o, "\nexpected:", 7.5)
+	}
+	if o := Energy(nil); o != 0 {
+		t.Error("Energy error\ninput: nil\noutput:", o, "\nexpected:", 0)
+	}
+}
+
+func TestEnergyEntropy(t *testing.T) {
+	tests := []struct {
+		x   []float64
+		n   int
+		out float64
+	}{
+		// Steady energy has the maximum entropy.
+		{[]float64{1, -1, 1, -1, 1, -1, 1, -1}, 4, 2},
+		// All energy in one sub-frame has none.
+		{[]float64{0, 0, 0, 0, 0, 3, 0, 0}, 4, 0},
+		{[]float64{1, 1, 1, 1, 0, 0, 0, 0}, 2, 0},
+		{[]float64{0, 0, 0, 0}, 2, 0},
+		// The trailing sample is ignored.
+		{[]float64{1, 0, 0, 1, 5}, 2, 1},
+	}
+	for _, v := range tests {
+		if o := EnergyEntropy(v.x, v.n); math.Abs(o-v.out) > 1e-12 {
+			t.Error("EnergyEntropy error\ninput:", v.x, v.n, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestFramewise(t *testing.T) {
+	x := []float64{1, 1, -1, -1, 2, 2, 2, 2}
+	o := Framewise(x, 4, 2, Energy)
+	if e := []float64{1, 2.5, 4}; !dsputils.PrettyClose(o, e) {
+		t.Error("Framewise error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+	o = Framewise(x, 4, 2, ZeroCrossingRate)
+	if e := []float64{1.0 / 3, 1.0 / 3, 0}; !dsputils.PrettyClose(o, e) {
+		t.Error("Framewise error\ninput:", x, "\noutput:", o, "\nexpected:", e)
+	}
+}
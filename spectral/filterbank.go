@@ -0,0 +1,142 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+)
+
+// HzToMel converts the frequency f in Hz to the mel scale, using the HTK
+// formula 2595*log10(1+f/700).
+func HzToMel(f float64) float64 {
+	return 2595 * math.Log10(1+f/700)
+}
+
+// MelToHz converts m on the mel scale to a frequency in Hz. It is the
+// inverse of HzToMel.
+func MelToHz(m float64) float64 {
+	return 700 * (math.Pow(10, m/2595) - 1)
+}
+
+// HzToBark converts the frequency f in Hz to the Bark scale, using
+// Traunmüller's formula 26.81*f/(1960+f) - 0.53.
+func HzToBark(f float64) float64 {
+	return 26.81*f/(1960+f) - 0.53
+}
+
+// BarkToHz converts z on the Bark scale to a frequency in Hz. It is the
+// inverse of HzToBark.
+func BarkToHz(z float64) float64 {
+	return 1960 * (z + 0.53) / (26.28 - z)
+}
+
+type FilterbankOptions struct {
+	// Fmin and Fmax are the lower edge of the first filter and the upper
+	// edge of the last filter, in Hz.
+	//
+	// The default values are 0 and 0, which sets Fmax to Fs/2.
+	Fmin, Fmax float64
+
+	// AreaNorm scales each filter to unit area (sum of weights times bin
+	// width in Hz), as in the Slaney Auditory Toolbox and librosa, so that
+	// wider filters do not collect more energy. Otherwise each filter has a
+	// peak weight of 1, as in HTK.
+	//
+	// The default value is false (unit peak).
+	AreaNorm bool
+}
+
+// MelFilterbank returns a matrix of n triangular filters spaced evenly on
+// the mel scale, for the one-sided spectrum of an nfft point FFT at sampling
+// frequency Fs. Each row holds the weights of one filter for the nfft/2+1
+// frequency bins; multiplying it with a power or magnitude spectrum, as by
+// ApplyFilterbank, warps the spectrum to the mel scale. A nil o uses the
+// default options.
+// Reference: https://librosa.org/doc/latest/generated/librosa.filters.mel.html
+func MelFilterbank(n, nfft int, Fs float64, o *FilterbankOptions) [][]float64 {
+	return filterbank(n, nfft, Fs, o, HzToMel, MelToHz)
+}
+
+// BarkFilterbank is like MelFilterbank, but spaces the filters evenly on the
+// Bark scale.
+func BarkFilterbank(n, nfft int, Fs float64, o *FilterbankOptions) [][]float64 {
+	return filterbank(n, nfft, Fs, o, HzToBark, BarkToHz)
+}
+
+// ApplyFilterbank returns the output of each filter in fb for the spectrum
+// s: the weighted sum of s by each row of fb.
+func ApplyFilterbank(fb [][]float64, s []float64) []float64 {
+	r := make([]float64, len(fb))
+	for i, w := range fb {
+		if len(w) != len(s) {
+			panic("spectrum length must match filterbank")
+		}
+		for j, v := range w {
+			r[i] += v * s[j]
+		}
+	}
+	return r
+}
+
+// filterbank returns n triangular filters with edges spaced evenly on the
+// scale given by the conversion functions to and from.
+func filterbank(n, nfft int, Fs float64, o *FilterbankOptions, to, from func(float64) float64) [][]float64 {
+	if n < 1 || nfft < 2 {
+		panic("n and nfft must be positive")
+	}
+	if o == nil {
+		o = &FilterbankOptions{}
+	}
+	fmin, fmax := o.Fmin, o.Fmax
+	if fmax == 0 {
+		fmax = Fs / 2
+	}
+	if fmin < 0 || fmin >= fmax || fmax > Fs/2 {
+		panic("filterbank edges must satisfy 0 <= Fmin < Fmax <= Fs/2")
+	}
+
+	// Edge frequencies of the filters: filter i rises from edges[i] to
+	// edges[i+1] and falls to edges[i+2].
+	lo, hi := to(fmin), to(fmax)
+	edges := make([]float64, n+2)
+	for i := range edges {
+		edges[i] = from(lo + (hi-lo)*float64(i)/float64(n+1))
+	}
+
+	nbins := nfft/2 + 1
+	df := Fs / float64(nfft)
+	fb := make([][]float64, n)
+	for i := range fb {
+		fb[i] = make([]float64, nbins)
+		l, c, u := edges[i], edges[i+1], edges[i+2]
+		for j := range fb[i] {
+			f := float64(j) * df
+			var w float64
+			if f > l && f <= c {
+				w = (f - l) / (c - l)
+			} else if f > c && f < u {
+				w = (u - f) / (u - c)
+			}
+			if o.AreaNorm {
+				w *= 2 / (u - l)
+			}
+			fb[i][j] = w
+		}
+	}
+
+	return fb
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestMelScale(t *testing.T) {
+	if m := HzToMel(1000); math.Abs(m-1000) > 0.1 {
+		t.Error("HzToMel error\noutput:", m, "\nexpected:", 1000)
+	}
+	if z := HzToBark(1000); math.Abs(z-8.527) > 0.001 {
+		t.Error("HzToBark error\noutput:", z, "\nexpected:", 8.527)
+	}
+	for _, f := range []float64{0, 100, 440, 8000} {
+		if v := MelToHz(HzToMel(f)); math.Abs(v-f) > 1e-9 {
+			t.Error("MelToHz error\ninput:", f, "\noutput:", v)
+		}
+		if v := BarkToHz(HzToBark(f)); math.Abs(v-f) > 1e-9 {
+			t.Error("BarkToHz error\ninput:", f, "\noutput:", v)
+		}
+	}
+}
+
+func TestMelFilterbank(t *testing.T) {
+	// Filters with edges at 0, 2, 4, 6 and 8 Hz, on bins 1 Hz apart.
+	fb := filterbank(3, 16, 16, nil, func(f float64) float64 { return f }, func(f float64) float64 { return f })
+	e := [][]float64{
+		{0, 0.5, 1, 0.5, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0.5, 1, 0.5, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0.5, 1, 0.5, 0},
+	}
+	for i := range e {
+		if !dsputils.PrettyClose(fb[i], e[i]) {
+			t.Error("filterbank error\noutput:", fb[i], "\nexpected:", e[i])
+		}
+	}
+
+	const nfft, fs = 512, 16000
+	for _, area := range []bool{false, true} {
+		o := &FilterbankOptions{Fmin: 300, Fmax: 6000, AreaNorm: area}
+		for _, fb := range [][][]float64{MelFilterbank(26, nfft, fs, o), BarkFilterbank(18, nfft, fs, o)} {
+			for i, w := range fb {
+				var sum, peak float64
+				for j, v := range w {
+					f := float64(j) * fs / nfft
+					if v != 0 && (f < 300 || f > 6000) {
+						t.Fatal("filterbank edge error:", i, f, v)
+					}
+					sum += v * fs / nfft
+					if v > peak {
+						peak = v
+					}
+				}
+				if area && math.Abs(sum-1) > 0.1 {
+					t.Error("filterbank area error:", i, sum)
+				}
+				if !area && (peak > 1 || peak < 0.5) {
+					t.Error("filterbank peak error:", i, peak)
+				}
+			}
+		}
+	}
+
+	// A flat spectrum gives each unit peak filter an output proportional
+	// to its width, so outputs increase with frequency.
+	fb = MelFilterbank(10, nfft, fs, nil)
+	flat := make([]float64, nfft/2+1)
+	for i := range flat {
+		flat[i] = 1
+	}
+	out := ApplyFilterbank(fb, flat)
+	for i := 1; i < len(out); i++ {
+		if out[i] <= out[i-1] {
+			t.Error("ApplyFilterbank error\noutput:", out)
+			break
+		}
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/window"
+)
+
+// PeriodogramOptions are the options of Periodogram.
+type PeriodogramOptions struct {
+	// Window is a function that returns an array of window values the length
+	// of its input parameter. x is scaled by these values.
+	//
+	// The default (nil) is window.Rectangular.
+	Window func(int) []float64
+
+	// Pad is the length of the FFT, to which x is zero padded.
+	//
+	// The default value is 0, which uses len(x).
+	Pad int
+
+	// Scale_off disables scaling by the sampling frequency, as for Pwelch.
+	//
+	// The default value is false (enable scaling).
+	Scale_off bool
+}
+
+// Periodogram estimates the power spectral density of x from the FFT of all
+// of x at once. It has the finest frequency resolution for the record, but
+// unlike Pwelch its variance does not decrease with longer records. Pxx is
+// scaled like Pwelch and freqs are from 0 to Fs/2. A nil o uses the default
+// options.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.periodogram.html
+// See also: http://www.mathworks.com/help/signal/ref/periodogram.html
+func Periodogram(x []float64, Fs float64, o *PeriodogramOptions) (Pxx, freqs []float64) {
+	if o == nil {
+		o = &PeriodogramOptions{}
+	}
+	wf := o.Window
+	if wf == nil {
+		wf = window.Rectangular
+	}
+	return Pwelch(x, Fs, &PwelchOptions{
+		NFFT:      len(x),
+		Window:    wf,
+		Pad:       o.Pad,
+		Scale_off: o.Scale_off,
+	})
+}
+
+// MultitaperOptions are the options of Pmtm.
+type MultitaperOptions struct {
+	// NW is the time-halfbandwidth product of the tapers. The estimate
+	// averages over a bandwidth of 2*NW*Fs/len(x).
+	//
+	// The default value is 0, which uses 4.
+	NW float64
+
+	// Tapers is the number of DPSS tapers averaged.
+	//
+	// The default value is 0, which uses 2*NW-1, rounded down.
+	Tapers int
+
+	// Pad is the length of the FFT, to which each tapered copy of x is zero
+	// padded.
+	//
+	// The default value is 0, which uses len(x).
+	Pad int
+
+	// Scale_off disables scaling by the sampling frequency, as for Pwelch.
+	//
+	// The default value is false (enable scaling).
+	Scale_off bool
+}
+
+// Pmtm estimates the power spectral density of x with Thomson's multitaper
+// method: the average of the periodograms of x multiplied by each of a set
+// of orthogonal DPSS tapers. Averaging lowers the variance like Pwelch does,
+// without splitting a short record into shorter segments. The periodograms
+// are weighted equally. Pxx is scaled like Pwelch and freqs are from 0 to
+// Fs/2. A nil o uses the default options.
+// Reference: http://dx.doi.org/10.1109/PROC.1982.12433
+// See also: http://www.mathworks.com/help/signal/ref/pmtm.html
+func Pmtm(x []float64, Fs float64, o *MultitaperOptions) (Pxx, freqs []float64) {
+	if o == nil {
+		o = &MultitaperOptions{}
+	}
+	if len(x) == 0 {
+		return []float64{}, []float64{}
+	}
+	nw := o.NW
+	if nw == 0 {
+		nw = 4
+	}
+	k := o.Tapers
+	if k == 0 {
+		k = int(math.Max(1, math.Floor(2*nw-1)))
+	}
+
+	tapers, _ := window.DPSS(len(x), nw, k)
+	fill := func(dst []float64, off int) {
+		copy(dst, x[off:])
+	}
+	for _, taper := range tapers {
+		taper := taper
+		w := newWelch(len(x), fill, Fs, &PwelchOptions{
+			NFFT:      len(x),
+			Window:    func(int) []float64 { return taper },
+			Pad:       o.Pad,
+			Scale_off: o.Scale_off,
+		})
+		if Pxx == nil {
+			Pxx = make([]float64, w.bins())
+			freqs = w.freqs(Fs)
+		}
+		w.add(Pxx, 0, 1/float64(k))
+	}
+	return Pxx, freqs
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/window"
+)
+
+func TestPeriodogram(t *testing.T) {
+	tests := []struct {
+		x        []float64
+		o        *PeriodogramOptions
+		p, freqs []float64
+	}{
+		{[]float64{1, 2, 3, 4}, nil, []float64{25, 4, 1}, []float64{0, 0.25, 0.5}},
+		{[]float64{1, 2, 3}, nil, []float64{12, 2}, []float64{0, 1.0 / 3}},
+		{[]float64{1, 2}, &PeriodogramOptions{Pad: 4}, []float64{4.5, 5, 0.5}, []float64{0, 0.25, 0.5}},
+	}
+	for _, v := range tests {
+		p, freqs := Periodogram(v.x, 1, v.o)
+		if !dsputils.PrettyClose(p, v.p) || !dsputils.PrettyClose(freqs, v.freqs) {
+			t.Error("Periodogram error\ninput:", v.x, "\noutput:", p, freqs, "\nexpected:", v.p, v.freqs)
+		}
+	}
+}
+
+func TestPmtm(t *testing.T) {
+	// With one taper, Pmtm is the periodogram with that taper as the window.
+	x := make([]float64, 64)
+	for i := range x {
+		x[i] = math.Sin(2*math.Pi*0.2*float64(i)) + 0.1*float64(i%5)
+	}
+	tapers, _ := window.DPSS(len(x), 2, 1)
+	p, freqs := Pmtm(x, 10, &MultitaperOptions{NW: 2, Tapers: 1})
+	ep, ef := Periodogram(x, 10, &PeriodogramOptions{Window: func(int) []float64 { return tapers[0] }})
+	if !dsputils.PrettyClose(p, ep) || !dsputils.PrettyClose(freqs, ef) {
+		t.Error("Pmtm error\ninput:", x, "\noutput:", p, "\nexpected:", ep)
+	}
+
+	// The tapers have unit energy, so for a signal of unit magnitude the
+	// density integrates to 1.
+	for i := range x {
+		x[i] = float64(1 - 2*(i%2))
+	}
+	p, freqs = Pmtm(x, 10, nil)
+	var sum float64
+	for _, v := range p {
+		sum += v * (freqs[1] - freqs[0])
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Error("Pmtm power error\noutput:", sum, "\nexpected:", 1)
+	}
+	if peak := p[len(p)-1]; peak < p[len(p)/2]*1e3 {
+		t.Error("Pmtm leakage error\noutput:", p)
+	}
+}
@@ -36,8 +36,11 @@ type PwelchOptions struct {
 
 	// Window is a function that returns an array of window values the length
 	// of its input parameter. Each segment is scaled by these values.
+	// Parametric windows can be used through their Values method, e.g.
+	// window.KaiserWindow(8.6).Values.
 	//
-	// The default (nil) is window.Hann, from the go-dsp/window package.
+	// The default (nil) is window.HannPeriodic, from the go-dsp/window package.
+	// Use window.Hann for the symmetric window used by MATLAB and matplotlib.
 	Window func(int) []float64
 
 	// Pad is the number of points to which the data segment is padded when
@@ -62,6 +65,81 @@ type PwelchOptions struct {
 	//
 	// The default value is false (enable scaling).
 	Scale_off bool
+
+	// Sides selects a one-sided or two-sided estimate.
+	//
+	// The default value is OneSided.
+	Sides Sides
+
+	// Detrend selects the trend removed from each segment before it is
+	// windowed. Removing the mean keeps a DC offset from leaking into the
+	// bins near zero frequency.
+	//
+	// The default value is DetrendNone.
+	Detrend Detrend
+}
+
+// Sides selects the frequencies of a power spectral density estimate.
+type Sides int
+
+const (
+	// OneSided returns the frequencies from 0 to Fs/2, with the power at
+	// negative frequencies folded into the positive ones.
+	OneSided Sides = iota
+
+	// TwoSided returns all Pad frequencies, from 0 to Fs-Fs/Pad, with the
+	// negative frequencies above Fs/2.
+	TwoSided
+)
+
+// Detrend selects the trend removed from each segment of a signal.
+type Detrend int
+
+const (
+	// DetrendNone leaves the segments unchanged.
+	DetrendNone Detrend = iota
+
+	// DetrendMean subtracts the mean of each segment.
+	DetrendMean
+
+	// DetrendLinear subtracts the least-squares line through each segment.
+	DetrendLinear
+)
+
+// detrend removes the trend d from x in place.
+func detrend(x []float64, d Detrend) {
+	n := float64(len(x))
+	switch d {
+	case DetrendMean:
+		var mean float64
+		for _, v := range x {
+			mean += v
+		}
+		mean /= n
+		for i := range x {
+			x[i] -= mean
+		}
+	case DetrendLinear:
+		if len(x) < 2 {
+			detrend(x, DetrendMean)
+			return
+		}
+		// Fit about the center index c, so the slope and mean are
+		// independent.
+		c := (n - 1) / 2
+		var mean, sxy, sxx float64
+		for i, v := range x {
+			t := float64(i) - c
+			mean += v
+			sxy += t * v
+			sxx += t * t
+		}
+		mean /= n
+		slope := sxy / sxx
+		for i := range x {
+			x[i] -= mean + slope*(float64(i)-c)
+		}
+	}
 }
 
 // Pwelch estimates the power spectral density of x using Welch's method.
@@ -72,74 +150,160 @@ type PwelchOptions struct {
 // Reference: http://matplotlib.org/api/mlab_api.html#matplotlib.mlab.psd
 // See also: http://www.mathworks.com/help/signal/ref/pwelch.html
 func Pwelch(x []float64, Fs float64, o *PwelchOptions) (Pxx, freqs []float64) {
-	if len(x) == 0 {
+	return pwelch(len(x), func(dst []float64, off int) {
+		copy(dst, x[off:])
+	}, Fs, o)
+}
+
+// Pwelch32 is like Pwelch, but for a float32 signal. Each segment is
+// converted to float64 as it is transformed, so x is never copied whole.
+func Pwelch32(x []float32, Fs float64, o *PwelchOptions) (Pxx, freqs []float32) {
+	p, f := pwelch(len(x), func(dst []float64, off int) {
+		for i := range dst {
+			if off+i >= len(x) {
+				break
+			}
+			dst[i] = float64(x[off+i])
+		}
+	}, Fs, o)
+	return dsputils.ToFloat32(p), dsputils.ToFloat32(f)
+}
+
+// pwelch computes Pwelch for a signal of length n. fill copies up to
+// len(dst) samples of the signal, starting at off, into dst.
+func pwelch(n int, fill func(dst []float64, off int), Fs float64, o *PwelchOptions) (Pxx, freqs []float64) {
+	if n == 0 {
 		return []float64{}, []float64{}
 	}
 
+	w := newWelch(n, fill, Fs, o)
+	segs := w.segments()
+	Pxx = make([]float64, w.bins())
+	for s := 0; s < segs; s++ {
+		w.add(Pxx, s*w.step, 1/float64(segs))
+	}
+
+	return Pxx, w.freqs(Fs)
+}
+
+// welch holds the resolved options and scratch space of a Welch estimate.
+type welch struct {
+	n               int
+	fill            func(dst []float64, off int)
+	nfft, pad, step int
+	sides           Sides
+	detrend         Detrend
+	wp              []float64 // window of length nfft
+	norm            float64
+	buf             []float64
+}
+
+// newWelch returns a welch for a signal of length n. fill copies up to
+// len(dst) samples of the signal, starting at off, into dst.
+func newWelch(n int, fill func(dst []float64, off int), Fs float64, o *PwelchOptions) *welch {
 	nfft := o.NFFT
 	pad := o.Pad
-	noverlap := o.Noverlap
 	wf := o.Window
-	enable_scaling := !o.Scale_off
 
 	if nfft == 0 {
 		nfft = 256
 	}
 
 	if wf == nil {
-		wf = window.Hann
+		wf = window.HannPeriodic
 	}
 
 	if pad == 0 {
 		pad = nfft
 	}
 
-	if len(x) < nfft {
-		x = dsputils.ZeroPadF(x, nfft)
+	// Compute the window once instead of once per segment.
+	wp := wf(nfft)
+	var norm float64
+	for _, x := range wp {
+		norm += math.Pow(x, 2)
 	}
 
-	lp := pad/2 + 1
-	var scale float64 = 2
-
-	segs := Segment(x, nfft, noverlap)
+	if !o.Scale_off {
+		norm *= Fs
+	}
 
-	Pxx = make([]float64, lp)
-	for _, x := range segs {
-		x = dsputils.ZeroPadF(x, pad)
-		window.Apply(x, wf)
+	size := pad
+	if nfft > size {
+		size = nfft
+	}
 
-		pgram := fft.FFTReal(x)
+	return &welch{
+		n:       n,
+		fill:    fill,
+		nfft:    nfft,
+		pad:     pad,
+		step:    nfft - o.Noverlap,
+		sides:   o.Sides,
+		detrend: o.Detrend,
+		wp:      wp,
+		norm:    norm,
+		buf:     make([]float64, size),
+	}
+}
 
-		for j := range Pxx {
-			d := real(cmplx.Conj(pgram[j])*pgram[j]) / float64(len(segs))
+// segments returns the number of segments in the signal. Short signals are
+// zero padded to a single segment.
+func (w *welch) segments() int {
+	if w.n > w.nfft {
+		return (w.n-w.nfft)/w.step + 1
+	}
+	return 1
+}
 
-			if j > 0 && j < lp-1 {
-				d *= scale
-			}
+// bins returns the number of frequency bins.
+func (w *welch) bins() int {
+	if w.sides == TwoSided {
+		return w.pad
+	}
+	return w.pad/2 + 1
+}
 
-			Pxx[j] += d
+// add adds the periodogram of the segment starting at off, multiplied by
+// weight, to dst.
+func (w *welch) add(dst []float64, off int, weight float64) {
+	x := w.buf
+	for i := range x {
+		x[i] = 0
+	}
+	w.fill(x[:w.nfft], off)
+	if w.detrend != DetrendNone {
+		m := w.n - off
+		if m > w.nfft {
+			m = w.nfft
 		}
+		detrend(x[:m], w.detrend)
 	}
-
-	w := wf(nfft)
-	var norm float64
-	for _, x := range w {
-		norm += math.Pow(x, 2)
+	for i, v := range w.wp {
+		x[i] *= v
 	}
 
-	if enable_scaling {
-		norm *= Fs
-	}
+	pgram := fft.FFTReal(x)
 
-	for i := range Pxx {
-		Pxx[i] /= norm
+	lp := len(dst)
+	for j := range dst {
+		d := real(cmplx.Conj(pgram[j])*pgram[j]) * weight / w.norm
+
+		// Double all but the zero and, for even pad, Nyquist frequencies.
+		if w.sides == OneSided && j > 0 && (j < lp-1 || w.pad%2 != 0) {
+			d *= 2
+		}
+
+		dst[j] += d
 	}
+}
 
-	freqs = make([]float64, lp)
-	coef := Fs / float64(pad)
+// freqs returns the frequencies of the bins.
+func (w *welch) freqs(Fs float64) []float64 {
+	freqs := make([]float64, w.bins())
+	coef := Fs / float64(w.pad)
 	for i := range freqs {
 		freqs[i] = float64(i) * coef
 	}
-
-	return
+	return freqs
 }
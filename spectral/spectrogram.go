@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+// SpectrogramOptions are the options of Spectrogram. The fields of the
+// embedded PwelchOptions have the same meaning and defaults as for Pwelch;
+// in particular, Noverlap defaults to 0, while overlapping segments by
+// half, NFFT/2, gives a smoother time axis.
+type SpectrogramOptions struct {
+	PwelchOptions
+}
+
+// Spectrogram returns the power spectral density of each segment of x, as
+// Pwelch would estimate it for that segment alone. S[t][f] is the density
+// of the segment centered at times[t] at frequency freqs[f]. Times are in
+// the same units as 1/Fs, with the first sample at 0. A nil o uses the
+// default options.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.spectrogram.html
+// See also: http://www.mathworks.com/help/signal/ref/spectrogram.html
+func Spectrogram(x []float64, Fs float64, o *SpectrogramOptions) (S [][]float64, times, freqs []float64) {
+	if o == nil {
+		o = &SpectrogramOptions{}
+	}
+	if len(x) == 0 {
+		return [][]float64{}, []float64{}, []float64{}
+	}
+
+	w := newWelch(len(x), func(dst []float64, off int) {
+		copy(dst, x[off:])
+	}, Fs, &o.PwelchOptions)
+	segs := w.segments()
+
+	S = make([][]float64, segs)
+	times = make([]float64, segs)
+	for s := range S {
+		S[s] = make([]float64, w.bins())
+		w.add(S[s], s*w.step, 1)
+		times[s] = (float64(s*w.step) + float64(w.nfft)/2) / Fs
+	}
+
+	return S, times, w.freqs(Fs)
+}
@@ -0,0 +1,81 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestSpectrogram(t *testing.T) {
+	// A tone at 1/8 of the sample rate for the first half, then at 1/4.
+	const n = 512
+	x := make([]float64, n)
+	for i := range x {
+		f := 0.125
+		if i >= n/2 {
+			f = 0.25
+		}
+		x[i] = math.Sin(2 * math.Pi * f * float64(i))
+	}
+
+	o := &SpectrogramOptions{PwelchOptions{NFFT: 64, Noverlap: 32}}
+	S, times, freqs := Spectrogram(x, 2, o)
+	if len(S) != 15 || len(times) != 15 || len(freqs) != 33 {
+		t.Fatal("Spectrogram size error\noutput:", len(S), len(times), len(freqs), "\nexpected:", 15, 15, 33)
+	}
+	if times[0] != 16 || times[14] != 240 {
+		t.Error("Spectrogram times error\noutput:", times, "\nexpected:", 16, "...", 240)
+	}
+	if freqs[8] != 0.25 || freqs[16] != 0.5 {
+		t.Error("Spectrogram freqs error\noutput:", freqs)
+	}
+	for i, row := range S {
+		peak := 0
+		for j, v := range row {
+			if v > row[peak] {
+				peak = j
+			}
+		}
+		e := 8
+		if times[i] > 128 {
+			e = 16
+		} else if times[i] == 128 {
+			continue
+		}
+		if peak != e {
+			t.Error("Spectrogram peak error\ntime:", times[i], "\noutput:", peak, "\nexpected:", e)
+		}
+	}
+
+	// Each row is the Pwelch estimate of its segment.
+	for i, row := range S {
+		p, _ := Pwelch(x[i*32:i*32+64], 2, &o.PwelchOptions)
+		if !dsputils.PrettyClose(row, p) {
+			t.Error("Spectrogram row error\nrow:", i, "\noutput:", row, "\nexpected:", p)
+		}
+	}
+}
+
+func TestSpectrogramEmpty(t *testing.T) {
+	S, times, freqs := Spectrogram(nil, 1, nil)
+	if len(S) != 0 || len(times) != 0 || len(freqs) != 0 {
+		t.Error("Spectrogram error\noutput:", S, times, freqs)
+	}
+}
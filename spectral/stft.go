@@ -0,0 +1,200 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math"
+	"math/cmplx"
+	"sort"
+
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/window"
+)
+
+// STFTOptions holds the segmentation parameters of STFT and ISTFT, which
+// must match for reconstruction.
+type STFTOptions struct {
+	// NFFT is the length of each segment, and of its FFT.
+	//
+	// The default value is 256.
+	NFFT int
+
+	// Window is a function that returns an array of window values the length
+	// of its input parameter. Each segment is scaled by these values, and
+	// ISTFT uses them again for synthesis.
+	//
+	// The default (nil) is window.HannPeriodic, from the go-dsp/window package.
+	Window func(int) []float64
+
+	// Hop is the number of samples between the starts of segments.
+	//
+	// The default value is 0, which uses NFFT/2.
+	Hop int
+}
+
+func (o *STFTOptions) params() (nfft, hop int, w []float64) {
+	var opts STFTOptions
+	if o != nil {
+		opts = *o
+	}
+	nfft = opts.NFFT
+	if nfft == 0 {
+		nfft = 256
+	}
+	hop = opts.Hop
+	if hop == 0 {
+		hop = nfft / 2
+	}
+	if hop < 1 || hop > nfft {
+		panic("hop must be between 1 and NFFT")
+	}
+	wf := opts.Window
+	if wf == nil {
+		wf = window.HannPeriodic
+	}
+	return nfft, hop, wf(nfft)
+}
+
+// STFT returns the short-time Fourier transform of x: the FFT of each
+// windowed segment, with NFFT/2+1 frequency bins per row. x is padded with
+// NFFT/2 zeros at each end, and more at the end to fill the last segment, so
+// row t is centered on sample t*Hop and every sample of x can be
+// reconstructed by ISTFT. The spectra are not scaled. A nil o uses the
+// default options.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.stft.html
+func STFT(x []float64, o *STFTOptions) [][]complex128 {
+	nfft, hop, w := o.params()
+	pad := nfft / 2
+
+	frames := 1
+	if n := len(x) + 2*pad; n > nfft {
+		frames = (n-nfft+hop-1)/hop + 1
+	}
+
+	r := make([][]complex128, frames)
+	seg := make([]float64, nfft)
+	for t := range r {
+		start := t*hop - pad
+		for i := range seg {
+			seg[i] = 0
+			if j := start + i; j >= 0 && j < len(x) {
+				seg[i] = x[j] * w[i]
+			}
+		}
+		r[t] = fft.FFTReal(seg)[:nfft/2+1]
+	}
+	return r
+}
+
+// ISTFT returns the n samples of the signal whose STFT is X, computed with
+// the options o, by weighted overlap-add: each inverse FFT is multiplied by
+// the window again, and the sum is divided by the sum of the squared,
+// shifted windows. Spectra modified between STFT and ISTFT give the signal
+// whose STFT is closest to them in the least squares sense. The window and
+// hop must satisfy NOLA. If n is negative, all reconstructed samples are
+// returned.
+// Reference: http://dx.doi.org/10.1109/TASSP.1984.1164317
+func ISTFT(X [][]complex128, o *STFTOptions, n int) []float64 {
+	nfft, hop, w := o.params()
+	if !NOLA(w, hop) {
+		panic("window and hop do not satisfy NOLA")
+	}
+	pad := nfft / 2
+
+	total := (len(X)-1)*hop + nfft
+	y := make([]float64, total)
+	norm := make([]float64, total)
+	full := make([]complex128, nfft)
+	for t, row := range X {
+		if len(row) != nfft/2+1 {
+			panic("X rows must have NFFT/2+1 bins")
+		}
+		copy(full, row)
+		for k := nfft/2 + 1; k < nfft; k++ {
+			full[k] = cmplx.Conj(row[nfft-k])
+		}
+		seg := fft.IFFT(full)
+		for i, v := range seg {
+			y[t*hop+i] += real(v) * w[i]
+			norm[t*hop+i] += w[i] * w[i]
+		}
+	}
+	for i := range y {
+		if norm[i] > 1e-10 {
+			y[i] /= norm[i]
+		}
+	}
+
+	y = y[pad:]
+	if n >= 0 {
+		if n > len(y) {
+			n = len(y)
+		}
+		y = y[:n]
+	}
+	return y
+}
+
+// COLA reports whether the window w with hop satisfies the constant
+// overlap-add constraint: the shifted windows sum to a constant, so
+// overlap-adding unmodified windowed segments reconstructs the signal up to a
+// gain.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.check_COLA.html
+func COLA(w []float64, hop int) bool {
+	s := windowSums(w, hop, false)
+	m := append([]float64(nil), s...)
+	sort.Float64s(m)
+	med := m[len(m)/2]
+	if len(m)%2 == 0 {
+		med = (m[len(m)/2-1] + med) / 2
+	}
+	for _, v := range s {
+		if math.Abs(v-med) > 1e-10 {
+			return false
+		}
+	}
+	return true
+}
+
+// NOLA reports whether the window w with hop satisfies the nonzero
+// overlap-add constraint, which ISTFT requires: the shifted, squared windows
+// sum to a nonzero value everywhere.
+// Reference: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.check_NOLA.html
+func NOLA(w []float64, hop int) bool {
+	for _, v := range windowSums(w, hop, true) {
+		if v <= 1e-10 {
+			return false
+		}
+	}
+	return true
+}
+
+// windowSums returns the sum of the windows w (squared if sq), shifted by
+// multiples of hop, over one hop.
+func windowSums(w []float64, hop int, sq bool) []float64 {
+	if hop < 1 || hop > len(w) {
+		panic("hop must be between 1 and len(w)")
+	}
+	s := make([]float64, hop)
+	for i, v := range w {
+		if sq {
+			v *= v
+		}
+		s[i%hop] += v
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package spectral
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/window"
+)
+
+func TestSTFT(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, 1000)
+	for i := range x {
+		x[i] = r.NormFloat64()
+	}
+
+	for _, o := range []*STFTOptions{
+		nil,
+		{NFFT: 64, Hop: 16},
+		{NFFT: 63, Hop: 20, Window: window.Hamming},
+		{NFFT: 100, Hop: 100, Window: window.Rectangular},
+		{NFFT: 2048},
+	} {
+		X := STFT(x, o)
+		nfft, hop, _ := o.params()
+		if len(X[0]) != nfft/2+1 {
+			t.Errorf("STFT %+v: got %v bins, expected %v", o, len(X[0]), nfft/2+1)
+		}
+		if min := (len(x) + hop - 1) / hop; len(X) < min {
+			t.Errorf("STFT %+v: got %v frames, expected at least %v", o, len(X), min)
+		}
+		y := ISTFT(X, o, len(x))
+		if !dsputils.PrettyClose(y, x) {
+			t.Errorf("ISTFT %+v error\noutput: %v\nexpected: %v", o, y[:10], x[:10])
+		}
+	}
+}
+
+func TestISTFTModified(t *testing.T) {
+	// Scaling every spectrum scales the signal.
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	o := &STFTOptions{NFFT: 4, Hop: 1}
+	X := STFT(x, o)
+	for _, row := range X {
+		for k := range row {
+			row[k] *= 2
+		}
+	}
+	y := ISTFT(X, o, -1)
+	e := make([]float64, len(x))
+	for i, v := range x {
+		e[i] = 2 * v
+	}
+	if !dsputils.PrettyClose(y[:len(x)], e) {
+		t.Error("ISTFT error\noutput:", y, "\nexpected:", e)
+	}
+}
+
+func TestCOLA(t *testing.T) {
+	tests := []struct {
+		w          []float64
+		hop        int
+		cola, nola bool
+	}{
+		{window.HannPeriodic(256), 128, true, true},
+		{window.HannPeriodic(256), 64, true, true},
+		{window.Hann(256), 128, false, true},
+		{window.HammingPeriodic(256), 128, true, true},
+		{window.HannPeriodic(256), 256, false, false},
+		{window.Rectangular(100), 100, true, true},
+		{window.Rectangular(100), 30, false, true},
+		{window.Bartlett(5), 2, true, true},
+		{window.Bartlett(5), 3, false, true},
+	}
+	for _, v := range tests {
+		if c := COLA(v.w, v.hop); c != v.cola {
+			t.Errorf("COLA(len %v, hop %v): got %v, expected %v", len(v.w), v.hop, c, v.cola)
+		}
+		if n := NOLA(v.w, v.hop); n != v.nola {
+			t.Errorf("NOLA(len %v, hop %v): got %v, expected %v", len(v.w), v.hop, n, v.nola)
+		}
+	}
+}
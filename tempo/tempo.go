@@ -0,0 +1,188 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package tempo provides onset detection and tempo and beat tracking for
+// music signals.
+package tempo
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/fft"
+	"github.com/mjibson/go-dsp/spectral"
+	"github.com/mjibson/go-dsp/window"
+)
+
+// OnsetStrength returns the onset strength envelope of x: the spectral flux,
+// the summed increase in log magnitude spectrum between consecutive frames
+// of frameSize samples, hop samples apart. Element t measures the change
+// into frame t, centered at sample t*hop + frameSize/2; the first element is
+// zero. The envelope is sampled at fs/hop.
+// Reference: http://www.ee.columbia.edu/~dpwe/pubs/Ellis07-beattrack.pdf
+func OnsetStrength(x []float64, frameSize, hop int) []float64 {
+	if frameSize < 1 || hop < 1 || hop > frameSize {
+		panic("frameSize and hop must be positive, with hop at most frameSize")
+	}
+	frames := spectral.Segment(x, frameSize, frameSize-hop)
+	w := window.Hann(frameSize)
+	r := make([]float64, len(frames))
+	var prev []float64
+	for t, f := range frames {
+		for i := range f {
+			f[i] *= w[i]
+		}
+		X := fft.FFTReal(f)
+		cur := make([]float64, frameSize/2+1)
+		for i := range cur {
+			cur[i] = math.Log1p(cmplx.Abs(X[i]))
+		}
+		if prev != nil {
+			for i, v := range cur {
+				if d := v - prev[i]; d > 0 {
+					r[t] += d
+				}
+			}
+		}
+		prev = cur
+	}
+	return r
+}
+
+// Tempo returns the tempo in beats per minute of the onset strength envelope
+// onset, sampled at rate frames per second, searching between minBPM and
+// maxBPM. The autocorrelation of the envelope is weighted by a log-Gaussian
+// centered at 120 BPM, one octave wide, to resolve the ambiguity between
+// multiples of the tempo.
+// Reference: http://www.ee.columbia.edu/~dpwe/pubs/Ellis07-beattrack.pdf
+func Tempo(onset []float64, rate, minBPM, maxBPM float64) float64 {
+	if minBPM <= 0 || maxBPM <= minBPM {
+		panic("bpm range must satisfy 0 < minBPM < maxBPM")
+	}
+	lo := int(math.Floor(60 * rate / maxBPM))
+	if lo < 1 {
+		lo = 1
+	}
+	hi := int(math.Ceil(60 * rate / minBPM))
+	if hi+1 >= len(onset) {
+		panic("onset envelope too short for minBPM")
+	}
+
+	var mean float64
+	for _, v := range onset {
+		mean += v
+	}
+	mean /= float64(len(onset))
+	o := make([]float64, len(onset))
+	for i, v := range onset {
+		o[i] = v - mean
+	}
+
+	ac := make([]float64, hi+2)
+	for lag := lo - 1; lag < len(ac); lag++ {
+		for i := lag; i < len(o); i++ {
+			ac[lag] += o[i] * o[i-lag]
+		}
+	}
+	weight := func(lag int) float64 {
+		d := math.Log2(60 * rate / float64(lag) / 120)
+		return math.Exp(-d * d / 2)
+	}
+
+	best := lo
+	for lag := lo; lag <= hi; lag++ {
+		if ac[lag]*weight(lag) > ac[best]*weight(best) {
+			best = lag
+		}
+	}
+
+	// Refine the lag by parabolic interpolation of the autocorrelation.
+	lag := float64(best)
+	a, b, c := ac[best-1], ac[best], ac[best+1]
+	if d := a - 2*b + c; d < 0 {
+		if p := (a - c) / (2 * d); p > -0.5 && p < 0.5 {
+			lag += p
+		}
+	}
+	return 60 * rate / lag
+}
+
+// Beats returns the beat times in seconds of the onset strength envelope
+// onset, sampled at rate frames per second, for a tempo of bpm. Beats are
+// chosen by dynamic programming to fall on strong onsets while keeping
+// near the tempo; tightness, typically 100, sets how strongly the spacing
+// is held to the tempo. Time 0 is the first onset frame; add the frame
+// center offset from OnsetStrength if needed.
+// Reference: http://www.ee.columbia.edu/~dpwe/pubs/Ellis07-beattrack.pdf
+func Beats(onset []float64, rate, bpm, tightness float64) []float64 {
+	if len(onset) == 0 {
+		return nil
+	}
+	period := 60 * rate / bpm
+	if period < 1 {
+		panic("bpm too high for rate")
+	}
+
+	// Normalize the envelope so tightness is independent of its scale.
+	var sd float64
+	for _, v := range onset {
+		sd += v * v
+	}
+	sd = math.Sqrt(sd / float64(len(onset)))
+	if sd == 0 {
+		sd = 1
+	}
+
+	score := make([]float64, len(onset))
+	back := make([]int, len(onset))
+	for t := range onset {
+		score[t] = onset[t] / sd
+		back[t] = -1
+		lo := t - int(math.Round(2*period))
+		hi := t - int(math.Round(period/2))
+		if lo < 0 {
+			lo = 0
+		}
+		best := math.Inf(-1)
+		for p := lo; p <= hi; p++ {
+			d := math.Log(float64(t-p) / period)
+			if s := score[p] - tightness*d*d; s > best {
+				best, back[t] = s, p
+			}
+		}
+		if back[t] >= 0 {
+			score[t] += best
+		}
+	}
+
+	// End on the best score within the last period.
+	last := len(score) - 1
+	for t := len(score) - int(period); t < len(score)-1; t++ {
+		if t >= 0 && score[t] > score[last] {
+			last = t
+		}
+	}
+
+	var beats []int
+	for t := last; t >= 0; t = back[t] {
+		beats = append(beats, t)
+	}
+	r := make([]float64, len(beats))
+	for i, t := range beats {
+		r[len(beats)-1-i] = float64(t) / rate
+	}
+	return r
+}
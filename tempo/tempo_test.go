@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package tempo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+const (
+	fs        = 8000.0
+	frameSize = 512
+	hop       = 128
+	rate      = fs / hop
+)
+
+// clicks returns a click track of decaying noise bursts at bpm, starting at
+// start seconds.
+func clicks(bpm, start, seconds float64) []float64 {
+	r := rand.New(rand.NewSource(1))
+	x := make([]float64, int(seconds*fs))
+	for c := start; c < seconds; c += 60 / bpm {
+		i0 := int(c * fs)
+		for i := i0; i < i0+400 && i < len(x); i++ {
+			x[i] += r.NormFloat64() * math.Exp(-float64(i-i0)/80)
+		}
+	}
+	for i := range x {
+		x[i] += 0.01 * r.NormFloat64()
+	}
+	return x
+}
+
+func TestOnsetStrength(t *testing.T) {
+	x := clicks(60, 0.5, 3)
+	o := OnsetStrength(x, frameSize, hop)
+	if n := (len(x)-frameSize)/hop + 1; len(o) != n {
+		t.Fatal("OnsetStrength length error:", len(o), n)
+	}
+	// The strongest onsets are at the clicks.
+	for _, c := range []float64{0.5, 1.5, 2.5} {
+		best := 0
+		for i := range o {
+			if math.Abs(float64(i)/rate-c) < 0.5 && o[i] > o[best] {
+				best = i
+			}
+		}
+		center := (float64(best*hop) + frameSize/2) / fs
+		if math.Abs(center-c) > float64(frameSize)/fs/2 {
+			t.Error("OnsetStrength error\ninput:", c, "\noutput:", center)
+		}
+	}
+}
+
+func TestTempo(t *testing.T) {
+	for _, bpm := range []float64{72, 90, 120, 150} {
+		o := OnsetStrength(clicks(bpm, 0.2, 12), frameSize, hop)
+		if e := Tempo(o, rate, 40, 240); math.Abs(e-bpm) > 1 {
+			t.Error("Tempo error\ninput:", bpm, "\noutput:", e)
+		}
+	}
+}
+
+func TestBeats(t *testing.T) {
+	const bpm, start = 100.0, 0.3
+	o := OnsetStrength(clicks(bpm, start, 10), frameSize, hop)
+	beats := Beats(o, rate, Tempo(o, rate, 40, 240), 100)
+	if len(beats) < 15 {
+		t.Fatal("Beats count error:", len(beats))
+	}
+	offset := float64(frameSize) / 2 / fs
+	for _, b := range beats {
+		// The nearest click.
+		k := math.Round((b + offset - start) / (60 / bpm))
+		c := start + k*60/bpm
+		if math.Abs(b+offset-c) > float64(frameSize)/fs/2 {
+			t.Error("Beats error\noutput:", b+offset, "\nnearest click:", c)
+		}
+	}
+}
@@ -0,0 +1,199 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package transform provides discrete cosine and sine transforms, computed
+// with the fft package.
+//
+// The transforms use the unnormalized definitions of scipy.fft.dct and
+// scipy.fft.dst, so for example the DCT-II of x is
+//
+//	y[k] = 2 Σ x[n] cos(πk(2n+1)/2N)
+//
+// and the inverse functions undo this scaling.
+package transform
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Type is the type of a discrete cosine or sine transform.
+type Type int
+
+const (
+	TypeI Type = iota + 1
+	TypeII
+	TypeIII
+	TypeIV
+)
+
+// DCT returns the type II discrete cosine transform of x, the transform
+// usually meant by "the DCT".
+func DCT(x []float64) []float64 {
+	return DCTType(x, TypeII)
+}
+
+// IDCT returns the inverse of DCT, so that IDCT(DCT(x)) is x.
+func IDCT(x []float64) []float64 {
+	return IDCTType(x, TypeII)
+}
+
+// DCTType returns the discrete cosine transform of type t of x. The type I
+// transform requires at least 2 points.
+// Reference: http://en.wikipedia.org/wiki/Discrete_cosine_transform
+func DCTType(x []float64, t Type) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	switch t {
+	case TypeI:
+		return dct1(x)
+	case TypeII:
+		return dct2(x)
+	case TypeIII:
+		return dct3(x)
+	case TypeIV:
+		return dct4(x)
+	}
+	panic("invalid type")
+}
+
+// IDCTType returns the inverse of the discrete cosine transform of type t,
+// so that IDCTType(DCTType(x, t), t) is x. The inverse of each type is a
+// scaled transform of type I, III, II and IV, respectively.
+func IDCTType(x []float64, t Type) []float64 {
+	n := len(x)
+	var y []float64
+	switch t {
+	case TypeI:
+		y = DCTType(x, TypeI)
+		n = 2 * (n - 1)
+	case TypeII:
+		y = DCTType(x, TypeIII)
+		n *= 2
+	case TypeIII:
+		y = DCTType(x, TypeII)
+		n *= 2
+	case TypeIV:
+		y = DCTType(x, TypeIV)
+		n *= 2
+	default:
+		panic("invalid type")
+	}
+	return scale(y, n)
+}
+
+// dct1 computes the DCT-I as the real part of the FFT of the even extension
+// of x, of length 2(N-1).
+func dct1(x []float64) []float64 {
+	n := len(x)
+	if n < 2 {
+		panic("DCT-I requires at least 2 points")
+	}
+
+	v := make([]complex128, 2*(n-1))
+	for i, f := range x {
+		v[i] = complex(f, 0)
+	}
+	for i := 1; i < n-1; i++ {
+		v[len(v)-i] = complex(x[i], 0)
+	}
+
+	V := fft.FFT(v)
+	y := make([]float64, n)
+	for k := range y {
+		y[k] = real(V[k])
+	}
+	return y
+}
+
+// dct2 computes the DCT-II from a zero-padded FFT of length 2N:
+//
+//	y[k] = 2 Re(exp(-iπk/2N) Σ x[n] exp(-2πink/2N))
+func dct2(x []float64) []float64 {
+	n := len(x)
+	v := make([]complex128, 2*n)
+	for i, f := range x {
+		v[i] = complex(f, 0)
+	}
+
+	V := fft.FFT(v)
+	y := make([]float64, n)
+	for k := range y {
+		y[k] = 2 * real(V[k]*shift(-float64(k), n))
+	}
+	return y
+}
+
+// dct3 computes the DCT-III from a zero-padded FFT of length 2N:
+//
+//	y[k] = Re Σ a[n] x[n] exp(iπn/2N) exp(2πink/2N)
+//
+// where a[0] is 1 and a[n] is 2 otherwise. The sum is an unscaled inverse
+// DFT, computed as the conjugate of the FFT of the conjugate.
+func dct3(x []float64) []float64 {
+	n := len(x)
+	v := make([]complex128, 2*n)
+	for i, f := range x {
+		if i > 0 {
+			f *= 2
+		}
+		v[i] = cmplx.Conj(complex(f, 0) * shift(float64(i), n))
+	}
+
+	V := fft.FFT(v)
+	y := make([]float64, n)
+	for k := range y {
+		y[k] = real(V[k])
+	}
+	return y
+}
+
+// dct4 computes the DCT-IV from a zero-padded FFT of length 2N:
+//
+//	y[k] = 2 Re(exp(-iπ(2k+1)/4N) Σ x[n] exp(-iπn/2N) exp(-2πink/2N))
+func dct4(x []float64) []float64 {
+	n := len(x)
+	v := make([]complex128, 2*n)
+	for i, f := range x {
+		v[i] = complex(f, 0) * shift(-float64(i), n)
+	}
+
+	V := fft.FFT(v)
+	y := make([]float64, n)
+	for k := range y {
+		y[k] = 2 * real(V[k]*shift(-(float64(k)+0.5), n))
+	}
+	return y
+}
+
+// shift returns exp(iπa/2n).
+func shift(a float64, n int) complex128 {
+	sin, cos := math.Sincos(math.Pi * a / float64(2*n))
+	return complex(cos, sin)
+}
+
+// scale divides each element of x by n, in place, and returns x.
+func scale(x []float64, n int) []float64 {
+	d := float64(n)
+	for i := range x {
+		x[i] /= d
+	}
+	return x
+}
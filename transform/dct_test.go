@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+var dctTests = []struct {
+	t   Type
+	in  []float64
+	out []float64
+}{
+	{TypeI, []float64{1, 2, 3, 4}, []float64{15, -4, 0, -1}},
+	{TypeII, []float64{1, 2, 3, 4}, []float64{20, -6.308644059797899, 0, -0.44834152916796777}},
+	{TypeIII, []float64{1, 2, 3, 4}, []float64{11.999626276085152, -9.102943217749221, 2.617661843510648, -1.5143449018465822}},
+	{TypeIV, []float64{1, 2, 3, 4}, []float64{10.181592984263283, -9.446695610035622, 5.010298174943416, -4.689564857456724}},
+	{TypeII, []float64{3}, []float64{6}},
+	{TypeIII, []float64{3}, []float64{3}},
+	{TypeI, []float64{1, 1}, []float64{2, 0}},
+	{TypeII, []float64{}, []float64{}},
+}
+
+func TestDCT(t *testing.T) {
+	for _, dt := range dctTests {
+		o := DCTType(dt.in, dt.t)
+		if !dsputils.PrettyClose(o, dt.out) {
+			t.Error("DCT error\ntype:", dt.t, "\ninput:", dt.in, "\noutput:", o, "\nexpected:", dt.out)
+		}
+		if len(dt.in) == 0 {
+			continue
+		}
+		if i := IDCTType(o, dt.t); !dsputils.PrettyClose(i, dt.in) {
+			t.Error("IDCT error\ntype:", dt.t, "\ninput:", o, "\noutput:", i, "\nexpected:", dt.in)
+		}
+	}
+
+	// Compare with the definitions for lengths of each FFT algorithm.
+	for _, n := range []int{2, 5, 8, 17, 30} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = math.Sin(float64(i*i)) + 0.25
+		}
+		for t0 := TypeI; t0 <= TypeIV; t0++ {
+			if o, e := DCTType(x, t0), naiveDCT(x, t0); !dsputils.PrettyClose(o, e) {
+				t.Error("DCT error\ntype:", t0, "\ninput:", x, "\noutput:", o, "\nexpected:", e)
+			}
+			if o := IDCTType(DCTType(x, t0), t0); !dsputils.PrettyClose(o, x) {
+				t.Error("IDCT error\ntype:", t0, "\noutput:", o, "\nexpected:", x)
+			}
+		}
+	}
+
+	if o, e := DCT([]float64{1, 2, 3, 4}), dctTests[1].out; !dsputils.PrettyClose(o, e) {
+		t.Error("DCT error\noutput:", o, "\nexpected:", e)
+	}
+	if o := IDCT(DCT([]float64{1, 2, 3, 4})); !dsputils.PrettyClose(o, []float64{1, 2, 3, 4}) {
+		t.Error("IDCT error\noutput:", o)
+	}
+}
+
+// naiveDCT computes the DCT of type t from its definition.
+func naiveDCT(x []float64, t Type) []float64 {
+	n := len(x)
+	N := float64(n)
+	y := make([]float64, n)
+	for k := range y {
+		K := float64(k)
+		for i, v := range x {
+			I := float64(i)
+			switch t {
+			case TypeI:
+				if i == 0 || i == n-1 {
+					y[k] += v * math.Cos(math.Pi*K*I/(N-1))
+				} else {
+					y[k] += 2 * v * math.Cos(math.Pi*K*I/(N-1))
+				}
+			case TypeII:
+				y[k] += 2 * v * math.Cos(math.Pi*K*(2*I+1)/(2*N))
+			case TypeIII:
+				if i == 0 {
+					y[k] += v
+				} else {
+					y[k] += 2 * v * math.Cos(math.Pi*I*(2*K+1)/(2*N))
+				}
+			case TypeIV:
+				y[k] += 2 * v * math.Cos(math.Pi*(2*I+1)*(2*K+1)/(4*N))
+			}
+		}
+	}
+	return y
+}
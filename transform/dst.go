@@ -0,0 +1,121 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package transform
+
+import (
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// DST returns the type II discrete sine transform of x.
+func DST(x []float64) []float64 {
+	return DSTType(x, TypeII)
+}
+
+// IDST returns the inverse of DST, so that IDST(DST(x)) is x.
+func IDST(x []float64) []float64 {
+	return IDSTType(x, TypeII)
+}
+
+// DSTType returns the discrete sine transform of type t of x. Types II, III
+// and IV are computed from the cosine transform of the same type, by
+// reversing the input or output and negating alternate elements.
+// Reference: http://en.wikipedia.org/wiki/Discrete_sine_transform
+func DSTType(x []float64, t Type) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+
+	switch t {
+	case TypeI:
+		return dst1(x)
+	case TypeII:
+		// DST-II(x)[k] = DCT-II((-1)^n x[n])[N-1-k]
+		return reverse(dct2(alternate(copyOf(x))))
+	case TypeIII:
+		// DST-III(x)[k] = (-1)^k DCT-III(reverse(x))[k]
+		return alternate(dct3(reverse(copyOf(x))))
+	case TypeIV:
+		// DST-IV(x)[k] = (-1)^k DCT-IV(reverse(x))[k]
+		return alternate(dct4(reverse(copyOf(x))))
+	}
+	panic("invalid type")
+}
+
+// IDSTType returns the inverse of the discrete sine transform of type t, so
+// that IDSTType(DSTType(x, t), t) is x. The inverse of each type is a scaled
+// transform of type I, III, II and IV, respectively.
+func IDSTType(x []float64, t Type) []float64 {
+	n := len(x)
+	var y []float64
+	switch t {
+	case TypeI:
+		y = DSTType(x, TypeI)
+		n = 2 * (n + 1)
+	case TypeII:
+		y = DSTType(x, TypeIII)
+		n *= 2
+	case TypeIII:
+		y = DSTType(x, TypeII)
+		n *= 2
+	case TypeIV:
+		y = DSTType(x, TypeIV)
+		n *= 2
+	default:
+		panic("invalid type")
+	}
+	return scale(y, n)
+}
+
+// dst1 computes the DST-I from the FFT of the odd extension of x, of length
+// 2(N+1): y[k] is -Im(V[k+1]).
+func dst1(x []float64) []float64 {
+	n := len(x)
+	v := make([]complex128, 2*(n+1))
+	for i, f := range x {
+		v[i+1] = complex(f, 0)
+		v[len(v)-1-i] = complex(-f, 0)
+	}
+
+	V := fft.FFT(v)
+	y := make([]float64, n)
+	for k := range y {
+		y[k] = -imag(V[k+1])
+	}
+	return y
+}
+
+func copyOf(x []float64) []float64 {
+	r := make([]float64, len(x))
+	copy(r, x)
+	return r
+}
+
+// reverse reverses x in place and returns it.
+func reverse(x []float64) []float64 {
+	for i, j := 0, len(x)-1; i < j; i, j = i+1, j-1 {
+		x[i], x[j] = x[j], x[i]
+	}
+	return x
+}
+
+// alternate negates the odd-indexed elements of x in place and returns it.
+func alternate(x []float64) []float64 {
+	for i := 1; i < len(x); i += 2 {
+		x[i] = -x[i]
+	}
+	return x
+}
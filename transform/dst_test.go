@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+var dstTests = []struct {
+	t   Type
+	in  []float64
+	out []float64
+}{
+	{TypeI, []float64{1, 2, 3, 4}, []float64{15.38841768587627, -6.881909602355868, 3.6327126400268033, -1.6245984811645293}},
+	{TypeII, []float64{1, 2, 3, 4}, []float64{13.065629648763766, -5.656854249492381, 5.411961001461966, -4}},
+	{TypeIII, []float64{1, 2, 3, 4}, []float64{13.137071184544089, -1.6199144044217744, 0.7232313460858415, -0.5197830649482911}},
+	{TypeIV, []float64{1, 2, 3, 4}, []float64{15.44756149315178, -0.44693337867146443, 1.003150694407037, 0.4083909335848732}},
+	{TypeI, []float64{3}, []float64{6}},
+	{TypeII, []float64{}, []float64{}},
+}
+
+func TestDST(t *testing.T) {
+	for _, dt := range dstTests {
+		o := DSTType(dt.in, dt.t)
+		if !dsputils.PrettyClose(o, dt.out) {
+			t.Error("DST error\ntype:", dt.t, "\ninput:", dt.in, "\noutput:", o, "\nexpected:", dt.out)
+		}
+		if len(dt.in) == 0 {
+			continue
+		}
+		if i := IDSTType(o, dt.t); !dsputils.PrettyClose(i, dt.in) {
+			t.Error("IDST error\ntype:", dt.t, "\ninput:", o, "\noutput:", i, "\nexpected:", dt.in)
+		}
+	}
+
+	for _, n := range []int{1, 2, 5, 8, 17, 30} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = math.Cos(float64(i*i)) - 0.25
+		}
+		for t0 := TypeI; t0 <= TypeIV; t0++ {
+			if o, e := DSTType(x, t0), naiveDST(x, t0); !dsputils.PrettyClose(o, e) {
+				t.Error("DST error\ntype:", t0, "\ninput:", x, "\noutput:", o, "\nexpected:", e)
+			}
+			if o := IDSTType(DSTType(x, t0), t0); !dsputils.PrettyClose(o, x) {
+				t.Error("IDST error\ntype:", t0, "\noutput:", o, "\nexpected:", x)
+			}
+		}
+	}
+
+	x := []float64{1, 2, 3, 4}
+	if o := DST(x); !dsputils.PrettyClose(o, dstTests[1].out) {
+		t.Error("DST error\noutput:", o, "\nexpected:", dstTests[1].out)
+	}
+	if o := IDST(DST(x)); !dsputils.PrettyClose(o, x) {
+		t.Error("IDST error\noutput:", o, "\nexpected:", x)
+	}
+}
+
+// naiveDST computes the DST of type t from its definition.
+func naiveDST(x []float64, t Type) []float64 {
+	n := len(x)
+	N := float64(n)
+	y := make([]float64, n)
+	for k := range y {
+		K := float64(k)
+		for i, v := range x {
+			I := float64(i)
+			switch t {
+			case TypeI:
+				y[k] += 2 * v * math.Sin(math.Pi*(K+1)*(I+1)/(N+1))
+			case TypeII:
+				y[k] += 2 * v * math.Sin(math.Pi*(K+1)*(2*I+1)/(2*N))
+			case TypeIII:
+				if i == n-1 {
+					y[k] += v * math.Sin(math.Pi*(2*K+1)/2)
+				} else {
+					y[k] += 2 * v * math.Sin(math.Pi*(2*K+1)*(I+1)/(2*N))
+				}
+			case TypeIV:
+				y[k] += 2 * v * math.Sin(math.Pi*(2*I+1)*(2*K+1)/(4*N))
+			}
+		}
+	}
+	return y
+}
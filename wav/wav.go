@@ -14,15 +14,18 @@
  * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
  */
 
-// Package wav provides support for the WAV file format.
+// Package wav provides support for the WAV file format. Wav reads files and
+// Writer writes them.
 //
-// Supported formats are PCM 8- and 16-bit, and IEEE float. Extended chunks
+// Supported formats are PCM 8-, 16-, 24- and 32-bit, and IEEE float 32- and
+// 64-bit, including their WAVE_FORMAT_EXTENSIBLE forms. Extended chunks
 // (JUNK, bext, and others added by tools like ProTools) are ignored.
 package wav
 
 import (
 	"bytes"
 	"encoding/binary"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -31,8 +34,22 @@ import (
 )
 
 const (
-	wavFormatPCM       = 1
-	wavFormatIEEEFloat = 3
+	wavFormatPCM        = 1
+	wavFormatIEEEFloat  = 3
+	wavFormatExtensible = 0xfffe
+)
+
+// Errors returned by New and the read methods. Errors for unsupported
+// formats wrap ErrUnknownFormat or ErrUnknownBitsPerSample with the value
+// found; test for them with errors.Is.
+var (
+	ErrMissingRIFF          = errors.New("wav: missing RIFF")
+	ErrMissingWAVE          = errors.New("wav: missing WAVE")
+	ErrBadFmtSize           = errors.New("wav: bad fmt size")
+	ErrMissingFmt           = errors.New("wav: data chunk before fmt chunk")
+	ErrUnknownFormat        = errors.New("wav: unknown audio format")
+	ErrUnknownBitsPerSample = errors.New("wav: unknown bits per sample")
+	ErrNotSeekable          = errors.New("wav: reader is not an io.ReadSeeker")
 )
 
 // Header contains Wav fmt chunk data.
@@ -54,6 +71,11 @@ type Wav struct {
 	Duration time.Duration
 
 	r io.Reader
+
+	// The underlying reader if it can seek, and the offset and size of the
+	// data chunk in it.
+	rs         io.ReadSeeker
+	start, end int64
 }
 
 // New reads the WAV header from r.
@@ -64,10 +86,10 @@ func New(r io.Reader) (*Wav, error) {
 		return nil, err
 	}
 	if string(header[0:4]) != "RIFF" {
-		return nil, fmt.Errorf("wav: missing RIFF")
+		return nil, ErrMissingRIFF
 	}
 	if string(header[8:12]) != "WAVE" {
-		return nil, fmt.Errorf("wav: missing WAVE")
+		return nil, ErrMissingWAVE
 	}
 	hasFmt := false
 	for {
@@ -78,7 +100,7 @@ func New(r io.Reader) (*Wav, error) {
 		switch typ := string(header[:4]); typ {
 		case "fmt ":
 			if sz < 16 {
-				return nil, fmt.Errorf("wav: bad fmt size")
+				return nil, ErrBadFmtSize
 			}
 			f := make([]byte, sz)
 			if _, err := io.ReadFull(r, f); err != nil {
@@ -87,20 +109,33 @@ func New(r io.Reader) (*Wav, error) {
 			if err := binary.Read(bytes.NewBuffer(f), binary.LittleEndian, &w.Header); err != nil {
 				return nil, err
 			}
+			// The format of extensible files is the first two bytes of the
+			// subformat GUID, after cbSize, wValidBitsPerSample and
+			// dwChannelMask.
+			if w.AudioFormat == wavFormatExtensible && len(f) >= 26 {
+				w.AudioFormat = binary.LittleEndian.Uint16(f[24:])
+			}
 			switch w.AudioFormat {
 			case wavFormatPCM:
 			case wavFormatIEEEFloat:
 			default:
-				return nil, fmt.Errorf("wav: unknown audio format: %02x", w.AudioFormat)
+				return nil, fmt.Errorf("%w: %02x", ErrUnknownFormat, w.AudioFormat)
 			}
 			hasFmt = true
 		case "data":
 			if !hasFmt {
-				return nil, fmt.Errorf("wav: unexpected fmt chunk")
+				return nil, ErrMissingFmt
+			}
+			if w.BitsPerSample > 0 {
+				w.Samples = int(int64(sz) * 8 / int64(w.BitsPerSample))
 			}
-			w.Samples = int(sz) / int(w.BitsPerSample) * 8
 			w.Duration = time.Duration(w.Samples) * time.Second / time.Duration(w.SampleRate) / time.Duration(w.NumChannels)
 			w.r = io.LimitReader(r, int64(sz))
+			if rs, ok := r.(io.ReadSeeker); ok {
+				if off, err := rs.Seek(0, io.SeekCurrent); err == nil {
+					w.rs, w.start, w.end = rs, off, off+int64(sz)
+				}
+			}
 			return &w, nil
 		default:
 			io.CopyN(ioutil.Discard, r, int64(sz))
@@ -108,8 +143,10 @@ func New(r io.Reader) (*Wav, error) {
 	}
 }
 
-// ReadSamples returns a [n]T, where T is uint8, int16, or float32, based on the
-// wav data. n is the number of samples to return.
+// ReadSamples returns a [n]T, where T is uint8, int16, int32, float32, or
+// float64, based on the wav data. n is the number of samples to return. 24-
+// and 32-bit PCM samples are both returned as int32; 24-bit samples are sign
+// extended, so they lie in [-1<<23, 1<<23).
 func (w *Wav) ReadSamples(n int) (interface{}, error) {
 	var data interface{}
 	switch w.AudioFormat {
@@ -119,13 +156,24 @@ func (w *Wav) ReadSamples(n int) (interface{}, error) {
 			data = make([]uint8, n)
 		case 16:
 			data = make([]int16, n)
+		case 24:
+			return w.read24(n)
+		case 32:
+			data = make([]int32, n)
 		default:
-			return nil, fmt.Errorf("wav: unknown bits per sample: %v", w.BitsPerSample)
+			return nil, fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, w.BitsPerSample)
 		}
 	case wavFormatIEEEFloat:
-		data = make([]float32, n)
+		switch w.BitsPerSample {
+		case 32:
+			data = make([]float32, n)
+		case 64:
+			data = make([]float64, n)
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, w.BitsPerSample)
+		}
 	default:
-		return nil, fmt.Errorf("wav: unknown audio format")
+		return nil, fmt.Errorf("%w: %02x", ErrUnknownFormat, w.AudioFormat)
 	}
 	if err := binary.Read(w.r, binary.LittleEndian, data); err != nil {
 		return nil, err
@@ -133,6 +181,20 @@ func (w *Wav) ReadSamples(n int) (interface{}, error) {
 	return data, nil
 }
 
+// read24 reads n packed 3-byte samples and returns them sign extended to
+// int32.
+func (w *Wav) read24(n int) ([]int32, error) {
+	b := make([]byte, 3*n)
+	if _, err := io.ReadFull(w.r, b); err != nil {
+		return nil, err
+	}
+	d := make([]int32, n)
+	for i := range d {
+		d[i] = int32(uint32(b[3*i])<<8|uint32(b[3*i+1])<<16|uint32(b[3*i+2])<<24) >> 8
+	}
+	return d, nil
+}
+
 // ReadFloats is like ReadSamples, but it converts any underlying data to a
 // float32.
 func (w *Wav) ReadFloats(n int) ([]float32, error) {
@@ -152,10 +214,127 @@ func (w *Wav) ReadFloats(n int) ([]float32, error) {
 		for i, v := range d {
 			f[i] = (float32(v) - math.MinInt16) / (math.MaxInt16 - math.MinInt16)
 		}
+	case []int32:
+		// Map [-1<<(b-1), 1<<(b-1)) to [0, 1] as for 16-bit samples.
+		h := math.Ldexp(1, int(w.BitsPerSample)-1)
+		f = make([]float32, len(d))
+		for i, v := range d {
+			f[i] = float32((float64(v) + h) / (2*h - 1))
+		}
 	case []float32:
 		f = d
+	case []float64:
+		f = make([]float32, len(d))
+		for i, v := range d {
+			f[i] = float32(v)
+		}
 	default:
-		return nil, fmt.Errorf("wav: unknown type: %T", d)
+		return nil, fmt.Errorf("%w: %T", ErrUnknownFormat, d)
 	}
 	return f, nil
 }
+
+// ReadFloat64s implements dsputils.SampleReader. It reads up to len(p)
+// interleaved samples into p, scaled as ReadFloats, and returns io.EOF after
+// the last sample.
+func (w *Wav) ReadFloat64s(p []float64) (int, error) {
+	f, err := w.readFloats(len(p))
+	for i, v := range f {
+		p[i] = float64(v)
+	}
+	return len(f), err
+}
+
+// ReadFloat32s implements dsputils.Float32Reader. It is like ReadFloat64s,
+// but for float32 samples.
+func (w *Wav) ReadFloat32s(p []float32) (int, error) {
+	f, err := w.readFloats(len(p))
+	return copy(p, f), err
+}
+
+// readFloats is like ReadFloats, but reads at most the remaining samples and
+// returns io.EOF if there are none.
+func (w *Wav) readFloats(n int) ([]float32, error) {
+	bps := int(w.BitsPerSample / 8)
+	if bps == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, w.BitsPerSample)
+	}
+	want := n
+	if lr, ok := w.r.(*io.LimitedReader); ok {
+		if rem := int(lr.N) / bps; rem < n {
+			n = rem
+		}
+	}
+	if n == 0 && want > 0 {
+		return nil, io.EOF
+	}
+	// The data may end before its reported size, as when it was written
+	// with the size unknown, so decode the whole samples that were read.
+	b := make([]byte, n*bps)
+	m, err := io.ReadFull(w.r, b)
+	if err == io.EOF {
+		return nil, io.EOF
+	} else if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	r := Wav{Header: w.Header, r: bytes.NewReader(b[:m-m%bps])}
+	return r.ReadFloats(m / bps)
+}
+
+// ReadChannels reads up to n samples from each channel and returns them
+// deinterleaved, one slice per channel, scaled as ReadFloats. Fewer than n
+// samples are returned at the end of the data, and io.EOF after the last
+// sample.
+func (w *Wav) ReadChannels(n int) ([][]float64, error) {
+	c := int(w.NumChannels)
+	if c < 1 {
+		return nil, fmt.Errorf("wav: invalid number of channels: %v", c)
+	}
+	f, err := w.readFloats(n * c)
+	if err != nil {
+		return nil, err
+	}
+
+	frames := len(f) / c
+	r := make([][]float64, c)
+	for i := range r {
+		r[i] = make([]float64, frames)
+		for j := range r[i] {
+			r[i][j] = float64(f[j*c+i])
+		}
+	}
+	return r, nil
+}
+
+// SeekSample moves the read position to the sample at offset, counted like
+// Samples in interleaved samples from the start of the data, so frame i of a
+// multichannel file starts at sample i * NumChannels. It returns
+// ErrNotSeekable if the reader passed to New is not an io.ReadSeeker.
+func (w *Wav) SeekSample(offset int) error {
+	if w.rs == nil {
+		return ErrNotSeekable
+	}
+	bps := int64(w.BitsPerSample / 8)
+	if bps == 0 {
+		return fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, w.BitsPerSample)
+	}
+	pos := w.start + int64(offset)*bps
+	if offset < 0 || pos > w.end {
+		return fmt.Errorf("wav: sample offset out of range: %v", offset)
+	}
+	if _, err := w.rs.Seek(pos, io.SeekStart); err != nil {
+		return err
+	}
+	w.r = io.LimitReader(w.rs, w.end-pos)
+	return nil
+}
+
+// ReadSamplesAt returns n samples starting at the sample at offset, as
+// SeekSample followed by ReadSamples. Later reads continue after the
+// returned samples.
+func (w *Wav) ReadSamplesAt(offset, n int) (interface{}, error) {
+	if err := w.SeekSample(offset); err != nil {
+		return nil, err
+	}
+	return w.ReadSamples(n)
+}
@@ -2,9 +2,15 @@ package wav
 
 import (
 	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
 	"os"
 	"reflect"
 	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
 )
 
 func checkHeader(b []byte) error {
@@ -50,6 +56,73 @@ func TestInvalidHeaderValidation(t *testing.T) {
 	validateErrorForMissingHeaderValue(err, "data")
 }
 
+func TestSentinelErrors(t *testing.T) {
+	header := func(format, bits uint16, data bool) []byte {
+		var b bytes.Buffer
+		b.WriteString("RIFF\x00\x00\x00\x00WAVE")
+		if format != 0 {
+			b.WriteString("fmt \x10\x00\x00\x00")
+			binary.Write(&b, binary.LittleEndian, Header{
+				AudioFormat:   format,
+				NumChannels:   1,
+				SampleRate:    8000,
+				ByteRate:      8000 * uint32(bits) / 8,
+				BlockAlign:    bits / 8,
+				BitsPerSample: bits,
+			})
+		}
+		if data {
+			b.WriteString("data\x04\x00\x00\x00\x00\x00\x00\x00")
+		}
+		return b.Bytes()
+	}
+	riff := header(0, 0, false)
+	badFmt := append(riff[:12:12], "fmt \x04\x00\x00\x00"...)
+
+	tests := []struct {
+		name string
+		b    []byte
+		err  error
+	}{
+		{"riff", make([]byte, 12), ErrMissingRIFF},
+		{"wave", append([]byte("RIFF\x00\x00\x00\x00"), make([]byte, 4)...), ErrMissingWAVE},
+		{"fmt size", badFmt, ErrBadFmtSize},
+		{"no fmt", header(0, 0, true), ErrMissingFmt},
+		{"format", header(2, 16, true), ErrUnknownFormat},
+	}
+	for _, tt := range tests {
+		if err := checkHeader(tt.b); !errors.Is(err, tt.err) {
+			t.Errorf("%s: got %v, expected %v", tt.name, err, tt.err)
+		}
+	}
+
+	w, err := New(bytes.NewReader(header(wavFormatPCM, 12, true)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ReadSamples(1); !errors.Is(err, ErrUnknownBitsPerSample) {
+		t.Errorf("bits: got %v, expected %v", err, ErrUnknownBitsPerSample)
+	}
+
+	// Fewer than 8 bits per sample must not divide by zero.
+	for _, bits := range []uint16{0, 4} {
+		read := map[string]func(w *Wav) error{
+			"ReadFloat64s": func(w *Wav) error { _, err := w.ReadFloat64s(make([]float64, 1)); return err },
+			"ReadFloat32s": func(w *Wav) error { _, err := w.ReadFloat32s(make([]float32, 1)); return err },
+			"ReadChannels": func(w *Wav) error { _, err := w.ReadChannels(1); return err },
+		}
+		for name, f := range read {
+			w, err := New(bytes.NewReader(header(wavFormatPCM, bits, true)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := f(w); !errors.Is(err, ErrUnknownBitsPerSample) {
+				t.Errorf("%s %d bits: got %v, expected %v", name, bits, err, ErrUnknownBitsPerSample)
+			}
+		}
+	}
+}
+
 type wavTest struct {
 	w   Wav
 	typ reflect.Type
@@ -67,8 +140,8 @@ func TestWav(t *testing.T) {
 					BlockAlign:    2,
 					BitsPerSample: 16,
 				},
-				Samples:  41888,
-				Duration: 949841269,
+				Samples:  41895,
+				Duration: 950000000,
 			},
 			typ: reflect.TypeOf(make([]uint8, 0)),
 		},
@@ -106,5 +179,401 @@ func TestWav(t *testing.T) {
 
 func eq(x, y Wav) bool {
 	x.r, y.r = nil, nil
+	x.rs, y.rs = nil, nil
+	x.start, x.end, y.start, y.end = 0, 0, 0, 0
 	return x == y
 }
+
+func TestReadFloat64s(t *testing.T) {
+	for _, name := range []string{"small.wav", "float.wav"} {
+		f, err := os.Open(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		w, err := New(f)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		n := int(w.r.(*io.LimitedReader).N) / int(w.BitsPerSample/8)
+		if n != w.Samples {
+			t.Errorf("%v: Samples is %v, expected %v", name, w.Samples, n)
+		}
+		x, err := dsputils.ReadAll(w)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		if len(x) != n {
+			t.Errorf("%v: got %v samples, expected %v", name, len(x), n)
+		}
+
+		f.Seek(0, 0)
+		w, _ = New(f)
+		e, err := w.ReadFloats(100)
+		if err != nil {
+			t.Fatalf("%v: %v", name, err)
+		}
+		for i, v := range e {
+			if x[i] != float64(v) {
+				t.Errorf("%v: sample %v: got %v, expected %v", name, i, x[i], v)
+				break
+			}
+		}
+	}
+}
+
+func TestReadFloat32s(t *testing.T) {
+	f, err := os.Open("float.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w, err := New(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e, err := w.ReadFloats(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f.Seek(0, 0)
+	w, _ = New(f)
+	p := make([]float32, 100)
+	if n, err := w.ReadFloat32s(p); n != len(p) || err != nil {
+		t.Fatalf("got %v, %v, expected %v, nil", n, err, len(p))
+	}
+	if !reflect.DeepEqual(p, e) {
+		t.Error("ReadFloat32s error\noutput:", p, "\nexpected:", e)
+	}
+
+	var total int
+	for {
+		n, err := w.ReadFloat32s(p)
+		total += n
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := w.Samples - 100; total != n {
+		t.Errorf("got %v samples, expected %v", total, n)
+	}
+}
+
+// makeWav returns a WAV file with header h and the samples in data.
+func makeWav(h Header, data interface{}) []byte {
+	var d bytes.Buffer
+	binary.Write(&d, binary.LittleEndian, data)
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, uint32(36+d.Len()))
+	b.WriteString("WAVEfmt \x10\x00\x00\x00")
+	binary.Write(&b, binary.LittleEndian, h)
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, uint32(d.Len()))
+	b.Write(d.Bytes())
+	return b.Bytes()
+}
+
+func TestReadChannels(t *testing.T) {
+	h := Header{
+		AudioFormat:   wavFormatIEEEFloat,
+		NumChannels:   3,
+		SampleRate:    8000,
+		ByteRate:      8000 * 12,
+		BlockAlign:    12,
+		BitsPerSample: 32,
+	}
+	data := []float32{
+		0, 10, 20,
+		1, 11, 21,
+		2, 12, 22,
+		3, 13, 23,
+		4, 14, 24,
+	}
+	w, err := New(bytes.NewReader(makeWav(h, data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range [][][]float64{
+		{{0, 1}, {10, 11}, {20, 21}},
+		{{2, 3}, {12, 13}, {22, 23}},
+		{{4}, {14}, {24}},
+	} {
+		o, err := w.ReadChannels(2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(o, e) {
+			t.Error("ReadChannels error\noutput:", o, "\nexpected:", e)
+		}
+	}
+	if _, err := w.ReadChannels(2); err != io.EOF {
+		t.Errorf("got %v, expected %v", err, io.EOF)
+	}
+
+	// PCM samples are scaled as ReadFloats.
+	h = Header{
+		AudioFormat:   wavFormatPCM,
+		NumChannels:   2,
+		SampleRate:    8000,
+		ByteRate:      8000 * 2,
+		BlockAlign:    2,
+		BitsPerSample: 8,
+	}
+	w, err = New(bytes.NewReader(makeWav(h, []uint8{0, 255, 51, 102})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := w.ReadChannels(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := [][]float64{{0, 0.2}, {1, 0.4}}; !dsputils.PrettyClose2F(o, e) {
+		t.Error("ReadChannels error\noutput:", o, "\nexpected:", e)
+	}
+}
+
+func TestSamples(t *testing.T) {
+	for _, tt := range []struct {
+		bits    uint16
+		data    interface{}
+		samples int
+	}{
+		{8, []uint8{1, 2, 3, 4, 5}, 5},
+		{16, []int16{1, 2, 3}, 3},
+		{16, make([]int16, 12), 12},
+	} {
+		h := Header{
+			AudioFormat:   wavFormatPCM,
+			NumChannels:   1,
+			SampleRate:    8000,
+			ByteRate:      8000 * uint32(tt.bits) / 8,
+			BlockAlign:    tt.bits / 8,
+			BitsPerSample: tt.bits,
+		}
+		w, err := New(bytes.NewReader(makeWav(h, tt.data)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Samples != tt.samples {
+			t.Errorf("%v bits: got %v samples, expected %v", tt.bits, w.Samples, tt.samples)
+		}
+	}
+}
+
+func TestRead24(t *testing.T) {
+	h := Header{
+		AudioFormat:   wavFormatPCM,
+		NumChannels:   1,
+		SampleRate:    48000,
+		ByteRate:      48000 * 3,
+		BlockAlign:    3,
+		BitsPerSample: 24,
+	}
+	data := []byte{
+		0x00, 0x00, 0x80,
+		0xff, 0xff, 0x7f,
+		0x01, 0x00, 0x00,
+		0xff, 0xff, 0xff,
+		0x00, 0x00, 0x00,
+		0x56, 0x34, 0x12,
+	}
+	w, err := New(bytes.NewReader(makeWav(h, data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Samples != 6 {
+		t.Errorf("got %v samples, expected 6", w.Samples)
+	}
+	o, err := w.ReadSamples(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	e := []int32{-1 << 23, 1<<23 - 1, 1, -1, 0, 0x123456}
+	if !reflect.DeepEqual(o, e) {
+		t.Error("ReadSamples error\noutput:", o, "\nexpected:", e)
+	}
+	if _, err := w.ReadSamples(1); err != io.EOF {
+		t.Errorf("got %v, expected %v", err, io.EOF)
+	}
+
+	w, _ = New(bytes.NewReader(makeWav(h, data)))
+	f, err := w.ReadFloats(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ef := []float32{0, 1, float32(8388609.0 / 16777215), float32(8388607.0 / 16777215), float32(8388608.0 / 16777215), float32((8388608.0 + 0x123456) / 16777215)}
+	if !reflect.DeepEqual(f, ef) {
+		t.Error("ReadFloats error\noutput:", f, "\nexpected:", ef)
+	}
+}
+
+func TestRead32(t *testing.T) {
+	pcm := Header{
+		AudioFormat:   wavFormatPCM,
+		NumChannels:   2,
+		SampleRate:    48000,
+		ByteRate:      48000 * 8,
+		BlockAlign:    8,
+		BitsPerSample: 32,
+	}
+	in := []int32{math.MinInt32, math.MaxInt32, 0, -1}
+	w, err := New(bytes.NewReader(makeWav(pcm, in)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := w.ReadFloats(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := []float32{0, 1, 0.5, 0.5}; !reflect.DeepEqual(f, e) {
+		t.Error("ReadFloats error\noutput:", f, "\nexpected:", e)
+	}
+
+	double := pcm
+	double.AudioFormat = wavFormatIEEEFloat
+	double.BitsPerSample = 64
+	double.BlockAlign = 16
+	double.ByteRate = 48000 * 16
+	fin := []float64{-1, 0.5, 1e-3, 0.25}
+	w, err = New(bytes.NewReader(makeWav(double, fin)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Samples != 4 {
+		t.Errorf("got %v samples, expected 4", w.Samples)
+	}
+	o, err := w.ReadSamples(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(o, fin) {
+		t.Error("ReadSamples error\noutput:", o, "\nexpected:", fin)
+	}
+}
+
+func TestExtensible(t *testing.T) {
+	// A WAVE_FORMAT_EXTENSIBLE fmt chunk for 24-bit stereo PCM, with the
+	// KSDATAFORMAT_SUBTYPE_PCM GUID.
+	var b bytes.Buffer
+	b.WriteString("RIFF\x00\x00\x00\x00WAVEfmt \x28\x00\x00\x00")
+	binary.Write(&b, binary.LittleEndian, Header{
+		AudioFormat:   wavFormatExtensible,
+		NumChannels:   2,
+		SampleRate:    96000,
+		ByteRate:      96000 * 6,
+		BlockAlign:    6,
+		BitsPerSample: 24,
+	})
+	b.Write([]byte{22, 0, 24, 0, 3, 0, 0, 0})
+	b.Write([]byte{1, 0, 0, 0, 0, 0, 0x10, 0, 0x80, 0, 0, 0xaa, 0, 0x38, 0x9b, 0x71})
+	b.WriteString("data\x06\x00\x00\x00")
+	b.Write([]byte{1, 0, 0, 0xff, 0xff, 0xff})
+
+	w, err := New(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.AudioFormat != wavFormatPCM {
+		t.Errorf("got format %v, expected %v", w.AudioFormat, wavFormatPCM)
+	}
+	o, err := w.ReadSamples(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := []int32{1, -1}; !reflect.DeepEqual(o, e) {
+		t.Error("ReadSamples error\noutput:", o, "\nexpected:", e)
+	}
+}
+
+func TestReadSamplesAt(t *testing.T) {
+	f, err := os.Open("small.wav")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	w, err := New(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := w.ReadSamples(w.Samples)
+	if err != nil {
+		t.Fatal(err)
+	}
+	all := d.([]int16)
+
+	for _, tt := range []struct{ off, n int }{
+		{0, 10},
+		{30000, 100},
+		{12345, 1},
+		{w.Samples - 5, 5},
+		{17, 0},
+	} {
+		o, err := w.ReadSamplesAt(tt.off, tt.n)
+		if err != nil {
+			t.Fatalf("%v: %v", tt, err)
+		}
+		if e := all[tt.off : tt.off+tt.n]; !reflect.DeepEqual(o, e) {
+			t.Error("ReadSamplesAt error\ninput:", tt, "\noutput:", o, "\nexpected:", e)
+		}
+	}
+
+	// Reads continue after a seek.
+	if err := w.SeekSample(100); err != nil {
+		t.Fatal(err)
+	}
+	p := make([]float64, 3)
+	if _, err := w.ReadFloat64s(p); err != nil {
+		t.Fatal(err)
+	}
+	o, err := w.ReadSamples(2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := all[103:105]; !reflect.DeepEqual(o, e) {
+		t.Error("ReadSamples after SeekSample error\noutput:", o, "\nexpected:", e)
+	}
+
+	if err := w.SeekSample(w.Samples); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := w.ReadFloat64s(p); n != 0 || err != io.EOF {
+		t.Errorf("got %v, %v, expected 0, %v", n, err, io.EOF)
+	}
+	for _, off := range []int{-1, w.Samples + 1} {
+		if err := w.SeekSample(off); err == nil {
+			t.Errorf("%v: expected error", off)
+		}
+	}
+
+	// Seeking is relative to the data chunk, wherever the reader started.
+	h := Header{
+		AudioFormat:   wavFormatPCM,
+		NumChannels:   1,
+		SampleRate:    8000,
+		ByteRate:      8000 * 3,
+		BlockAlign:    3,
+		BitsPerSample: 24,
+	}
+	r := bytes.NewReader(append([]byte("junk"), makeWav(h, []byte{1, 0, 0, 2, 0, 0, 3, 0, 0})...))
+	r.Seek(4, io.SeekStart)
+	w, err = New(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, err := w.ReadSamplesAt(1, 2); err != nil || !reflect.DeepEqual(o, []int32{2, 3}) {
+		t.Errorf("got %v, %v, expected [2 3], nil", o, err)
+	}
+
+	w, err = New(bytes.NewBuffer(makeWav(h, []byte{1, 0, 0})))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.ReadSamplesAt(0, 1); err != ErrNotSeekable {
+		t.Errorf("got %v, expected %v", err, ErrNotSeekable)
+	}
+}
@@ -0,0 +1,252 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wav
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrClosed is returned by the write methods of a closed Writer.
+var ErrClosed = errors.New("wav: writer closed")
+
+// Writer writes WAV files.
+type Writer struct {
+	Header
+
+	w      io.Writer
+	n      int64 // bytes of sample data written
+	closed bool
+}
+
+// NewWriter writes a WAV header for hdr to w and returns a Writer for the
+// sample data. If hdr.ByteRate or hdr.BlockAlign is 0, it is computed from
+// the other fields.
+//
+// The chunk sizes in the header are not known until Close. If w is an
+// io.WriteSeeker, such as an *os.File, Close seeks back and fills them in.
+// Otherwise they are written as 0xFFFFFFFF, which many readers, including
+// New, treat as data that continues to the end of the file.
+func NewWriter(w io.Writer, hdr Header) (*Writer, error) {
+	if hdr.NumChannels < 1 {
+		return nil, fmt.Errorf("wav: invalid number of channels: %v", hdr.NumChannels)
+	}
+	switch hdr.AudioFormat {
+	case wavFormatPCM:
+		switch hdr.BitsPerSample {
+		case 8, 16, 24, 32:
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, hdr.BitsPerSample)
+		}
+	case wavFormatIEEEFloat:
+		switch hdr.BitsPerSample {
+		case 32, 64:
+		default:
+			return nil, fmt.Errorf("%w: %v", ErrUnknownBitsPerSample, hdr.BitsPerSample)
+		}
+	default:
+		return nil, fmt.Errorf("%w: %02x", ErrUnknownFormat, hdr.AudioFormat)
+	}
+	if hdr.BlockAlign == 0 {
+		hdr.BlockAlign = hdr.NumChannels * hdr.BitsPerSample / 8
+	}
+	if hdr.ByteRate == 0 {
+		hdr.ByteRate = hdr.SampleRate * uint32(hdr.BlockAlign)
+	}
+
+	wr := &Writer{Header: hdr, w: w}
+	if err := wr.writeHeader(math.MaxUint32, math.MaxUint32); err != nil {
+		return nil, err
+	}
+	return wr, nil
+}
+
+// writeHeader writes the RIFF, fmt and data chunk headers with the given
+// RIFF and data chunk sizes.
+func (w *Writer) writeHeader(riff, data uint32) error {
+	var b bytes.Buffer
+	b.WriteString("RIFF")
+	binary.Write(&b, binary.LittleEndian, riff)
+	b.WriteString("WAVEfmt ")
+	binary.Write(&b, binary.LittleEndian, uint32(16))
+	binary.Write(&b, binary.LittleEndian, w.Header)
+	b.WriteString("data")
+	binary.Write(&b, binary.LittleEndian, data)
+	_, err := w.w.Write(b.Bytes())
+	return err
+}
+
+// WriteSamples writes interleaved samples. data must be a []T, where T is
+// uint8, int16, int32, float32, or float64, matching the header as for
+// ReadSamples. For 24-bit PCM, the low three bytes of each int32 are written.
+func (w *Writer) WriteSamples(data interface{}) error {
+	if w.closed {
+		return ErrClosed
+	}
+	var n int
+	switch d := data.(type) {
+	case []uint8:
+		n = len(d)
+		if w.AudioFormat != wavFormatPCM || w.BitsPerSample != 8 {
+			return fmt.Errorf("wav: %T does not match header", d)
+		}
+	case []int16:
+		n = len(d) * 2
+		if w.AudioFormat != wavFormatPCM || w.BitsPerSample != 16 {
+			return fmt.Errorf("wav: %T does not match header", d)
+		}
+	case []int32:
+		if w.AudioFormat != wavFormatPCM || w.BitsPerSample < 24 {
+			return fmt.Errorf("wav: %T does not match header", d)
+		}
+		if w.BitsPerSample == 24 {
+			b := make([]byte, 3*len(d))
+			for i, v := range d {
+				b[3*i] = byte(v)
+				b[3*i+1] = byte(v >> 8)
+				b[3*i+2] = byte(v >> 16)
+			}
+			data = b
+		}
+		n = len(d) * int(w.BitsPerSample/8)
+	case []float32:
+		n = len(d) * 4
+		if w.AudioFormat != wavFormatIEEEFloat || w.BitsPerSample != 32 {
+			return fmt.Errorf("wav: %T does not match header", d)
+		}
+	case []float64:
+		n = len(d) * 8
+		if w.AudioFormat != wavFormatIEEEFloat || w.BitsPerSample != 64 {
+			return fmt.Errorf("wav: %T does not match header", d)
+		}
+	default:
+		return fmt.Errorf("%w: %T", ErrUnknownFormat, d)
+	}
+	if err := binary.Write(w.w, binary.LittleEndian, data); err != nil {
+		return err
+	}
+	w.n += int64(n)
+	return nil
+}
+
+// WriteFloats writes interleaved samples, converting them to the header's
+// format. It is the inverse of ReadFloats: PCM samples are mapped from [0, 1]
+// to the full range of the integer type, clipping values outside it, and
+// float samples are written unchanged.
+func (w *Writer) WriteFloats(f []float32) error {
+	var d interface{}
+	switch w.AudioFormat {
+	case wavFormatPCM:
+		switch w.BitsPerSample {
+		case 8:
+			s := make([]uint8, len(f))
+			for i, v := range f {
+				s[i] = uint8(math.Round(clip(v) * math.MaxUint8))
+			}
+			d = s
+		case 16:
+			s := make([]int16, len(f))
+			for i, v := range f {
+				s[i] = int16(math.Round(clip(v)*(math.MaxInt16-math.MinInt16)) + math.MinInt16)
+			}
+			d = s
+		case 24, 32:
+			h := math.Ldexp(1, int(w.BitsPerSample)-1)
+			s := make([]int32, len(f))
+			for i, v := range f {
+				s[i] = int32(math.Round(clip(v)*(2*h-1)) - h)
+			}
+			d = s
+		}
+	case wavFormatIEEEFloat:
+		switch w.BitsPerSample {
+		case 32:
+			d = f
+		case 64:
+			s := make([]float64, len(f))
+			for i, v := range f {
+				s[i] = float64(v)
+			}
+			d = s
+		}
+	}
+	return w.WriteSamples(d)
+}
+
+// WriteFloat64s implements dsputils.SampleWriter. It writes the interleaved
+// samples in p as WriteFloats.
+func (w *Writer) WriteFloat64s(p []float64) (int, error) {
+	f := make([]float32, len(p))
+	for i, v := range p {
+		f[i] = float32(v)
+	}
+	if err := w.WriteFloats(f); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// clip returns v limited to [0, 1].
+func clip(v float32) float64 {
+	if v < 0 {
+		return 0
+	}
+	if v > 1 {
+		return 1
+	}
+	return float64(v)
+}
+
+// Close pads the data chunk to an even length and, if the underlying writer
+// is an io.WriteSeeker, fills in the chunk sizes in the header and seeks to
+// the end. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.closed {
+		return ErrClosed
+	}
+	w.closed = true
+
+	pad := w.n % 2
+	if pad == 1 {
+		if _, err := w.w.Write([]byte{0}); err != nil {
+			return err
+		}
+	}
+	s, ok := w.w.(io.WriteSeeker)
+	if !ok {
+		return nil
+	}
+	if w.n+pad+36 > math.MaxUint32 {
+		return errors.New("wav: data too large")
+	}
+	end, err := s.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := s.Seek(end-w.n-pad-44, io.SeekStart); err != nil {
+		return err
+	}
+	if err := w.writeHeader(uint32(w.n+pad+36), uint32(w.n)); err != nil {
+		return err
+	}
+	_, err = s.Seek(end, io.SeekStart)
+	return err
+}
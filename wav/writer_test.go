@@ -0,0 +1,228 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wav
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"reflect"
+	"testing"
+)
+
+var writerTests = []struct {
+	hdr Header
+	in  []float32
+}{
+	{
+		Header{AudioFormat: wavFormatPCM, NumChannels: 1, SampleRate: 8000, BitsPerSample: 8},
+		[]float32{0, 1, 51.0 / 255, 128.0 / 255, 1},
+	},
+	{
+		Header{AudioFormat: wavFormatPCM, NumChannels: 2, SampleRate: 44100, BitsPerSample: 16},
+		[]float32{0, 1, 32768.0 / 65535, 1000.0 / 65535},
+	},
+	{
+		Header{AudioFormat: wavFormatIEEEFloat, NumChannels: 2, SampleRate: 48000, BitsPerSample: 32},
+		[]float32{-1, 0.5, 0.25, 1e-3, 2, -0.75},
+	},
+}
+
+func TestWriter(t *testing.T) {
+	for _, wt := range writerTests {
+		f, err := ioutil.TempFile("", "wav")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.Remove(f.Name())
+		defer f.Close()
+
+		w, err := NewWriter(f, wt.hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Write in two parts to check that the sizes accumulate.
+		if err := w.WriteFloats(wt.in[:2]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteFloats(wt.in[2:]); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteFloats(wt.in); err != ErrClosed {
+			t.Errorf("got %v, expected %v", err, ErrClosed)
+		}
+
+		b, err := ioutil.ReadFile(f.Name())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(b)%2 != 0 {
+			t.Errorf("odd file length %v", len(b))
+		}
+		r, err := New(bytes.NewReader(b))
+		if err != nil {
+			t.Fatal(err)
+		}
+		e := wt.hdr
+		e.BlockAlign = e.NumChannels * e.BitsPerSample / 8
+		e.ByteRate = e.SampleRate * uint32(e.BlockAlign)
+		if r.Header != e {
+			t.Errorf("got header %+v, expected %+v", r.Header, e)
+		}
+		if r.Samples != len(wt.in) {
+			t.Errorf("got %v samples, expected %v", r.Samples, len(wt.in))
+		}
+		o, err := r.ReadFloats(len(wt.in))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(o, wt.in) {
+			t.Error("WriteFloats error\ninput:", wt.in, "\noutput:", o)
+		}
+	}
+}
+
+func TestWriterStream(t *testing.T) {
+	// A writer that cannot seek leaves the sizes unknown.
+	var b bytes.Buffer
+	w, err := NewWriter(&b, Header{AudioFormat: wavFormatPCM, NumChannels: 1, SampleRate: 8000, BitsPerSample: 16})
+	if err != nil {
+		t.Fatal(err)
+	}
+	in := []int16{-32768, 0, 32767}
+	if err := w.WriteSamples(in); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.WriteFloat64s([]float64{-1, 2}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data := b.Bytes()
+	r, err := New(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, err := r.ReadSamples(5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e := []int16{-32768, 0, 32767, -32768, 32767}; !reflect.DeepEqual(o, e) {
+		t.Error("WriteSamples error\noutput:", o, "\nexpected:", e)
+	}
+
+	// ReadFloat64s stops at the end of the data.
+	r, err = New(bytes.NewReader(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := make([]float64, 8)
+	if n, err := r.ReadFloat64s(p); n != 5 || err != nil {
+		t.Fatal("ReadFloat64s error:", n, err)
+	}
+	if n, err := r.ReadFloat64s(p); n != 0 || err != io.EOF {
+		t.Error("ReadFloat64s EOF error:", n, err)
+	}
+}
+
+func TestWriterErrors(t *testing.T) {
+	for _, hdr := range []Header{
+		{AudioFormat: wavFormatPCM, NumChannels: 0, BitsPerSample: 16},
+		{AudioFormat: 2, NumChannels: 1, BitsPerSample: 16},
+		{AudioFormat: wavFormatPCM, NumChannels: 1, BitsPerSample: 12},
+		{AudioFormat: wavFormatIEEEFloat, NumChannels: 1, BitsPerSample: 16},
+	} {
+		if _, err := NewWriter(ioutil.Discard, hdr); err == nil {
+			t.Errorf("%+v: expected error", hdr)
+		}
+	}
+	if _, err := NewWriter(ioutil.Discard, Header{AudioFormat: 2, NumChannels: 1}); !errors.Is(err, ErrUnknownFormat) {
+		t.Errorf("got %v, expected %v", err, ErrUnknownFormat)
+	}
+
+	w, err := NewWriter(ioutil.Discard, Header{AudioFormat: wavFormatPCM, NumChannels: 1, BitsPerSample: 8})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteSamples([]int16{1}); err == nil {
+		t.Error("expected error for mismatched sample type")
+	}
+}
+
+func TestWriterFormats(t *testing.T) {
+	tests := []struct {
+		hdr  Header
+		data interface{}
+	}{
+		{
+			Header{AudioFormat: wavFormatPCM, NumChannels: 1, SampleRate: 48000, BitsPerSample: 24},
+			[]int32{-1 << 23, 1<<23 - 1, 1, -1, 0, 0x123456, -0x123456},
+		},
+		{
+			Header{AudioFormat: wavFormatPCM, NumChannels: 2, SampleRate: 48000, BitsPerSample: 32},
+			[]int32{math.MinInt32, math.MaxInt32, 0, -1},
+		},
+		{
+			Header{AudioFormat: wavFormatIEEEFloat, NumChannels: 1, SampleRate: 48000, BitsPerSample: 64},
+			[]float64{-1, 0.5, 1e-300, math.Pi},
+		},
+	}
+	for _, wt := range tests {
+		var b bytes.Buffer
+		w, err := NewWriter(&b, wt.hdr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteSamples(wt.data); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteFloats([]float32{0, 1, 0.5}); err != nil {
+			t.Fatal(err)
+		}
+		w.Close()
+
+		r, err := New(&b)
+		if err != nil {
+			t.Fatal(err)
+		}
+		n := reflect.ValueOf(wt.data).Len()
+		o, err := r.ReadSamples(n)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(o, wt.data) {
+			t.Error("WriteSamples error\nbits:", wt.hdr.BitsPerSample, "\noutput:", o, "\nexpected:", wt.data)
+		}
+		f, err := r.ReadFloats(3)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i, e := range []float32{0, 1, 0.5} {
+			if math.Abs(float64(f[i]-e)) > 1e-7 {
+				t.Error("WriteFloats error\nbits:", wt.hdr.BitsPerSample, "\noutput:", f, "\nexpected:", e)
+			}
+		}
+	}
+}
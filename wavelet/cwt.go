@@ -0,0 +1,137 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"math/cmplx"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Ricker returns points samples of the Ricker (Mexican hat) wavelet of
+// width a, the negative normalized second derivative of a Gaussian, centered
+// in the output:
+//
+//	2/(sqrt(3a)*pi^(1/4)) * (1 - t^2/a^2) * exp(-t^2/(2a^2))
+//
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.ricker.html
+func Ricker(points int, a float64) []float64 {
+	A := 2 / (math.Sqrt(3*a) * math.Pow(math.Pi, 0.25))
+	r := make([]float64, points)
+	for i := range r {
+		t := float64(i) - float64(points-1)/2
+		q := t * t / (a * a)
+		r[i] = A * (1 - q) * math.Exp(-q/2)
+	}
+	return r
+}
+
+// Morlet returns points samples of the complex Morlet wavelet of scale s
+// and angular frequency w (5 or 6 are typical), centered in the output:
+//
+//	pi^(-1/4) / sqrt(s) * exp(i*w*t/s) * exp(-(t/s)^2/2)
+//
+// The wavelet's center frequency is w*fs/(2*pi*s) for sampling frequency fs.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.morlet2.html
+func Morlet(points int, s, w float64) []complex128 {
+	A := math.Pow(math.Pi, -0.25) / math.Sqrt(s)
+	r := make([]complex128, points)
+	for i := range r {
+		x := (float64(i) - float64(points-1)/2) / s
+		r[i] = complex(A*math.Exp(-x*x/2), 0) * cmplx.Exp(complex(0, w*x))
+	}
+	return r
+}
+
+// RickerWavelet is Ricker as a complex wavelet function for CWT.
+func RickerWavelet(points int, a float64) []complex128 {
+	return dsputils.ToComplex(Ricker(points, a))
+}
+
+// MorletWavelet returns Morlet with angular frequency w as a wavelet function
+// for CWT.
+func MorletWavelet(w float64) func(points int, s float64) []complex128 {
+	return func(points int, s float64) []complex128 {
+		return Morlet(points, s, w)
+	}
+}
+
+// MorletScale returns the Morlet wavelet scale whose center frequency is f,
+// for angular frequency w and sampling frequency fs.
+func MorletScale(f, w, fs float64) float64 {
+	return w * fs / (2 * math.Pi * f)
+}
+
+// CWT returns the continuous wavelet transform of x: one row per width, each
+// the correlation of x with the wavelet of that width, of the same length as
+// x. The wavelet function returns the given number of points of the wavelet
+// at a width; each width uses min(10*width, len(x)) points. The squared
+// magnitudes of the result form a scaleogram. The correlations are computed
+// with FFTs.
+// Reference: http://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.cwt.html
+func CWT(x []float64, widths []float64, wavelet func(points int, width float64) []complex128) [][]complex128 {
+	r := make([][]complex128, len(widths))
+	if len(x) == 0 {
+		for i := range r {
+			r[i] = []complex128{}
+		}
+		return r
+	}
+
+	// Size the FFT for the longest wavelet.
+	maxM := 1
+	for _, width := range widths {
+		if m := cwtPoints(width, len(x)); m > maxM {
+			maxM = m
+		}
+	}
+	n := dsputils.NextPowerOf2(len(x) + maxM - 1)
+	X := fft.FFT(dsputils.ZeroPad(dsputils.ToComplex(x), n))
+
+	for i, width := range widths {
+		m := cwtPoints(width, len(x))
+		w := wavelet(m, width)
+
+		// Correlation is convolution with the reversed conjugate.
+		k := make([]complex128, n)
+		for j, v := range w {
+			k[m-1-j] = cmplx.Conj(v)
+		}
+		K := fft.FFT(k)
+		for j := range K {
+			K[j] *= X[j]
+		}
+		full := fft.IFFT(K)
+
+		start := (m - 1) / 2
+		r[i] = append([]complex128(nil), full[start:start+len(x)]...)
+	}
+	return r
+}
+
+func cwtPoints(width float64, n int) int {
+	m := int(10 * width)
+	if m > n {
+		m = n
+	}
+	if m < 1 {
+		m = 1
+	}
+	return m
+}
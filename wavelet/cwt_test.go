@@ -0,0 +1,105 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestRicker(t *testing.T) {
+	r := Ricker(5, 1)
+	A := 2 / (math.Sqrt(3) * math.Pow(math.Pi, 0.25))
+	e := []float64{-3 * A * math.Exp(-2), 0, A, 0, -3 * A * math.Exp(-2)}
+	if !dsputils.PrettyClose(r, e) {
+		t.Error("Ricker error\noutput:", r, "\nexpected:", e)
+	}
+
+	// Unit energy.
+	var sum float64
+	for _, v := range Ricker(1001, 20) {
+		sum += v * v
+	}
+	if math.Abs(sum-1) > 1e-3 {
+		t.Error("Ricker energy error:", sum)
+	}
+}
+
+func TestMorlet(t *testing.T) {
+	var sum float64
+	for _, v := range Morlet(1001, 20, 6) {
+		sum += real(v)*real(v) + imag(v)*imag(v)
+	}
+	if math.Abs(sum-1) > 1e-3 {
+		t.Error("Morlet energy error:", sum)
+	}
+}
+
+func TestCWT(t *testing.T) {
+	x := make([]float64, 100)
+	for i := range x {
+		x[i] = rand.NormFloat64()
+	}
+	widths := []float64{1, 2.5, 4, 20}
+	r := CWT(x, widths, MorletWavelet(5))
+
+	// Compare with direct correlation.
+	for i, width := range widths {
+		m := cwtPoints(width, len(x))
+		w := Morlet(m, width, 5)
+		for j := range x {
+			var e complex128
+			for k, v := range w {
+				if idx := j + k - m + 1 + (m-1)/2; idx >= 0 && idx < len(x) {
+					e += complex(x[idx], 0) * cmplx.Conj(v)
+				}
+			}
+			if cmplx.Abs(r[i][j]-e) > 1e-9 {
+				t.Fatal("CWT error at", width, j, ":", r[i][j], e)
+			}
+		}
+	}
+}
+
+func TestCWTScale(t *testing.T) {
+	// The Morlet scaleogram of a tone peaks at the matching scale.
+	const fs, f, w = 1000.0, 50.0, 6.0
+	x := make([]float64, 2000)
+	for i := range x {
+		x[i] = math.Sin(2 * math.Pi * f * float64(i) / fs)
+	}
+	var widths []float64
+	for s := 5.0; s <= 40; s += 0.5 {
+		widths = append(widths, s)
+	}
+	r := CWT(x, widths, MorletWavelet(w))
+
+	best, bestP := 0, 0.0
+	for i := range r {
+		v := cmplx.Abs(r[i][len(x)/2])
+		if v > bestP {
+			best, bestP = i, v
+		}
+	}
+	if s := MorletScale(f, w, fs); math.Abs(widths[best]-s) > 1 {
+		t.Error("CWT scale error\noutput:", widths[best], "\nexpected:", s)
+	}
+}
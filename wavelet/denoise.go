@@ -0,0 +1,157 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"sort"
+)
+
+// ThresholdMode selects how coefficients are shrunk by Threshold.
+type ThresholdMode int
+
+const (
+	// Soft sets coefficients below the threshold to zero and shrinks the
+	// others towards zero by the threshold.
+	Soft ThresholdMode = iota
+
+	// Hard sets coefficients below the threshold to zero and keeps the
+	// others unchanged.
+	Hard
+)
+
+// ThresholdRule selects how Denoise chooses thresholds.
+type ThresholdRule int
+
+const (
+	// VisuShrink uses the universal threshold for every level.
+	VisuShrink ThresholdRule = iota
+
+	// SureShrink uses the threshold minimizing Stein's unbiased risk
+	// estimate separately for each level.
+	SureShrink
+)
+
+// Threshold returns x with threshold t applied to each value.
+func Threshold(x []float64, t float64, mode ThresholdMode) []float64 {
+	r := make([]float64, len(x))
+	for i, v := range x {
+		if math.Abs(v) <= t {
+			continue
+		}
+		switch mode {
+		case Soft:
+			if v > 0 {
+				r[i] = v - t
+			} else {
+				r[i] = v + t
+			}
+		case Hard:
+			r[i] = v
+		default:
+			panic("unknown threshold mode")
+		}
+	}
+	return r
+}
+
+// NoiseSigma estimates the standard deviation of white Gaussian noise from
+// the finest level detail coefficients d, as their median absolute value
+// divided by 0.6745.
+func NoiseSigma(d []float64) float64 {
+	if len(d) == 0 {
+		return 0
+	}
+	a := make([]float64, len(d))
+	for i, v := range d {
+		a[i] = math.Abs(v)
+	}
+	sort.Float64s(a)
+	m := a[len(a)/2]
+	if len(a)%2 == 0 {
+		m = (a[len(a)/2-1] + m) / 2
+	}
+	return m / 0.6745
+}
+
+// UniversalThreshold returns the VisuShrink threshold sigma*sqrt(2*ln(n))
+// for n samples with noise standard deviation sigma.
+// Reference: http://statweb.stanford.edu/~imj/WEBLIST/1994/isaws.pdf
+func UniversalThreshold(sigma float64, n int) float64 {
+	if n < 2 {
+		return 0
+	}
+	return sigma * math.Sqrt(2*math.Log(float64(n)))
+}
+
+// SureThreshold returns the soft threshold for the coefficients d that
+// minimizes Stein's unbiased risk estimate, given noise standard deviation
+// sigma. The result is capped at the universal threshold.
+// Reference: http://statweb.stanford.edu/~imj/WEBLIST/1995/ausws.pdf
+func SureThreshold(d []float64, sigma float64) float64 {
+	n := len(d)
+	if n == 0 || sigma == 0 {
+		return 0
+	}
+	a := make([]float64, n)
+	for i, v := range d {
+		a[i] = math.Abs(v) / sigma
+	}
+	sort.Float64s(a)
+
+	// With t = a[k]: risk = n - 2*(k+1) + sum(a[:k+1]^2) + (n-k-1)*t^2.
+	best, bestRisk := 0.0, float64(n)
+	var sum float64
+	for k, t := range a {
+		sum += t * t
+		risk := float64(n-2*(k+1)) + sum + float64(n-k-1)*t*t
+		if risk < bestRisk {
+			best, bestRisk = t, risk
+		}
+	}
+	if u := math.Sqrt(2 * math.Log(float64(n))); best > u {
+		best = u
+	}
+	return best * sigma
+}
+
+// Denoise returns x with additive white Gaussian noise removed by wavelet
+// shrinkage: the detail coefficients of a level-level decomposition (0 uses
+// MaxLevel) are thresholded and the signal reconstructed. The noise level is
+// estimated from the finest detail coefficients. The result has the same
+// length as x.
+// Reference: http://statweb.stanford.edu/~imj/WEBLIST/1994/isaws.pdf
+func Denoise(x []float64, w Wavelet, mode Mode, level int, rule ThresholdRule, tmode ThresholdMode) []float64 {
+	if len(x) == 0 {
+		return []float64{}
+	}
+	coeffs := Wavedec(x, w, mode, level)
+	sigma := NoiseSigma(coeffs[len(coeffs)-1])
+	for i := 1; i < len(coeffs); i++ {
+		var t float64
+		switch rule {
+		case VisuShrink:
+			t = UniversalThreshold(sigma, len(x))
+		case SureShrink:
+			t = SureThreshold(coeffs[i], sigma)
+		default:
+			panic("unknown threshold rule")
+		}
+		coeffs[i] = Threshold(coeffs[i], t, tmode)
+	}
+	return Waverec(coeffs, w, mode)[:len(x)]
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestThreshold(t *testing.T) {
+	x := []float64{-3, -1, 0, 0.5, 2, 4}
+	tests := []struct {
+		mode ThresholdMode
+		out  []float64
+	}{
+		{Soft, []float64{-2, 0, 0, 0, 1, 3}},
+		{Hard, []float64{-3, 0, 0, 0, 2, 4}},
+	}
+	for _, v := range tests {
+		o := Threshold(x, 1, v.mode)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error("Threshold error\ninput:", x, v.mode, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestNoiseSigma(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	d := make([]float64, 10000)
+	for i := range d {
+		d[i] = 2 * r.NormFloat64()
+	}
+	if s := NoiseSigma(d); math.Abs(s-2) > 0.1 {
+		t.Error("NoiseSigma error\noutput:", s, "\nexpected: 2")
+	}
+}
+
+func TestSureThreshold(t *testing.T) {
+	// Pure noise: the risk is minimized near the universal threshold.
+	r := rand.New(rand.NewSource(2))
+	d := make([]float64, 1000)
+	for i := range d {
+		d[i] = r.NormFloat64()
+	}
+	if th := SureThreshold(d, 1); th < 1.5 || th > UniversalThreshold(1, len(d)) {
+		t.Error("SureThreshold noise error:", th)
+	}
+
+	// Large coefficients only: no shrinkage is needed.
+	for i := range d {
+		d[i] = 100 + float64(i)
+	}
+	if th := SureThreshold(d, 1); th != 0 {
+		t.Error("SureThreshold signal error:", th)
+	}
+}
+
+func TestDenoise(t *testing.T) {
+	const n = 1024
+	r := rand.New(rand.NewSource(3))
+	clean := make([]float64, n)
+	noisy := make([]float64, n)
+	for i := range clean {
+		// A piecewise smooth signal with a jump.
+		clean[i] = math.Sin(2 * math.Pi * 3 * float64(i) / n)
+		if i > n/2 {
+			clean[i] += 1
+		}
+		noisy[i] = clean[i] + 0.2*r.NormFloat64()
+	}
+
+	mse := func(x []float64) float64 {
+		var s float64
+		for i := range x {
+			s += (x[i] - clean[i]) * (x[i] - clean[i])
+		}
+		return s / float64(len(x))
+	}
+
+	before := mse(noisy)
+	for _, rule := range []ThresholdRule{VisuShrink, SureShrink} {
+		for _, mode := range []Mode{Symmetric, Periodization} {
+			o := Denoise(noisy, Daubechies(4), mode, 5, rule, Soft)
+			if len(o) != n {
+				t.Fatal("Denoise length error:", len(o))
+			}
+			if after := mse(o); after > before/4 {
+				t.Error("Denoise error", rule, mode, "\nmse:", after, "\nnoisy mse:", before)
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+// Mode is the signal extension used at the boundaries of a discrete wavelet
+// transform.
+type Mode int
+
+const (
+	// Symmetric extends the signal by half-sample symmetric reflection:
+	// ... x1 x0 | x0 x1 ... xn-1 | xn-1 xn-2 ...
+	Symmetric Mode = iota
+
+	// Zero extends the signal with zeros.
+	Zero
+
+	// Periodic extends the signal periodically.
+	Periodic
+
+	// Constant extends the signal by repeating its edge values.
+	Constant
+
+	// Reflect extends the signal by whole-sample symmetric reflection:
+	// ... x2 x1 | x0 x1 ... xn-1 | xn-2 xn-3 ...
+	Reflect
+
+	// Periodization treats the signal as periodic and gives the minimal
+	// number of coefficients, ceil(n/2) of each kind, rather than the
+	// (n+len(filter)-1)/2 of the other modes. Odd length signals are first
+	// extended by repeating the last sample.
+	Periodization
+)
+
+// extend returns the value of x at index i, which may be outside x, using
+// the extension mode.
+func extend(x []float64, i int, mode Mode) float64 {
+	n := len(x)
+	if i >= 0 && i < n {
+		return x[i]
+	}
+	switch mode {
+	case Zero:
+		return 0
+	case Constant:
+		if i < 0 {
+			return x[0]
+		}
+		return x[n-1]
+	case Periodic, Periodization:
+		i %= n
+		if i < 0 {
+			i += n
+		}
+		return x[i]
+	case Symmetric:
+		p := 2 * n
+		i %= p
+		if i < 0 {
+			i += p
+		}
+		if i >= n {
+			i = p - 1 - i
+		}
+		return x[i]
+	case Reflect:
+		if n == 1 {
+			return x[0]
+		}
+		p := 2*n - 2
+		i %= p
+		if i < 0 {
+			i += p
+		}
+		if i >= n {
+			i = p - i
+		}
+		return x[i]
+	}
+	panic("unknown mode")
+}
+
+// offset returns the index offset of the first filter tap for coefficient
+// zero.
+func offset(w Wavelet, mode Mode) int {
+	if mode == Periodization {
+		return len(w.Lo)/2 - 1
+	}
+	return len(w.Lo) - 2
+}
+
+// CoeffLen returns the number of approximation (and of detail) coefficients
+// of a single level DWT of n samples.
+func CoeffLen(n int, w Wavelet, mode Mode) int {
+	if mode == Periodization {
+		return (n + 1) / 2
+	}
+	return (n + len(w.Lo) - 1) / 2
+}
+
+// DWT returns the single level discrete wavelet transform of x: the
+// approximation coefficients a and detail coefficients d.
+// Reference: https://pywavelets.readthedocs.io/en/latest/ref/dwt-discrete-wavelet-transform.html
+func DWT(x []float64, w Wavelet, mode Mode) (a, d []float64) {
+	if len(x) == 0 {
+		panic("x must be non-empty")
+	}
+	if mode == Periodization && len(x)%2 == 1 {
+		x = append(append([]float64{}, x...), x[len(x)-1])
+	}
+
+	n := CoeffLen(len(x), w, mode)
+	o := offset(w, mode)
+	a = make([]float64, n)
+	d = make([]float64, n)
+	for k := range a {
+		start := 2*k - o
+		for m := range w.Lo {
+			v := extend(x, start+m, mode)
+			a[k] += w.Lo[m] * v
+			d[k] += w.Hi[m] * v
+		}
+	}
+	return a, d
+}
+
+// IDWT returns the signal reconstructed from the approximation coefficients
+// a and detail coefficients d of DWT. Either may be nil to reconstruct from
+// the other alone. The result has 2*len(a) samples for Periodization, and
+// 2*len(a)-len(w.Lo)+2 otherwise, which is one more than the original length
+// if it was odd.
+func IDWT(a, d []float64, w Wavelet, mode Mode) []float64 {
+	n := len(a)
+	if a == nil {
+		n = len(d)
+	} else if d != nil && len(d) != n {
+		panic("a and d must have the same length")
+	}
+
+	F := len(w.Lo)
+	o := offset(w, mode)
+	var size int
+	if mode == Periodization {
+		size = 2 * n
+	} else {
+		size = 2*n - F + 2
+	}
+	if size <= 0 {
+		panic("too few coefficients")
+	}
+
+	x := make([]float64, size)
+	for k := 0; k < n; k++ {
+		start := 2*k - o
+		for m := 0; m < F; m++ {
+			i := start + m
+			if mode == Periodization {
+				i %= size
+				if i < 0 {
+					i += size
+				}
+			} else if i < 0 || i >= size {
+				continue
+			}
+			if a != nil {
+				x[i] += w.Lo[m] * a[k]
+			}
+			if d != nil {
+				x[i] += w.Hi[m] * d[k]
+			}
+		}
+	}
+	return x
+}
+
+// MaxLevel returns the maximum useful decomposition level for a signal of
+// length n: the level at which the approximation becomes shorter than the
+// filter.
+func MaxLevel(n int, w Wavelet) int {
+	level := 0
+	for (len(w.Lo)-1)<<uint(level+1) <= n {
+		level++
+	}
+	return level
+}
+
+// Wavedec returns the multilevel discrete wavelet transform of x to the
+// given level: {aN, dN, ..., d2, d1}, the approximation coefficients of the
+// last level followed by the detail coefficients from the last level to the
+// first. A level of 0 uses MaxLevel.
+// Reference: https://pywavelets.readthedocs.io/en/latest/ref/dwt-discrete-wavelet-transform.html#multilevel-decomposition-using-wavedec
+func Wavedec(x []float64, w Wavelet, mode Mode, level int) [][]float64 {
+	if level == 0 {
+		level = MaxLevel(len(x), w)
+		if level == 0 {
+			level = 1
+		}
+	}
+	if level < 0 {
+		panic("level must be non-negative")
+	}
+
+	details := make([][]float64, 0, level)
+	a := x
+	for i := 0; i < level; i++ {
+		var d []float64
+		a, d = DWT(a, w, mode)
+		details = append(details, d)
+	}
+
+	r := [][]float64{a}
+	for i := len(details) - 1; i >= 0; i-- {
+		r = append(r, details[i])
+	}
+	return r
+}
+
+// Waverec returns the signal reconstructed from the coefficients returned
+// by Wavedec. Any detail slice may be nil to omit it. The result may be one
+// sample longer than the original signal if its length was odd.
+func Waverec(coeffs [][]float64, w Wavelet, mode Mode) []float64 {
+	if len(coeffs) < 2 {
+		panic("coeffs must have at least one level")
+	}
+	a := coeffs[0]
+	for _, d := range coeffs[1:] {
+		// An odd length at the previous level gives one extra sample.
+		n := len(d)
+		if d == nil {
+			n = len(a)
+		}
+		if len(a) == n+1 {
+			a = a[:n]
+		}
+		a = IDWT(a, d, w, mode)
+	}
+	return a
+}
@@ -0,0 +1,136 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestDWT(t *testing.T) {
+	x := []float64{1, 2, 3, 4}
+	for _, mode := range []Mode{Symmetric, Periodization} {
+		a, d := DWT(x, Haar(), mode)
+		ea := []float64{3 / math.Sqrt2, 7 / math.Sqrt2}
+		ed := []float64{-1 / math.Sqrt2, -1 / math.Sqrt2}
+		if !dsputils.PrettyClose(a, ea) || !dsputils.PrettyClose(d, ed) {
+			t.Error("DWT error\nmode:", mode, "\noutput:", a, d, "\nexpected:", ea, ed)
+		}
+	}
+
+	// The coefficients of a constant signal with symmetric extension are
+	// constant, and the details zero.
+	a, d := DWT([]float64{2, 2, 2, 2, 2}, Daubechies(3), Symmetric)
+	for i := range a {
+		if math.Abs(a[i]-2*math.Sqrt2) > 1e-9 || math.Abs(d[i]) > 1e-9 {
+			t.Error("DWT constant error\noutput:", a, d)
+			break
+		}
+	}
+}
+
+func TestExtend(t *testing.T) {
+	x := []float64{1, 2, 3}
+	for _, v := range []struct {
+		mode Mode
+		e    []float64 // indexes -4 through 6
+	}{
+		{Symmetric, []float64{3, 3, 2, 1, 1, 2, 3, 3, 2, 1, 1}},
+		{Zero, []float64{0, 0, 0, 0, 1, 2, 3, 0, 0, 0, 0}},
+		{Periodic, []float64{3, 1, 2, 3, 1, 2, 3, 1, 2, 3, 1}},
+		{Constant, []float64{1, 1, 1, 1, 1, 2, 3, 3, 3, 3, 3}},
+		{Reflect, []float64{1, 2, 3, 2, 1, 2, 3, 2, 1, 2, 3}},
+	} {
+		o := make([]float64, len(v.e))
+		for i := range o {
+			o[i] = extend(x, i-4, v.mode)
+		}
+		if !dsputils.PrettyClose(o, v.e) {
+			t.Error("extend error\nmode:", v.mode, "\noutput:", o, "\nexpected:", v.e)
+		}
+	}
+}
+
+func TestReconstruction(t *testing.T) {
+	ws := []Wavelet{Haar(), Daubechies(2), Daubechies(5), Symlet(4)}
+	modes := []Mode{Symmetric, Zero, Periodic, Constant, Reflect, Periodization}
+	for _, n := range []int{1, 2, 7, 16, 33} {
+		x := make([]float64, n)
+		for i := range x {
+			x[i] = rand.NormFloat64()
+		}
+		for _, w := range ws {
+			for _, mode := range modes {
+				a, d := DWT(x, w, mode)
+				if len(a) != CoeffLen(n, w, mode) {
+					t.Error("DWT length error:", w.Name, mode, n, len(a))
+				}
+				y := IDWT(a, d, w, mode)
+				if len(y) < n || len(y) > n+1 || !dsputils.PrettyClose(y[:n], x) {
+					t.Error("IDWT error\nwavelet:", w.Name, "\nmode:", mode, "\noutput:", y, "\nexpected:", x)
+				}
+
+				c := Wavedec(x, w, mode, 3)
+				if len(c) != 4 {
+					t.Fatal("Wavedec length error:", len(c))
+				}
+				y = Waverec(c, w, mode)
+				if len(y) < n || len(y) > n+1 || !dsputils.PrettyClose(y[:n], x) {
+					t.Error("Waverec error\nwavelet:", w.Name, "\nmode:", mode, "\noutput:", y, "\nexpected:", x)
+				}
+			}
+		}
+	}
+}
+
+func TestPeriodizationEnergy(t *testing.T) {
+	// Periodization is orthogonal, so it preserves energy.
+	x := make([]float64, 64)
+	var e float64
+	for i := range x {
+		x[i] = rand.NormFloat64()
+		e += x[i] * x[i]
+	}
+	var o float64
+	for _, c := range Wavedec(x, Symlet(4), Periodization, 0) {
+		for _, v := range c {
+			o += v * v
+		}
+	}
+	if math.Abs(o-e) > 1e-9 {
+		t.Error("Periodization energy error\noutput:", o, "\nexpected:", e)
+	}
+}
+
+func TestMaxLevel(t *testing.T) {
+	for _, v := range []struct {
+		n     int
+		w     Wavelet
+		level int
+	}{
+		{1000, Haar(), 9},
+		{1000, Daubechies(4), 7},
+		{5, Daubechies(4), 0},
+	} {
+		if o := MaxLevel(v.n, v.w); o != v.level {
+			t.Error("MaxLevel error\ninput:", v.n, v.w.Name, "\noutput:", o, "\nexpected:", v.level)
+		}
+	}
+}
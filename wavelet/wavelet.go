@@ -0,0 +1,223 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+// Package wavelet provides discrete and continuous wavelet transforms.
+package wavelet
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"strconv"
+	"strings"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+// Wavelet is an orthogonal wavelet, defined by its scaling (lowpass) filter
+// Lo and wavelet (highpass) filter Hi. Hi is the quadrature mirror of Lo:
+// Hi[n] = (-1)^n * Lo[len(Lo)-1-n].
+type Wavelet struct {
+	Name   string
+	Lo, Hi []float64
+}
+
+func newWavelet(name string, lo []float64) Wavelet {
+	hi := make([]float64, len(lo))
+	for n := range hi {
+		hi[n] = lo[len(lo)-1-n]
+		if n%2 == 1 {
+			hi[n] = -hi[n]
+		}
+	}
+	return Wavelet{Name: name, Lo: lo, Hi: hi}
+}
+
+// Haar returns the Haar wavelet, which is also Daubechies(1).
+func Haar() Wavelet {
+	return newWavelet("haar", []float64{math.Sqrt2 / 2, math.Sqrt2 / 2})
+}
+
+// Daubechies returns the Daubechies wavelet with n vanishing moments (dbN),
+// whose filters have 2n taps. It is the minimum phase orthogonal wavelet
+// with maximal vanishing moments for its length. The filters are computed
+// by spectral factorization; n up to about 20 gives accurate results.
+// Reference: I. Daubechies, "Ten Lectures on Wavelets," SIAM, 1992, section 6.1.
+func Daubechies(n int) Wavelet {
+	if n == 1 {
+		w := Haar()
+		w.Name = "db1"
+		return w
+	}
+	groups := daubechiesRoots(n)
+	z := make([]complex128, 0, n-1)
+	for _, g := range groups {
+		for _, r := range g {
+			z = append(z, r)
+		}
+	}
+	return newWavelet("db"+strconv.Itoa(n), daubechiesFilter(n, z))
+}
+
+// Symlet returns the symlet with n vanishing moments (symN), whose filters
+// have 2n taps. Symlets have the same magnitude response as Daubechies
+// wavelets, but use the factorization whose phase is closest to linear, so
+// they are nearly symmetric.
+func Symlet(n int) Wavelet {
+	if n < 2 {
+		panic("n must be at least 2")
+	}
+	groups := daubechiesRoots(n)
+
+	var best []float64
+	bestErr := math.Inf(1)
+	for choice := 0; choice < 1<<uint(len(groups)); choice++ {
+		z := make([]complex128, 0, n-1)
+		for i, g := range groups {
+			for _, r := range g {
+				if choice&(1<<uint(i)) != 0 {
+					r = 1 / r
+				}
+				z = append(z, r)
+			}
+		}
+		h := daubechiesFilter(n, z)
+		if e := phaseNonlinearity(h); e < bestErr-1e-9 {
+			best, bestErr = h, e
+		}
+	}
+	return newWavelet("sym"+strconv.Itoa(n), best)
+}
+
+// ByName returns the wavelet with the given name: "haar", "dbN" or "symN".
+func ByName(name string) (Wavelet, error) {
+	name = strings.ToLower(name)
+	switch {
+	case name == "haar":
+		return Haar(), nil
+	case strings.HasPrefix(name, "db"):
+		if n, err := strconv.Atoi(name[2:]); err == nil && n >= 1 && n <= 20 {
+			return Daubechies(n), nil
+		}
+	case strings.HasPrefix(name, "sym"):
+		if n, err := strconv.Atoi(name[3:]); err == nil && n >= 2 && n <= 20 {
+			return Symlet(n), nil
+		}
+	}
+	return Wavelet{}, fmt.Errorf("wavelet: unknown wavelet %q", name)
+}
+
+// daubechiesRoots returns the zeros, other than those at z = -1, of the
+// order n minimum phase Daubechies filter, in groups that must be reflected
+// about the unit circle together to keep the filter real: single real roots
+// and complex conjugate pairs.
+func daubechiesRoots(n int) [][]complex128 {
+	if n < 1 {
+		panic("n must be positive")
+	}
+
+	// |Q(w)|^2 = P(y) with y = sin^2(w/2), where
+	// P(y) = sum over k < n of binomial(n-1+k, k) * y^k.
+	p := make([]float64, n)
+	c := 1.0
+	for k := 0; k < n; k++ {
+		p[n-1-k] = c
+		c = c * float64(n+k) / float64(k+1)
+	}
+
+	var groups [][]complex128
+	for _, y := range dsputils.Roots(p) {
+		if imag(y) < -1e-12*cmplx.Abs(y) {
+			continue
+		}
+		// y = (2 - z - 1/z)/4, so z^2 - (2-4y)z + 1 = 0; take the root
+		// inside the unit circle.
+		b := 2 - 4*y
+		d := cmplx.Sqrt(b*b - 4)
+		z := (b + d) / 2
+		if cmplx.Abs(z) > 1 {
+			z = (b - d) / 2
+		}
+		if math.Abs(imag(y)) <= 1e-12*cmplx.Abs(y) {
+			groups = append(groups, []complex128{complex(real(z), 0)})
+		} else {
+			groups = append(groups, []complex128{z, cmplx.Conj(z)})
+		}
+	}
+	return groups
+}
+
+// daubechiesFilter returns the real filter with n zeros at z = -1 and the
+// zeros z, normalized so its coefficients sum to sqrt(2).
+func daubechiesFilter(n int, z []complex128) []float64 {
+	c := []complex128{1}
+	mul := func(r complex128) {
+		c = append(c, 0)
+		for i := len(c) - 1; i > 0; i-- {
+			c[i] -= r * c[i-1]
+		}
+	}
+	for i := 0; i < n; i++ {
+		mul(-1)
+	}
+	for _, r := range z {
+		mul(r)
+	}
+
+	h := make([]float64, len(c))
+	var sum float64
+	for i, v := range c {
+		h[i] = real(v)
+		sum += h[i]
+	}
+	for i := range h {
+		h[i] *= math.Sqrt2 / sum
+	}
+	return h
+}
+
+// phaseNonlinearity returns the squared error of a least squares linear fit
+// to the unwrapped phase response of h over the passband.
+func phaseNonlinearity(h []float64) float64 {
+	const npts = 256
+	w := make([]float64, npts)
+	phase := make([]float64, npts)
+	for i := range w {
+		w[i] = math.Pi * (float64(i) + 0.5) / npts / 2
+		var r complex128
+		for n, v := range h {
+			r += complex(v, 0) * cmplx.Exp(complex(0, -w[i]*float64(n)))
+		}
+		phase[i] = cmplx.Phase(r)
+	}
+	phase = dsputils.Unwrap(phase)
+
+	var sw, sp, sww, swp float64
+	for i := range w {
+		sw += w[i]
+		sp += phase[i]
+		sww += w[i] * w[i]
+		swp += w[i] * phase[i]
+	}
+	slope := (npts*swp - sw*sp) / (npts*sww - sw*sw)
+	icept := (sp - slope*sw) / npts
+	var e float64
+	for i := range w {
+		d := phase[i] - (icept + slope*w[i])
+		e += d * d
+	}
+	return e
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package wavelet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestDaubechies(t *testing.T) {
+	s3 := math.Sqrt(3)
+	db2 := []float64{(1 + s3) / (4 * math.Sqrt2), (3 + s3) / (4 * math.Sqrt2), (3 - s3) / (4 * math.Sqrt2), (1 - s3) / (4 * math.Sqrt2)}
+	if w := Daubechies(2); !dsputils.PrettyClose(w.Lo, db2) {
+		t.Error("Daubechies error\noutput:", w.Lo, "\nexpected:", db2)
+	}
+	db3 := []float64{0.3326705529500825, 0.8068915093110924, 0.4598775021184914, -0.1350110200102546, -0.0854412738820267, 0.0352262918857095}
+	if w := Daubechies(3); !dsputils.PrettyClose(w.Lo, db3) {
+		t.Error("Daubechies error\noutput:", w.Lo, "\nexpected:", db3)
+	}
+	if w := Daubechies(1); !dsputils.PrettyClose(w.Lo, Haar().Lo) {
+		t.Error("Daubechies(1) error\noutput:", w.Lo)
+	}
+}
+
+func TestOrthogonal(t *testing.T) {
+	var ws []Wavelet
+	for n := 1; n <= 12; n++ {
+		ws = append(ws, Daubechies(n))
+	}
+	for n := 2; n <= 8; n++ {
+		ws = append(ws, Symlet(n))
+	}
+
+	for _, w := range ws {
+		h := w.Lo
+		var sum float64
+		for _, v := range h {
+			sum += v
+		}
+		if math.Abs(sum-math.Sqrt2) > 1e-9 {
+			t.Error(w.Name, "sum error:", sum)
+		}
+
+		// Orthonormal to its even shifts.
+		for s := 0; s < len(h); s += 2 {
+			var r float64
+			for i := 0; i+s < len(h); i++ {
+				r += h[i] * h[i+s]
+			}
+			e := 0.0
+			if s == 0 {
+				e = 1
+			}
+			if math.Abs(r-e) > 1e-9 {
+				t.Error(w.Name, "orthogonality error at shift", s, ":", r)
+			}
+		}
+
+		// The wavelet has len(h)/2 vanishing moments.
+		for p := 0; p < len(h)/2; p++ {
+			var m float64
+			for i, v := range w.Hi {
+				m += v * math.Pow(float64(i), float64(p))
+			}
+			if math.Abs(m) > 1e-6*math.Pow(float64(len(h)), float64(p)) {
+				t.Error(w.Name, "moment error at", p, ":", m)
+			}
+		}
+	}
+}
+
+func TestSymlet(t *testing.T) {
+	// Symlets are closer to linear phase than Daubechies wavelets.
+	for n := 4; n <= 8; n++ {
+		s, d := phaseNonlinearity(Symlet(n).Lo), phaseNonlinearity(Daubechies(n).Lo)
+		if s >= d {
+			t.Error("Symlet phase error:", n, s, d)
+		}
+	}
+}
+
+func TestByName(t *testing.T) {
+	for _, name := range []string{"haar", "db4", "DB10", "sym5"} {
+		if _, err := ByName(name); err != nil {
+			t.Error(err)
+		}
+	}
+	for _, name := range []string{"db0", "sym1", "coif2", "db"} {
+		if _, err := ByName(name); err == nil {
+			t.Error("expected error for", name)
+		}
+	}
+}
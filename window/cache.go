@@ -0,0 +1,75 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"sync"
+)
+
+// maxCached is the number of lengths a Cached window function keeps.
+const maxCached = 16
+
+// Cached returns a window function that computes wf at most once for each of
+// the 16 most recently used lengths. Later calls with one of those lengths
+// return a copy of the stored values, avoiding repeated trigonometric work in
+// hot loops; other lengths are recomputed, evicting the least recently used.
+func Cached(wf func(int) []float64) func(int) []float64 {
+	type entry struct {
+		L int
+		w []float64
+	}
+	var (
+		lock  sync.Mutex
+		cache []entry // most recently used first
+	)
+
+	// get returns the values for L, moving them to the front of cache.
+	get := func(L int) ([]float64, bool) {
+		for i, e := range cache {
+			if e.L == L {
+				copy(cache[1:i+1], cache[:i])
+				cache[0] = e
+				return e.w, true
+			}
+		}
+		return nil, false
+	}
+
+	return func(L int) []float64 {
+		lock.Lock()
+		w, ok := get(L)
+		lock.Unlock()
+
+		if !ok {
+			w = wf(L)
+
+			lock.Lock()
+			if _, ok := get(L); !ok {
+				if len(cache) < maxCached {
+					cache = append(cache, entry{})
+				}
+				copy(cache[1:], cache)
+				cache[0] = entry{L, w}
+			}
+			lock.Unlock()
+		}
+
+		r := make([]float64, len(w))
+		copy(r, w)
+		return r
+	}
+}
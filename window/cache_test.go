@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestCached(t *testing.T) {
+	calls := 0
+	wf := Cached(func(L int) []float64 {
+		calls++
+		return Hann(L)
+	})
+
+	for i := 0; i < 3; i++ {
+		o := wf(16)
+		if !dsputils.PrettyClose(o, Hann(16)) {
+			t.Error("Cached error\noutput:", o, "\nexpected:", Hann(16))
+		}
+		// Modifying the result must not affect later calls.
+		o[0] = 10
+	}
+	wf(8)
+
+	if calls != 2 {
+		t.Error("Cached: expected 2 calls, got", calls)
+	}
+}
+
+func TestCachedBound(t *testing.T) {
+	calls := 0
+	wf := Cached(func(L int) []float64 {
+		calls++
+		return Hann(L)
+	})
+
+	// Only the most recently used lengths are kept.
+	for L := 1; L <= 3*maxCached; L++ {
+		wf(L)
+	}
+	wf(3 * maxCached)
+	if calls != 3*maxCached {
+		t.Error("Cached: expected", 3*maxCached, "calls, got", calls)
+	}
+	wf(1)
+	if calls != 3*maxCached+1 {
+		t.Error("Cached: expected", 3*maxCached+1, "calls, got", calls)
+	}
+}
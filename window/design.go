@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+)
+
+// KaiserBeta returns the Kaiser window beta parameter for a sidelobe (or FIR
+// stopband) attenuation of atten dB, using Kaiser's empirical formula.
+// Reference: http://www.mathworks.com/help/signal/ref/kaiserord.html
+func KaiserBeta(atten float64) float64 {
+	switch {
+	case atten > 50:
+		return 0.1102 * (atten - 8.7)
+	case atten >= 21:
+		return 0.5842*math.Pow(atten-21, 0.4) + 0.07886*(atten-21)
+	default:
+		return 0
+	}
+}
+
+// KaiserOrd returns the length L and beta of a Kaiser window achieving atten
+// dB of attenuation with a transition (or mainlobe) width of width, expressed
+// as a fraction of the Nyquist frequency (0 < width < 1). It matches
+// scipy.signal.kaiserord.
+func KaiserOrd(atten, width float64) (L int, beta float64) {
+	atten = math.Abs(atten)
+	if atten < 8 {
+		panic("attenuation too small")
+	}
+	if width <= 0 {
+		panic("width must be positive")
+	}
+
+	L = int(math.Ceil((atten-7.95)/2.285/(math.Pi*width) + 1))
+	return L, KaiserBeta(atten)
+}
+
+// Design returns a window and its length meeting a sidelobe attenuation of
+// atten dB and a mainlobe (or FIR transition) width of width, as a fraction of
+// the Nyquist frequency. The result is a Kaiser window parameterized with
+// KaiserOrd.
+func Design(atten, width float64) (Window, int) {
+	L, beta := KaiserOrd(atten, width)
+	return KaiserWindow(beta), L
+}
@@ -0,0 +1,49 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+type kaiserOrdTest struct {
+	atten, width float64
+	L            int
+	beta         float64
+}
+
+var kaiserOrdTests = []kaiserOrdTest{
+	{65, 0.048, 167, 6.20426},
+	{40, 0.1, 46, 3.3953210522614574},
+	{20, 0.2, 10, 0},
+}
+
+func TestKaiserOrd(t *testing.T) {
+	for _, v := range kaiserOrdTests {
+		L, beta := KaiserOrd(v.atten, v.width)
+		if L != v.L || !dsputils.Float64Equal(beta, v.beta) {
+			t.Error("KaiserOrd error\ninput:", v.atten, v.width, "\noutput:", L, beta, "\nexpected:", v.L, v.beta)
+		}
+
+		w, L := Design(v.atten, v.width)
+		if L != v.L || w.Name != "kaiser" || !dsputils.Float64Equal(w.Params["beta"], v.beta) {
+			t.Error("Design error\ninput:", v.atten, v.width, "\noutput:", w.Params, L)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// DPSS returns the first k L-point discrete prolate spheroidal (Slepian)
+// sequences with time-halfbandwidth product nw, and their concentration
+// ratios: the fraction of each sequence's energy within the band
+// |f| < nw/L cycles per sample. The sequences have unit energy and are
+// mutually orthogonal; the even-numbered ones are symmetric with a positive
+// sum, and the odd-numbered ones antisymmetric and positive at the start.
+// Typically k is at most 2*nw-1, beyond which the concentration drops off.
+// They are the tapers of the multitaper spectral estimate.
+//
+// The sequences are computed as eigenvectors of the tridiagonal matrix that
+// commutes with the sinc kernel, by bisection and inverse iteration.
+// Reference: http://dx.doi.org/10.1002/j.1538-7305.1978.tb02104.x
+// See also: https://docs.scipy.org/doc/scipy/reference/generated/scipy.signal.windows.dpss.html
+func DPSS(L int, nw float64, k int) (tapers [][]float64, ratios []float64) {
+	if L < 1 {
+		panic("L must be positive")
+	}
+	if nw <= 0 || nw >= float64(L)/2 {
+		panic("nw must be between 0 and L/2")
+	}
+	if k < 1 || k > L {
+		panic("k must be between 1 and L")
+	}
+
+	w := nw / float64(L)
+	c := math.Cos(2 * math.Pi * w)
+	d := make([]float64, L)
+	e := make([]float64, L-1)
+	for i := range d {
+		h := float64(L-1-2*i) / 2
+		d[i] = h * h * c
+	}
+	for i := range e {
+		e[i] = float64((i+1)*(L-1-i)) / 2
+	}
+
+	tapers = make([][]float64, k)
+	ratios = make([]float64, k)
+	for j := range tapers {
+		lambda := tridiagEigenvalue(d, e, L-1-j)
+		v := inverseIteration(d, e, lambda)
+
+		var s float64
+		for i, x := range v {
+			if j%2 == 0 {
+				s += x
+			} else {
+				s += (float64(L-1)/2 - float64(i)) * x
+			}
+		}
+		if s < 0 {
+			for i := range v {
+				v[i] = -v[i]
+			}
+		}
+		tapers[j] = v
+		ratios[j] = concentration(v, w)
+	}
+	return tapers, ratios
+}
+
+// sturm returns the number of eigenvalues of the symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e that are less than x.
+func sturm(d, e []float64, x float64) int {
+	var n int
+	q := d[0] - x
+	for i := 0; ; i++ {
+		if q < 0 {
+			n++
+		}
+		if i == len(e) {
+			return n
+		}
+		if q == 0 {
+			q = math.SmallestNonzeroFloat64
+		}
+		q = d[i+1] - x - e[i]*e[i]/q
+	}
+}
+
+// tridiagEigenvalue returns the i-th smallest eigenvalue, counting from 0,
+// of the symmetric tridiagonal matrix with diagonal d and off-diagonal e, by
+// bisection.
+func tridiagEigenvalue(d, e []float64, i int) float64 {
+	// Gershgorin bounds.
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for j, v := range d {
+		var r float64
+		if j > 0 {
+			r += math.Abs(e[j-1])
+		}
+		if j < len(e) {
+			r += math.Abs(e[j])
+		}
+		lo = math.Min(lo, v-r)
+		hi = math.Max(hi, v+r)
+	}
+	for {
+		mid := (lo + hi) / 2
+		if mid <= lo || mid >= hi {
+			return mid
+		}
+		if sturm(d, e, mid) > i {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+}
+
+// inverseIteration returns the unit eigenvector of the symmetric tridiagonal
+// matrix with diagonal d and off-diagonal e for the eigenvalue lambda.
+func inverseIteration(d, e []float64, lambda float64) []float64 {
+	n := len(d)
+	v := make([]float64, n)
+	for i := range v {
+		// An arbitrary start vector, unlikely to be orthogonal to the result.
+		v[i] = 1 + float64(i%7)/7
+	}
+	if n == 1 {
+		v[0] = 1
+		return v
+	}
+
+	dl := make([]float64, n-1)
+	dd := make([]float64, n)
+	du := make([]float64, n-1)
+	du2 := make([]float64, n)
+	swap := make([]bool, n-1)
+
+	// LU factorization of the shifted matrix with partial pivoting, as in
+	// LAPACK's dgttrf.
+	copy(dl, e)
+	copy(du, e)
+	for i := range dd {
+		dd[i] = d[i] - lambda
+	}
+	for i := 0; i < n-1; i++ {
+		if math.Abs(dd[i]) >= math.Abs(dl[i]) {
+			if dd[i] == 0 {
+				dd[i] = math.SmallestNonzeroFloat64
+			}
+			f := dl[i] / dd[i]
+			dl[i] = f
+			dd[i+1] -= f * du[i]
+		} else {
+			f := dd[i] / dl[i]
+			dd[i] = dl[i]
+			dl[i] = f
+			t := du[i]
+			du[i] = dd[i+1]
+			dd[i+1] = t - f*dd[i+1]
+			if i < n-2 {
+				du2[i] = du[i+1]
+				du[i+1] = -f * du[i+1]
+			}
+			swap[i] = true
+		}
+	}
+	if dd[n-1] == 0 {
+		dd[n-1] = math.SmallestNonzeroFloat64
+	}
+
+	for iter := 0; iter < 3; iter++ {
+		for i := 0; i < n-1; i++ {
+			if swap[i] {
+				v[i], v[i+1] = v[i+1], v[i]-dl[i]*v[i+1]
+			} else {
+				v[i+1] -= dl[i] * v[i]
+			}
+		}
+		v[n-1] /= dd[n-1]
+		v[n-2] = (v[n-2] - du[n-2]*v[n-1]) / dd[n-2]
+		for i := n - 3; i >= 0; i-- {
+			v[i] = (v[i] - du[i]*v[i+1] - du2[i]*v[i+2]) / dd[i]
+		}
+
+		var norm float64
+		for _, x := range v {
+			norm += x * x
+		}
+		norm = math.Sqrt(norm)
+		for i := range v {
+			v[i] /= norm
+		}
+	}
+	return v
+}
+
+// concentration returns the fraction of the energy of v in the band
+// |f| < w, from its autocorrelation r: 2w r[0] + 2 Σ r[m] sin(2πwm)/(πm).
+func concentration(v []float64, w float64) float64 {
+	n := len(v)
+	X := fft.FFTReal(dsputils.ZeroPadF(v, dsputils.NextPowerOf2(2*n)))
+	for i, x := range X {
+		X[i] = complex(real(x)*real(x)+imag(x)*imag(x), 0)
+	}
+	r := fft.IFFT(X)
+
+	s := 2 * w * real(r[0])
+	for m := 1; m < n; m++ {
+		s += 2 * real(r[m]) * math.Sin(2*math.Pi*w*float64(m)) / (math.Pi * float64(m))
+	}
+	return s
+}
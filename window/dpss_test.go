@@ -0,0 +1,70 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestDPSS(t *testing.T) {
+	// Eigenvectors of the 16x16 sinc matrix for nw = 2.5, computed
+	// independently with the Jacobi eigenvalue algorithm.
+	tapers := [][]float64{
+		{0.007082725726060191, 0.027520528793286703, 0.06878098610536935, 0.13398202265568818, 0.21845710315559572, 0.3087595071578883, 0.385571424305091, 0.4298711279297098, 0.4298711279294499, 0.3855714243043856, 0.308759507156932, 0.2184571031546236, 0.13398202265489229, 0.06878098610484233, 0.02752052879301602, 0.007082725725967364},
+		{0.03432151752287854, 0.10186197071861014, 0.2001586589638961, 0.3036170967691005, 0.37198501003472856, 0.3667794238553171, 0.2707721531275634, 0.09990890742571694, -0.09990890742686964, -0.27077215312858544, -0.36677942385611545, -0.3719850100352701, -0.3036170967694111, -0.20015865896403864, -0.10186197071865549, -0.034321517522884336},
+		{0.11087345837822075, 0.23776451344806124, 0.345847447890371, 0.3707406300556977, 0.27640800564477613, 0.08380556856575382, -0.13084462965623783, -0.2712396806675142, -0.2712396806675059, -0.13084462965621335, 0.08380556856578891, 0.27640800564481494, 0.3707406300557344, 0.3458474478903998, 0.23776451344807936, 0.11087345837822937},
+		{0.26292688659610686, 0.3765627216284875, 0.357270203807736, 0.19109917256382197, -0.04549996411303678, -0.2249951717380809, -0.24718533814720367, -0.1054296507940309, 0.10542965079402096, 0.24718533814719706, 0.2249951717380797, 0.045499964113040234, -0.1910991725638158, -0.3572702038077303, -0.37656272162848353, -0.26292688659610536},
+	}
+	ratios := []float64{0.9999984138562424, 0.9998936965828739, 0.9970049366432132, 0.9570277890278117}
+
+	v, r := DPSS(16, 2.5, 4)
+	for i := range tapers {
+		if !dsputils.PrettyClose(v[i], tapers[i]) {
+			t.Error("DPSS error\ntaper:", i, "\noutput:", v[i], "\nexpected:", tapers[i])
+		}
+	}
+	if !dsputils.PrettyClose(r, ratios) {
+		t.Error("DPSS ratios error\noutput:", r, "\nexpected:", ratios)
+	}
+}
+
+func TestDPSSOrthonormal(t *testing.T) {
+	v, r := DPSS(1000, 4, 7)
+	for i := range v {
+		for j := range v {
+			var dot float64
+			for n := range v[i] {
+				dot += v[i][n] * v[j][n]
+			}
+			e := 0.0
+			if i == j {
+				e = 1
+			}
+			if math.Abs(dot-e) > 1e-9 {
+				t.Error("DPSS orthonormality error\ntapers:", i, j, "\noutput:", dot, "\nexpected:", e)
+			}
+		}
+	}
+	for i := 1; i < len(r); i++ {
+		if r[i] >= r[i-1] || r[i] > 1 {
+			t.Error("DPSS ratios not decreasing:", r)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+	"math/cmplx"
+)
+
+// Metrics computed by these functions follow the definitions in:
+// Harris, F. J. "On the Use of Windows for Harmonic Analysis with the
+// Discrete Fourier Transform." Proceedings of the IEEE 66.1 (1978).
+
+// CoherentGain returns the coherent gain of the window values w: the mean of
+// w, which is the factor by which a windowed sinusoid's amplitude is reduced.
+// Dividing an amplitude spectrum by the coherent gain corrects for it.
+func CoherentGain(w []float64) float64 {
+	if len(w) == 0 {
+		return 0
+	}
+
+	var sum float64
+	for _, v := range w {
+		sum += v
+	}
+	return sum / float64(len(w))
+}
+
+// ENBW returns the equivalent noise bandwidth of the window values w in
+// frequency bins. Multiply by the bin width (Fs / len(w)) to get Hz.
+func ENBW(w []float64) float64 {
+	var sum, sum2 float64
+	for _, v := range w {
+		sum += v
+		sum2 += v * v
+	}
+	return float64(len(w)) * sum2 / (sum * sum)
+}
+
+// ScallopingLoss returns the scalloping loss of the window values w in dB:
+// the reduction in amplitude of a sinusoid half way between two frequency
+// bins relative to one centered on a bin.
+func ScallopingLoss(w []float64) float64 {
+	var sum float64
+	var half complex128
+	N := float64(len(w))
+	for n, v := range w {
+		sum += v
+		half += complex(v, 0) * cmplx.Exp(complex(0, -math.Pi*float64(n)/N))
+	}
+	return -20 * math.Log10(cmplx.Abs(half)/sum)
+}
+
+// ProcessingGain returns the processing gain of the window values w in dB:
+// the improvement in signal to noise ratio of a sinusoid from the DFT of len(w)
+// windowed samples, which is len(w) / ENBW(w).
+func ProcessingGain(w []float64) float64 {
+	return 10 * math.Log10(float64(len(w))/ENBW(w))
+}
@@ -0,0 +1,52 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+	"testing"
+)
+
+type metricsTest struct {
+	name                string
+	w                   []float64
+	cg, enbw, sl, pgain float64
+}
+
+var metricsTests = []metricsTest{
+	{"rectangular", Rectangular(64), 1, 1, 3.9224, 10 * math.Log10(64)},
+	{"hann", HannPeriodic(64), 0.5, 1.5, 1.4236, 10 * math.Log10(64/1.5)},
+	{"hamming", HammingPeriodic(64), 0.54, 1.3628, 1.7514, 10 * math.Log10(64/1.3628)},
+}
+
+func TestMetrics(t *testing.T) {
+	const tol = 1e-3
+	for _, v := range metricsTests {
+		if o := CoherentGain(v.w); math.Abs(o-v.cg) > tol {
+			t.Error(v.name, "coherent gain error\noutput:", o, "\nexpected:", v.cg)
+		}
+		if o := ENBW(v.w); math.Abs(o-v.enbw) > tol {
+			t.Error(v.name, "ENBW error\noutput:", o, "\nexpected:", v.enbw)
+		}
+		if o := ScallopingLoss(v.w); math.Abs(o-v.sl) > tol {
+			t.Error(v.name, "scalloping loss error\noutput:", o, "\nexpected:", v.sl)
+		}
+		if o := ProcessingGain(v.w); math.Abs(o-v.pgain) > tol {
+			t.Error(v.name, "processing gain error\noutput:", o, "\nexpected:", v.pgain)
+		}
+	}
+}
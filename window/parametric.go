@@ -0,0 +1,245 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+
+	"github.com/mjibson/go-dsp/fft"
+)
+
+// Window describes a window function together with its parameters, so
+// parametric windows (Kaiser, Tukey, Chebyshev) can be passed around like
+// the parameterless ones. Values has the func(int) []float64 signature used
+// throughout go-dsp, so w.Values can be passed anywhere a window function is
+// expected.
+type Window struct {
+	// Name is the name of the window, e.g. "kaiser".
+	Name string
+
+	// Params holds the window's named parameters, e.g. {"beta": 8.6}. It is
+	// informational; the parameters are already bound into Func.
+	Params map[string]float64
+
+	// Periodic selects the periodic (DFT-even) form of the window.
+	Periodic bool
+
+	// Func returns the L-point symmetric window.
+	Func func(int) []float64
+}
+
+// Values returns the L-point window.
+func (w Window) Values(L int) []float64 {
+	if w.Periodic {
+		return Periodic(w.Func)(L)
+	}
+	return w.Func(L)
+}
+
+// Window returns the registered window as a Window.
+func (e Entry) Window() Window {
+	return Window{Name: e.Name, Func: e.Symmetric}
+}
+
+// KaiserWindow returns a Window for Kaiser with parameter beta.
+func KaiserWindow(beta float64) Window {
+	return Window{
+		Name:   "kaiser",
+		Params: map[string]float64{"beta": beta},
+		Func:   func(L int) []float64 { return Kaiser(L, beta) },
+	}
+}
+
+// TukeyWindow returns a Window for Tukey with parameter alpha.
+func TukeyWindow(alpha float64) Window {
+	return Window{
+		Name:   "tukey",
+		Params: map[string]float64{"alpha": alpha},
+		Func:   func(L int) []float64 { return Tukey(L, alpha) },
+	}
+}
+
+// ChebyshevWindow returns a Window for Chebyshev with sidelobe attenuation
+// at dB.
+func ChebyshevWindow(at float64) Window {
+	return Window{
+		Name:   "chebyshev",
+		Params: map[string]float64{"attenuation": at},
+		Func:   func(L int) []float64 { return Chebyshev(L, at) },
+	}
+}
+
+// HannPoissonWindow returns a Window for HannPoisson with parameter alpha.
+func HannPoissonWindow(alpha float64) Window {
+	return Window{
+		Name:   "hannpoisson",
+		Params: map[string]float64{"alpha": alpha},
+		Func:   func(L int) []float64 { return HannPoisson(L, alpha) },
+	}
+}
+
+// Kaiser returns an L-point Kaiser window with shape parameter beta. beta = 0
+// is a rectangular window; larger values trade a wider mainlobe for lower
+// sidelobes.
+// Reference: http://www.mathworks.com/help/signal/ref/kaiser.html
+func Kaiser(L int, beta float64) []float64 {
+	r := make([]float64, L)
+
+	if L == 1 {
+		r[0] = 1
+		return r
+	}
+
+	N := float64(L - 1)
+	den := besselI0(beta)
+	for n := range r {
+		k := 2*float64(n)/N - 1
+		r[n] = besselI0(beta*math.Sqrt(1-k*k)) / den
+	}
+
+	return r
+}
+
+// besselI0 returns the zeroth order modified Bessel function of the first
+// kind, evaluated by its power series.
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; k < 500; k++ {
+		term *= q / float64(k*k)
+		sum += term
+		if term < sum*1e-17 {
+			break
+		}
+	}
+	return sum
+}
+
+// Tukey returns an L-point Tukey (tapered cosine) window. alpha is the
+// fraction of the window inside the cosine tapers: alpha = 0 is a rectangular
+// window and alpha = 1 is a Hann window.
+// Reference: http://www.mathworks.com/help/signal/ref/tukeywin.html
+func Tukey(L int, alpha float64) []float64 {
+	r := make([]float64, L)
+
+	if L == 1 {
+		r[0] = 1
+		return r
+	}
+
+	if alpha <= 0 {
+		return Rectangular(L)
+	} else if alpha >= 1 {
+		return Hann(L)
+	}
+
+	N := float64(L - 1)
+	edge := alpha * N / 2
+	for n := range r {
+		x := float64(n)
+		if x > N/2 {
+			x = N - x
+		}
+		if x < edge {
+			r[n] = 0.5 * (1 - math.Cos(math.Pi*x/edge))
+		} else {
+			r[n] = 1
+		}
+	}
+
+	return r
+}
+
+// Chebyshev returns an L-point Dolph-Chebyshev window with sidelobes at
+// dB below the mainlobe. It has the narrowest mainlobe for a given sidelobe
+// level.
+// Reference: http://www.mathworks.com/help/signal/ref/chebwin.html
+func Chebyshev(L int, at float64) []float64 {
+	if L == 1 {
+		return []float64{1}
+	}
+
+	order := float64(L - 1)
+	beta := math.Cosh(math.Acosh(math.Pow(10, math.Abs(at)/20)) / order)
+
+	// Chebyshev polynomial samples in the frequency domain.
+	p := make([]complex128, L)
+	for k := range p {
+		x := beta * math.Cos(math.Pi*float64(k)/float64(L))
+		var v float64
+		if x > 1 {
+			v = math.Cosh(order * math.Acosh(x))
+		} else if x < -1 {
+			v = float64(2*(L%2)-1) * math.Cosh(order*math.Acosh(-x))
+		} else {
+			v = math.Cos(order * math.Acos(x))
+		}
+		p[k] = complex(v, 0)
+
+		if L%2 == 0 {
+			sin, cos := math.Sincos(math.Pi / float64(L) * float64(k))
+			p[k] *= complex(cos, sin)
+		}
+	}
+
+	f := fft.FFT(p)
+	r := make([]float64, L)
+	if L%2 == 1 {
+		n := (L + 1) / 2
+		for i := 0; i < n; i++ {
+			r[n-1+i] = real(f[i])
+			r[n-1-i] = real(f[i])
+		}
+	} else {
+		n := L / 2
+		for i := 0; i < n; i++ {
+			r[n+i] = real(f[i+1])
+			r[n-1-i] = real(f[i+1])
+		}
+	}
+
+	var max float64
+	for _, v := range r {
+		max = math.Max(max, v)
+	}
+	for i := range r {
+		r[i] /= max
+	}
+
+	return r
+}
+
+// HannPoisson returns an L-point Hann-Poisson window: a Hann window multiplied
+// by a Poisson (exponential) window with decay alpha. For alpha >= 2 its
+// transform has no sidelobes, only a monotonic decay, which suits peak-finding
+// algorithms that hill-climb in the frequency domain. alpha = 0 is a Hann
+// window.
+// Reference: https://en.wikipedia.org/wiki/Window_function#Hann%E2%80%93Poisson_window
+func HannPoisson(L int, alpha float64) []float64 {
+	r := Hann(L)
+
+	if L == 1 {
+		return r
+	}
+
+	N := float64(L - 1)
+	for n := range r {
+		r[n] *= math.Exp(-alpha * math.Abs(N-2*float64(n)) / N)
+	}
+
+	return r
+}
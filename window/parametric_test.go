@@ -0,0 +1,99 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+	"math/cmplx"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+	"github.com/mjibson/go-dsp/fft"
+)
+
+type parametricTest struct {
+	name string
+	w    Window
+	in   int
+	out  []float64
+}
+
+var parametricTests = []parametricTest{
+	{"kaiser", KaiserWindow(0), 4, []float64{1, 1, 1, 1}},
+	{"kaiser", KaiserWindow(1), 3, []float64{0.789848315, 1, 0.789848315}},
+	{"tukey", TukeyWindow(0), 4, []float64{1, 1, 1, 1}},
+	{"tukey", TukeyWindow(1), 5, []float64{0, 0.5, 1, 0.5, 0}},
+	{"tukey", TukeyWindow(0.5), 9, []float64{0, 0.5, 1, 1, 1, 1, 1, 0.5, 0}},
+	{"hann-poisson", HannPoissonWindow(0), 5, []float64{0, 0.5, 1, 0.5, 0}},
+	{"hann-poisson", HannPoissonWindow(2), 5, []float64{0, 0.183939720585721, 1, 0.183939720585721, 0}},
+	{"hann-poisson", HannPoissonWindow(2), 1, []float64{1}},
+	{"periodic tukey", Window{Func: TukeyWindow(1).Func, Periodic: true}, 4, []float64{0, 0.5, 1, 0.5}},
+}
+
+func TestParametric(t *testing.T) {
+	for _, v := range parametricTests {
+		o := v.w.Values(v.in)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error(v.name, "error\ninput:", v.in, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+
+	e, _ := ByName("hann")
+	if o := e.Window().Values(5); !dsputils.PrettyClose(o, Hann(5)) {
+		t.Error("entry window error\noutput:", o, "\nexpected:", Hann(5))
+	}
+}
+
+func TestChebyshev(t *testing.T) {
+	for _, L := range []int{1, 2, 31, 32} {
+		for _, at := range []float64{50, 100} {
+			w := ChebyshevWindow(at).Values(L)
+			if len(w) != L {
+				t.Fatal("chebyshev length error:", len(w), L)
+			}
+
+			var max float64
+			for i, v := range w {
+				max = math.Max(max, v)
+				if !dsputils.Float64Equal(v, w[L-1-i]) {
+					t.Error("chebyshev not symmetric:", L, at, w)
+					break
+				}
+			}
+			if !dsputils.Float64Equal(max, 1) {
+				t.Error("chebyshev max error:", L, at, max)
+			}
+			if L < 31 {
+				continue
+			}
+
+			// All sidelobes are at -at dB.
+			f := fft.FFTReal(dsputils.ZeroPadF(w, 4096))
+			peak := cmplx.Abs(f[0])
+			i := 1
+			for ; i < len(f)/2 && cmplx.Abs(f[i]) < cmplx.Abs(f[i-1]); i++ {
+			}
+			var side float64
+			for ; i < len(f)/2; i++ {
+				side = math.Max(side, cmplx.Abs(f[i]))
+			}
+			if db := 20 * math.Log10(side/peak); math.Abs(db+at) > 0.5 {
+				t.Error("chebyshev sidelobe error:", L, at, db)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Entry describes a window function known to the registry.
+type Entry struct {
+	// Name is the canonical name of the window.
+	Name string
+
+	// Symmetric returns an L-point symmetric window.
+	Symmetric func(int) []float64
+
+	// Periodic returns an L-point periodic (DFT-even) window.
+	Periodic func(int) []float64
+}
+
+var (
+	registryLock sync.RWMutex
+	registry     = map[string]Entry{}
+)
+
+func init() {
+	Register("rectangular", Rectangular)
+	Register("boxcar", Rectangular)
+	Register("hamming", Hamming)
+	Register("hann", Hann)
+	Register("hanning", Hann)
+	Register("bartlett", Bartlett)
+	Register("flattop", FlatTop)
+	Register("blackman", Blackman)
+	Register("blackmanharris", BlackmanHarris)
+}
+
+// normalizeName lower-cases name and removes spaces, hyphens, and
+// underscores, so "Blackman-Harris" and "blackman_harris" are equivalent.
+func normalizeName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '_':
+			return -1
+		}
+		return r
+	}, strings.ToLower(name))
+}
+
+// Register adds the symmetric window function wf to the registry under name,
+// replacing any existing window with the same name. The periodic variant is
+// derived with Periodic. Window values are cached by length, as by Cached.
+func Register(name string, wf func(int) []float64) {
+	if wf == nil {
+		panic("window: Register of nil window function")
+	}
+
+	registryLock.Lock()
+	defer registryLock.Unlock()
+
+	registry[normalizeName(name)] = Entry{
+		Name:      name,
+		Symmetric: Cached(wf),
+		Periodic:  Cached(Periodic(wf)),
+	}
+}
+
+// ByName returns the registered window with the given name. Names are
+// case-insensitive, and spaces, hyphens, and underscores are ignored.
+func ByName(name string) (Entry, error) {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	e, ok := registry[normalizeName(name)]
+	if !ok {
+		return Entry{}, fmt.Errorf("window: unknown window: %q", name)
+	}
+	return e, nil
+}
+
+// Names returns the sorted names of all registered windows.
+func Names() []string {
+	registryLock.RLock()
+	defer registryLock.RUnlock()
+
+	r := make([]string, 0, len(registry))
+	for _, e := range registry {
+		r = append(r, e.Name)
+	}
+	sort.Strings(r)
+	return r
+}
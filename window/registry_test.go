@@ -0,0 +1,71 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestByName(t *testing.T) {
+	for _, name := range []string{"hann", "Hann", "HANNING", "blackman-harris", "Blackman_Harris", "flat top"} {
+		if _, err := ByName(name); err != nil {
+			t.Error("ByName error:", name, err)
+		}
+	}
+
+	if _, err := ByName("nonexistent"); err == nil {
+		t.Error("ByName: expected error for unknown window")
+	}
+
+	e, _ := ByName("hann")
+	if !dsputils.PrettyClose(e.Symmetric(10), Hann(10)) {
+		t.Error("ByName: hann symmetric mismatch")
+	}
+	if !dsputils.PrettyClose(e.Periodic(10), HannPeriodic(10)) {
+		t.Error("ByName: hann periodic mismatch")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	triangle := func(L int) []float64 {
+		return Bartlett(L)
+	}
+	Register("My Triangle", triangle)
+
+	e, err := ByName("mytriangle")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if e.Name != "My Triangle" {
+		t.Error("Register: name mismatch:", e.Name)
+	}
+	if !dsputils.PrettyClose(e.Symmetric(5), Bartlett(5)) {
+		t.Error("Register: window mismatch")
+	}
+
+	found := false
+	for _, n := range Names() {
+		if n == "My Triangle" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Names: registered window not listed")
+	}
+}
@@ -28,6 +28,18 @@ func Apply(x []float64, windowFunction func(int) []float64) {
 	}
 }
 
+// Taper applies a cosine rolloff to the first and last fraction p of x, in
+// place, leaving the middle unchanged. p must be in [0, 0.5]; for example,
+// p = 0.05 tapers the first and last 5% of x. This is useful for conditioning
+// long records before an FFT.
+func Taper(x []float64, p float64) {
+	if p < 0 || p > 0.5 {
+		panic("taper fraction out of range")
+	}
+
+	Apply(x, TukeyWindow(2*p).Values)
+}
+
 // Rectangular returns an L-point rectangular window (all values are 1).
 func Rectangular(L int) []float64 {
 	r := make([]float64, L)
@@ -39,40 +51,47 @@ func Rectangular(L int) []float64 {
 	return r
 }
 
-// Hamming returns an L-point symmetric Hamming window.
-// Reference: http://www.mathworks.com/help/signal/ref/hamming.html
-func Hamming(L int) []float64 {
+// CosineSum returns an L-point symmetric generalized cosine-sum window with
+// coefficients a:
+//
+//	w[n] = a[0] - a[1]*cos(2πn/N) + a[2]*cos(4πn/N) - a[3]*cos(6πn/N) + ...
+//
+// where N = L - 1. Hamming, Hann, Blackman, and FlatTop are all cosine-sum
+// windows.
+// Reference: https://en.wikipedia.org/wiki/Window_function#Cosine-sum_windows
+func CosineSum(L int, a []float64) []float64 {
 	r := make([]float64, L)
 
 	if L == 1 {
 		r[0] = 1
-	} else {
-		N := L - 1
-		coef := math.Pi * 2 / float64(N)
-		for n := 0; n <= N; n++ {
-			r[n] = 0.54 - 0.46*math.Cos(coef*float64(n))
+		return r
+	}
+
+	N := L - 1
+	coef := 2 * math.Pi / float64(N)
+
+	for n := 0; n <= N; n++ {
+		factor := float64(n) * coef
+		sign := float64(1)
+		for k, v := range a {
+			r[n] += sign * v * math.Cos(float64(k)*factor)
+			sign = -sign
 		}
 	}
 
 	return r
 }
 
+// Hamming returns an L-point symmetric Hamming window.
+// Reference: http://www.mathworks.com/help/signal/ref/hamming.html
+func Hamming(L int) []float64 {
+	return CosineSum(L, []float64{0.54, 0.46})
+}
+
 // Hann returns an L-point Hann window.
 // Reference: http://www.mathworks.com/help/signal/ref/hann.html
 func Hann(L int) []float64 {
-	r := make([]float64, L)
-
-	if L == 1 {
-		r[0] = 1
-	} else {
-		N := L - 1
-		coef := 2 * math.Pi / float64(N)
-		for n := 0; n <= N; n++ {
-			r[n] = 0.5 * (1 - math.Cos(coef*float64(n)))
-		}
-	}
-
-	return r
+	return CosineSum(L, []float64{0.5, 0.5})
 }
 
 // Bartlett returns an L-point Bartlett window.
@@ -100,53 +119,66 @@ func Bartlett(L int) []float64 {
 // FlatTop returns an L-point flat top window.
 // Reference: http://www.mathworks.com/help/signal/ref/flattopwin.html
 func FlatTop(L int) []float64 {
-	const (
-		alpha0 = float64(0.21557895)
-		alpha1 = float64(0.41663158)
-		alpha2 = float64(0.277263158)
-		alpha3 = float64(0.083578947)
-		alpha4 = float64(0.006947368)
-	)
+	return CosineSum(L, []float64{
+		0.21557895,
+		0.41663158,
+		0.277263158,
+		0.083578947,
+		0.006947368,
+	})
+}
 
-	r := make([]float64, L)
+// Blackman returns an L-point Blackman window
+// Reference: http://www.mathworks.com/help/signal/ref/blackman.html
+func Blackman(L int) []float64 {
+	return CosineSum(L, []float64{0.42, 0.5, 0.08})
+}
 
-	if L == 1 {
-		r[0] = 1
-		return r
+// BlackmanHarris returns an L-point minimum 4-term Blackman-Harris window.
+// Reference: http://www.mathworks.com/help/signal/ref/blackmanharris.html
+func BlackmanHarris(L int) []float64 {
+	return CosineSum(L, []float64{0.35875, 0.48829, 0.14128, 0.01168})
+}
+
+// Periodic returns a window function that produces the L-point periodic
+// (DFT-even) version of the symmetric window wf. A periodic window is the
+// first L points of an (L+1)-point symmetric window, which is the correct
+// choice for spectral analysis.
+func Periodic(wf func(int) []float64) func(int) []float64 {
+	return func(L int) []float64 {
+		if L == 1 {
+			return wf(1)
+		}
+		return wf(L + 1)[:L]
 	}
+}
 
-	N := L - 1
-	coef := 2 * math.Pi / float64(N)
+// HammingPeriodic returns an L-point periodic Hamming window.
+func HammingPeriodic(L int) []float64 {
+	return Periodic(Hamming)(L)
+}
 
-	for n := 0; n <= N; n++ {
-		factor := float64(n) * coef
+// HannPeriodic returns an L-point periodic Hann window.
+func HannPeriodic(L int) []float64 {
+	return Periodic(Hann)(L)
+}
 
-		term0 := alpha0
-		term1 := alpha1 * math.Cos(factor)
-		term2 := alpha2 * math.Cos(2*factor)
-		term3 := alpha3 * math.Cos(3*factor)
-		term4 := alpha4 * math.Cos(4*factor)
+// BartlettPeriodic returns an L-point periodic Bartlett window.
+func BartlettPeriodic(L int) []float64 {
+	return Periodic(Bartlett)(L)
+}
 
-		r[n] = term0 - term1 + term2 - term3 + term4
-	}
+// FlatTopPeriodic returns an L-point periodic flat top window.
+func FlatTopPeriodic(L int) []float64 {
+	return Periodic(FlatTop)(L)
+}
 
-	return r
+// BlackmanPeriodic returns an L-point periodic Blackman window.
+func BlackmanPeriodic(L int) []float64 {
+	return Periodic(Blackman)(L)
 }
 
-// Blackman returns an L-point Blackman window
-// Reference: http://www.mathworks.com/help/signal/ref/blackman.html
-func Blackman(L int) []float64 {
-	r := make([]float64, L)
-	if L == 1 {
-		r[0] = 1
-	} else {
-		N := L - 1
-		for n := 0; n <= N; n++ {
-			const term0 = 0.42
-			term1 := -0.5 * math.Cos(2*math.Pi*float64(n)/float64(N))
-			term2 := 0.08 * math.Cos(4*math.Pi*float64(n)/float64(N))
-			r[n] = term0 + term1 + term2
-		}
-	}
-	return r
+// BlackmanHarrisPeriodic returns an L-point periodic Blackman-Harris window.
+func BlackmanHarrisPeriodic(L int) []float64 {
+	return Periodic(BlackmanHarris)(L)
 }
@@ -0,0 +1,104 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+)
+
+// Outer2D returns a rows x cols separable 2-D window: the outer product of
+// the rows-point and cols-point windows of wf. It is used to taper images
+// before FFT2 to reduce edge leakage.
+func Outer2D(wf func(int) []float64, rows, cols int) [][]float64 {
+	wr := wf(rows)
+	wc := wf(cols)
+
+	r := make([][]float64, rows)
+	for i := range r {
+		r[i] = make([]float64, cols)
+		for j := range r[i] {
+			r[i][j] = wr[i] * wc[j]
+		}
+	}
+
+	return r
+}
+
+// Radial2D returns a rows x cols circularly symmetric 2-D window. The value
+// at each point is the 1-D window wf evaluated at the point's normalized
+// distance from the center, so the window is 1 at the center and falls to the
+// edge value of wf on the inscribed ellipse. Points outside that ellipse are
+// 0.
+func Radial2D(wf func(int) []float64, rows, cols int) [][]float64 {
+	// Sample the 1-D window densely and linearly interpolate into it.
+	half := 2 * rows
+	if cols > rows {
+		half = 2 * cols
+	}
+	w := wf(2*half + 1)
+
+	cr := float64(rows-1) / 2
+	cc := float64(cols-1) / 2
+
+	r := make([][]float64, rows)
+	for i := range r {
+		r[i] = make([]float64, cols)
+		for j := range r[i] {
+			var dr, dc float64
+			if cr > 0 {
+				dr = (float64(i) - cr) / cr
+			}
+			if cc > 0 {
+				dc = (float64(j) - cc) / cc
+			}
+
+			rho := math.Hypot(dr, dc)
+			if rho > 1 {
+				continue
+			}
+
+			pos := float64(half) * (1 + rho)
+			k := int(pos)
+			if k >= len(w)-1 {
+				r[i][j] = w[len(w)-1]
+				continue
+			}
+			frac := pos - float64(k)
+			r[i][j] = w[k]*(1-frac) + w[k+1]*frac
+		}
+	}
+
+	return r
+}
+
+// Apply2D applies the separable 2-D window of windowFunction to the matrix x,
+// which must not be ragged.
+func Apply2D(x [][]float64, windowFunction func(int) []float64) {
+	if len(x) == 0 {
+		return
+	}
+
+	for i, row := range Outer2D(windowFunction, len(x), len(x[0])) {
+		if len(x[i]) != len(row) {
+			panic("ragged input array")
+		}
+
+		for j, w := range row {
+			x[i][j] *= w
+		}
+	}
+}
@@ -0,0 +1,69 @@
+/*
+ * Copyright (c) 2012 Matt Jibson <matt.jibson@gmail.com>
+ *
+ * Permission to use, copy, modify, and distribute this software for any
+ * purpose with or without fee is hereby granted, provided that the above
+ * copyright notice and this permission notice appear in all copies.
+ *
+ * THE SOFTWARE IS PROVIDED "AS IS" AND THE AUTHOR DISCLAIMS ALL WARRANTIES
+ * WITH REGARD TO THIS SOFTWARE INCLUDING ALL IMPLIED WARRANTIES OF
+ * MERCHANTABILITY AND FITNESS. IN NO EVENT SHALL THE AUTHOR BE LIABLE FOR
+ * ANY SPECIAL, DIRECT, INDIRECT, OR CONSEQUENTIAL DAMAGES OR ANY DAMAGES
+ * WHATSOEVER RESULTING FROM LOSS OF USE, DATA OR PROFITS, WHETHER IN AN
+ * ACTION OF CONTRACT, NEGLIGENCE OR OTHER TORTIOUS ACTION, ARISING OUT OF
+ * OR IN CONNECTION WITH THE USE OR PERFORMANCE OF THIS SOFTWARE.
+ */
+
+package window
+
+import (
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/dsputils"
+)
+
+func TestOuter2D(t *testing.T) {
+	o := Outer2D(Hann, 3, 5)
+	e := [][]float64{
+		{0, 0, 0, 0, 0},
+		{0, 0.5, 1, 0.5, 0},
+		{0, 0, 0, 0, 0},
+	}
+	if !dsputils.PrettyClose2F(o, e) {
+		t.Error("Outer2D error\noutput:", o, "\nexpected:", e)
+	}
+
+	x := [][]float64{
+		{1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1},
+		{1, 1, 1, 1, 1},
+	}
+	Apply2D(x, Hann)
+	if !dsputils.PrettyClose2F(x, e) {
+		t.Error("Apply2D error\noutput:", x, "\nexpected:", e)
+	}
+}
+
+func TestRadial2D(t *testing.T) {
+	o := Radial2D(Hann, 5, 5)
+	e := [][]float64{
+		{0, 0, 0, 0, 0},
+		{0, 0.25, 0.5, 0.25, 0},
+		{0, 0.5, 1, 0.5, 0},
+		{0, 0.25, 0.5, 0.25, 0},
+		{0, 0, 0, 0, 0},
+	}
+	// Diagonal points are at rho = sqrt(2)/2, interpolated from a sampled
+	// window, so compare them against the continuous Hann value loosely.
+	d := 0.5 * (1 + math.Cos(math.Pi*math.Sqrt2/2))
+	for _, p := range [][2]int{{1, 1}, {1, 3}, {3, 1}, {3, 3}} {
+		if math.Abs(o[p[0]][p[1]]-d) > 0.01 {
+			t.Error("Radial2D diagonal error\noutput:", o[p[0]][p[1]], "\nexpected:", d)
+		}
+		e[p[0]][p[1]] = o[p[0]][p[1]]
+	}
+	if !dsputils.PrettyClose2F(o, e) {
+		t.Error("Radial2D error\noutput:", o, "\nexpected:", e)
+	}
+}
@@ -92,3 +92,63 @@ func TestWindowFunctions(t *testing.T) {
 		}
 	}
 }
+
+type cosineSumTest struct {
+	in  int
+	a   []float64
+	out []float64
+}
+
+var cosineSumTests = []cosineSumTest{
+	{1, []float64{0.5, 0.5}, []float64{1}},
+	{4, []float64{1}, []float64{1, 1, 1, 1}},
+	{5, []float64{0.5, 0.5}, []float64{0, 0.5, 1, 0.5, 0}},
+	{5, []float64{0.4, 0.4, 0.2}, []float64{0.2, 0.2, 1, 0.2, 0.2}},
+}
+
+func TestCosineSum(t *testing.T) {
+	for _, v := range cosineSumTests {
+		o := CosineSum(v.in, v.a)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error("CosineSum error\ninput:", v.in, v.a, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestPeriodic(t *testing.T) {
+	tests := []struct {
+		in   int
+		wf   func(int) []float64
+		name string
+		out  []float64
+	}{
+		{1, HannPeriodic, "hann", []float64{1}},
+		{4, HannPeriodic, "hann", []float64{0, 0.5, 1, 0.5}},
+		{8, HannPeriodic, "hann", []float64{0, 0.146446609406726, 0.5, 0.853553390593274, 1, 0.853553390593274, 0.5, 0.146446609406726}},
+		{4, HammingPeriodic, "hamming", []float64{0.08, 0.54, 1, 0.54}},
+		{4, BartlettPeriodic, "bartlett", []float64{0, 0.5, 1, 0.5}},
+		{4, BlackmanPeriodic, "blackman", []float64{0, 0.34, 1, 0.34}},
+		{4, FlatTopPeriodic, "flatTop", []float64{-0.0004210510000000013, -0.05473684000000003, 1, -0.05473684000000003}},
+	}
+	for _, v := range tests {
+		o := v.wf(v.in)
+		if !dsputils.PrettyClose(o, v.out) {
+			t.Error(v.name, "periodic error\ninput:", v.in, "\noutput:", o, "\nexpected:", v.out)
+		}
+	}
+}
+
+func TestTaper(t *testing.T) {
+	x := []float64{2, 2, 2, 2, 2, 2, 2, 2, 2}
+	Taper(x, 0.25)
+	e := []float64{0, 1, 2, 2, 2, 2, 2, 1, 0}
+	if !dsputils.PrettyClose(x, e) {
+		t.Error("Taper error\noutput:", x, "\nexpected:", e)
+	}
+
+	x = []float64{1, 1, 1}
+	Taper(x, 0)
+	if !dsputils.PrettyClose(x, []float64{1, 1, 1}) {
+		t.Error("Taper error\noutput:", x)
+	}
+}